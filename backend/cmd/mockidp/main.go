@@ -0,0 +1,36 @@
+// Command mockidp is a minimal OpenID Connect provider for exercising the SSO
+// flow locally. It auto-approves every authorization request as the identity
+// given by the MOCK_IDP_SUB, MOCK_IDP_EMAIL and MOCK_IDP_NAME variables. Its
+// issuer is plain http on localhost, so run the API with
+// SSO_ALLOW_INSECURE_ISSUERS=true to register it.
+package main
+
+import (
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/juazsh/managrr/internal/oidc/oidctest"
+)
+
+func main() {
+	port := getEnv("MOCK_IDP_PORT", "9000")
+
+	provider, err := oidctest.New(getEnv("MOCK_IDP_ISSUER", "http://localhost:"+port))
+	if err != nil {
+		log.Fatal("Failed to generate signing key:", err)
+	}
+	provider.Subject = getEnv("MOCK_IDP_SUB", provider.Subject)
+	provider.Email = getEnv("MOCK_IDP_EMAIL", provider.Email)
+	provider.Name = getEnv("MOCK_IDP_NAME", provider.Name)
+
+	log.Printf("Mock IdP listening on :%s with issuer %s", port, provider.Issuer)
+	log.Fatal(http.ListenAndServe(":"+port, provider))
+}
+
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,227 @@
+// Package apierror defines the error envelope every endpoint responds with.
+//
+// Errors carry a stable machine-readable code alongside the human message so
+// clients can branch on what went wrong without matching text:
+//
+//	{"error": "Project not found", "code": "project_not_found"}
+//
+// Validation failures list the offending fields in details. The "error" key is
+// kept from the original responses so existing clients keep working.
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Code identifies a kind of error. Codes are part of the API and must not
+// change once published.
+type Code string
+
+// Codes used when nothing more specific applies, one per status.
+const (
+	CodeBadRequest         Code = "bad_request"
+	CodeUnauthorized       Code = "unauthorized"
+	CodeForbidden          Code = "forbidden"
+	CodeNotFound           Code = "not_found"
+	CodeConflict           Code = "conflict"
+	CodeGone               Code = "gone"
+	CodePayloadTooLarge    Code = "payload_too_large"
+	CodeTooManyRequests    Code = "too_many_requests"
+	CodeInternal           Code = "internal_error"
+	CodeBadGateway         Code = "bad_gateway"
+	CodeServiceUnavailable Code = "service_unavailable"
+)
+
+const (
+	CodeValidationFailed    Code = "validation_failed"
+	CodeInvalidRequestBody  Code = "invalid_request_body"
+	CodeInvalidQuery        Code = "invalid_query"
+	CodeInvalidID           Code = "invalid_id"
+	CodeInvalidReference    Code = "invalid_reference"
+	CodeAlreadyExists       Code = "already_exists"
+	CodeInUse               Code = "in_use"
+	CodeAccessDenied        Code = "access_denied"
+	CodeAdminRequired       Code = "admin_required"
+	CodeInvalidToken        Code = "invalid_token"
+	CodeInvalidCredentials  Code = "invalid_credentials"
+	CodeAccountDisabled     Code = "account_disabled"
+	CodeEmailNotVerified    Code = "email_not_verified"
+	CodeEmailTaken          Code = "email_taken"
+	CodeFileTooLarge        Code = "file_too_large"
+	CodeUnsupportedFileType Code = "unsupported_file_type"
+
+	CodeProjectNotFound      Code = "project_not_found"
+	CodeContractNotFound     Code = "contract_not_found"
+	CodeEmployeeNotFound     Code = "employee_not_found"
+	CodeEstimateNotFound     Code = "estimate_not_found"
+	CodeExpenseNotFound      Code = "expense_not_found"
+	CodePaymentNotFound      Code = "payment_not_found"
+	CodeWorkLogNotFound      Code = "work_log_not_found"
+	CodeInvitationNotFound   Code = "invitation_not_found"
+	CodeMemberNotFound       Code = "member_not_found"
+	CodeUserNotFound         Code = "user_not_found"
+	CodeExchangeRateNotFound Code = "exchange_rate_not_found"
+	CodePhotoNotFound        Code = "photo_not_found"
+
+	CodeContractRequired     Code = "contract_required"
+	CodeInvalidContract      Code = "invalid_contract"
+	CodeAlreadyCheckedIn     Code = "already_checked_in"
+	CodeAlreadyCheckedOut    Code = "already_checked_out"
+	CodeWorkLogOverlap       Code = "work_log_overlap"
+	CodeCheckOutBeforeIn     Code = "check_out_before_check_in"
+	CodeStillCheckedIn       Code = "still_checked_in"
+	CodeAlreadyApproved      Code = "already_approved"
+	CodePaymentNotPending    Code = "payment_not_pending"
+	CodeInvitationPending    Code = "invitation_pending"
+	CodeInvitationExpired    Code = "invitation_expired"
+	CodeTransferPending      Code = "transfer_pending"
+	CodeTransferExpired      Code = "transfer_expired"
+	CodeAlreadyMember        Code = "already_member"
+	CodeSlugTaken            Code = "slug_taken"
+	CodeNotAssignedToProject Code = "not_assigned_to_project"
+	CodeExchangeRateMissing  Code = "exchange_rate_missing"
+	CodeCurrencyLocked       Code = "currency_locked"
+
+	CodeInvalidIdempotencyKey Code = "invalid_idempotency_key"
+	CodeIdempotencyKeyReused  Code = "idempotency_key_reused"
+	CodeRequestInProgress     Code = "request_in_progress"
+	CodeVersionConflict       Code = "version_conflict"
+
+	CodePersistedQueryNotFound Code = "persisted_query_not_found"
+	CodeQueryTooComplex        Code = "query_too_complex"
+)
+
+// FieldError explains why one request field was rejected. Field is the JSON
+// or form name the client sent.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is an API error and the JSON body it is written as.
+type Error struct {
+	Status  int          `json:"-"`
+	Message string       `json:"error"`
+	Code    Code         `json:"code"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return string(e.Code) + ": " + e.Message
+}
+
+// New returns an error with the given status, code and message.
+func New(status int, code Code, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// FromStatus returns an error with the default code for status.
+func FromStatus(status int, message string) *Error {
+	return New(status, CodeForStatus(status), message)
+}
+
+// WithMessage returns a copy of e with a different message. The predefined
+// errors below are shared, so they are never modified in place.
+func (e *Error) WithMessage(message string) *Error {
+	c := *e
+	c.Message = message
+	return &c
+}
+
+// WithDetails returns a copy of e with details appended.
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	c := *e
+	c.Details = append(append([]FieldError(nil), e.Details...), details...)
+	return &c
+}
+
+// Invalid reports a single invalid field.
+func Invalid(field, message string) *Error {
+	return New(http.StatusBadRequest, CodeValidationFailed, message).
+		WithDetails(FieldError{Field: field, Message: message})
+}
+
+// CodeForStatus maps an HTTP status to its default code.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodeVersionConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusBadGateway:
+		return CodeBadGateway
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// Write sends err as the response.
+func Write(w http.ResponseWriter, err *Error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(err.Status)
+	json.NewEncoder(w).Encode(err)
+}
+
+var (
+	ErrInvalidRequestBody = New(http.StatusBadRequest, CodeInvalidRequestBody, "Invalid request body")
+	ErrAccessDenied       = New(http.StatusForbidden, CodeAccessDenied, "Access denied")
+	ErrAdminRequired      = New(http.StatusForbidden, CodeAdminRequired, "Admin access required")
+	ErrUnauthenticated    = New(http.StatusUnauthorized, CodeUnauthorized, "User not found in context")
+	ErrInvalidCredentials = New(http.StatusUnauthorized, CodeInvalidCredentials, "Invalid email or password")
+	ErrAccountDisabled    = New(http.StatusForbidden, CodeAccountDisabled, "This account has been disabled")
+	ErrEmailNotVerified   = New(http.StatusForbidden, CodeEmailNotVerified, "Please verify your email before logging in")
+	ErrEmailTaken         = New(http.StatusConflict, CodeEmailTaken, "Email already registered")
+	ErrFileTooLarge       = New(http.StatusBadRequest, CodeFileTooLarge, "File too large. Maximum size is 5MB")
+	ErrUnsupportedImage   = New(http.StatusBadRequest, CodeUnsupportedFileType, "Only JPG, JPEG, and PNG files are allowed")
+	ErrNotAssigned        = New(http.StatusForbidden, CodeNotAssignedToProject, "You are not assigned to this project")
+
+	ErrProjectNotFound      = New(http.StatusNotFound, CodeProjectNotFound, "Project not found")
+	ErrContractNotFound     = New(http.StatusNotFound, CodeContractNotFound, "Contract not found")
+	ErrEmployeeNotFound     = New(http.StatusNotFound, CodeEmployeeNotFound, "Employee not found")
+	ErrEstimateNotFound     = New(http.StatusNotFound, CodeEstimateNotFound, "Estimate not found")
+	ErrExpenseNotFound      = New(http.StatusNotFound, CodeExpenseNotFound, "Expense not found")
+	ErrPaymentNotFound      = New(http.StatusNotFound, CodePaymentNotFound, "Payment summary not found")
+	ErrWorkLogNotFound      = New(http.StatusNotFound, CodeWorkLogNotFound, "Work log not found")
+	ErrInvitationNotFound   = New(http.StatusNotFound, CodeInvitationNotFound, "Invitation not found")
+	ErrMemberNotFound       = New(http.StatusNotFound, CodeMemberNotFound, "Member not found")
+	ErrUserNotFound         = New(http.StatusNotFound, CodeUserNotFound, "User not found")
+	ErrExchangeRateNotFound = New(http.StatusNotFound, CodeExchangeRateNotFound, "Exchange rate not found")
+	ErrPhotoNotFound        = New(http.StatusNotFound, CodePhotoNotFound, "Photo not found")
+
+	ErrContractRequired = New(http.StatusBadRequest, CodeContractRequired, "contract_id is required").
+				WithDetails(FieldError{Field: "contract_id", Message: "is required"})
+	ErrInvalidContract = New(http.StatusBadRequest, CodeInvalidContract, "Invalid contract_id for this project").
+				WithDetails(FieldError{Field: "contract_id", Message: "does not belong to this project"})
+	ErrAlreadyCheckedIn  = New(http.StatusConflict, CodeAlreadyCheckedIn, "You are already checked in to this project")
+	ErrAlreadyCheckedOut = New(http.StatusConflict, CodeAlreadyCheckedOut, "Already checked out from this work log")
+	ErrWorkLogOverlap    = New(http.StatusConflict, CodeWorkLogOverlap, "The time overlaps another work log")
+	ErrCheckOutBeforeIn  = New(http.StatusConflict, CodeCheckOutBeforeIn, "Check-out time is before the check-in time")
+	ErrStillCheckedIn    = New(http.StatusConflict, CodeStillCheckedIn, "Cannot approve a work log that is still checked in")
+	ErrWorkLogApproved   = New(http.StatusConflict, CodeAlreadyApproved, "Work log is already approved")
+	ErrVersionConflict   = New(http.StatusPreconditionFailed, CodeVersionConflict, "This record was changed by someone else. Reload it and try again.")
+	ErrPaymentNotPending = New(http.StatusConflict, CodePaymentNotPending, "Payment is not in pending status")
+	ErrCurrencyLocked    = New(http.StatusConflict, CodeCurrencyLocked, "The currency cannot be changed once the project has expenses or payments")
+
+	// Apollo clients look for this exact message before resending a
+	// persisted query with its text.
+	ErrPersistedQueryNotFound = New(http.StatusBadRequest, CodePersistedQueryNotFound, "PersistedQueryNotFound")
+)
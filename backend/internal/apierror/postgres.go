@@ -0,0 +1,60 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Postgres error codes the API turns into client errors.
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+	pqNotNullViolation    = "23502"
+	pqCheckViolation      = "23514"
+	pqInvalidText         = "22P02"
+	pqStringTooLong       = "22001"
+	pqNumericOutOfRange   = "22003"
+)
+
+// IsUniqueViolation reports whether err is a unique constraint violation,
+// optionally on a specific constraint or index.
+func IsUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != pqUniqueViolation {
+		return false
+	}
+	return constraint == "" || pqErr.Constraint == constraint
+}
+
+// FromDB maps the Postgres errors a client can cause to an API error. It
+// returns nil for anything else, which callers treat as an internal error.
+func FromDB(err error) *Error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		return New(http.StatusConflict, CodeAlreadyExists, "A record with these values already exists")
+	case pqForeignKeyViolation:
+		if strings.Contains(pqErr.Detail, "is still referenced") {
+			return New(http.StatusConflict, CodeInUse, "This record is still in use")
+		}
+		return New(http.StatusBadRequest, CodeInvalidReference, "A referenced record does not exist")
+	case pqNotNullViolation:
+		return Invalid(pqErr.Column, pqErr.Column+" is required")
+	case pqCheckViolation:
+		return New(http.StatusBadRequest, CodeValidationFailed, "A value is out of range")
+	case pqInvalidText:
+		return New(http.StatusBadRequest, CodeInvalidID, "Invalid ID format")
+	case pqStringTooLong:
+		return New(http.StatusBadRequest, CodeValidationFailed, "A value is too long")
+	case pqNumericOutOfRange:
+		return New(http.StatusBadRequest, CodeValidationFailed, "A number is too large")
+	}
+	return nil
+}
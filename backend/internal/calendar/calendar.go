@@ -0,0 +1,80 @@
+// Package calendar handles the time zones of users and projects and the
+// calendar dates bucketed in them.
+//
+// Instants are stored as UTC timestamps. Anything grouped by day or week, such
+// as weekly summaries, the day a work log belongs to or the date on a report,
+// is worked out in the zone of the project or user it is for, so a check-in at
+// 8pm in Denver is not counted towards the next day.
+package calendar
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	// Embedded so zones resolve on hosts without a system zoneinfo database.
+	_ "time/tzdata"
+)
+
+// DateLayout is how calendar dates are written in requests and responses.
+const DateLayout = "2006-01-02"
+
+// Zone is an IANA time zone name such as America/New_York.
+type Zone string
+
+// UTC is the zone of users and projects that have not chosen one.
+const UTC Zone = "UTC"
+
+var (
+	ErrUnknownZone = errors.New("must be an IANA time zone such as America/New_York")
+	ErrInvalidDate = errors.New("must be a date in YYYY-MM-DD format")
+)
+
+// ParseZone reads an IANA time zone name. "Local" is rejected, since it
+// means whatever zone the server happens to run in.
+func ParseZone(s string) (Zone, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "Local" {
+		return "", ErrUnknownZone
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return "", ErrUnknownZone
+	}
+	return Zone(loc.String()), nil
+}
+
+// Location returns the zone's location, or UTC if the name is not one.
+func (z Zone) Location() *time.Location {
+	if z == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(string(z))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Today returns the date it is in the zone at t.
+func (z Zone) Today(t time.Time) string {
+	return t.In(z.Location()).Format(DateLayout)
+}
+
+// StartOfWeek returns midnight on the Sunday on or before t, in the zone.
+func (z Zone) StartOfWeek(t time.Time) time.Time {
+	t = t.In(z.Location())
+	day := t.AddDate(0, 0, -int(t.Weekday()))
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// ParseDate reads a calendar date in YYYY-MM-DD format and returns it in
+// that form, so "2024-3-9" and "2024-02-30" are rejected rather than stored.
+func ParseDate(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return "", ErrInvalidDate
+	}
+	return t.Format(DateLayout), nil
+}
@@ -46,6 +46,12 @@ func GetDB() *sql.DB {
 	return db
 }
 
+// SetDB replaces the connection GetDB returns. Tests use it to swap in a
+// mock.
+func SetDB(conn *sql.DB) {
+	db = conn
+}
+
 func Close() error {
 	if db != nil {
 		return db.Close()
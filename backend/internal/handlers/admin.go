@@ -0,0 +1,691 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// adminSearchLimit caps every back-office search; admins are expected to
+// narrow the query rather than page through the whole user base.
+const adminSearchLimit = 50
+
+const adminUserColumns = `
+	id, email, name, phone, user_type, email_verified, time_zone, disabled_at, disabled_reason, created_at, updated_at
+`
+
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func scanAdminUser(row rowScanner) (*models.User, error) {
+	var u models.User
+	err := row.Scan(&u.ID, &u.Email, &u.Name, &u.Phone, &u.UserType, &u.EmailVerified, &u.TimeZone,
+		&u.DisabledAt, &u.DisabledReason, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// recordAdminAction appends an entry to the admin audit log. Callers that
+// change state pass their transaction so the action and its audit entry
+// commit together.
+func recordAdminAction(e execer, r *http.Request, adminID string, action models.AdminAction, targetUserID, targetProjectID string, details map[string]interface{}) error {
+	var detailsJSON []byte
+	if len(details) > 0 {
+		var err error
+		detailsJSON, err = json.Marshal(details)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := e.Exec(`
+		INSERT INTO admin_audit_logs (admin_id, action, target_user_id, target_project_id, details, ip_address)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, adminID, action, nilIfEmpty(targetUserID), nilIfEmpty(targetProjectID), detailsJSON, middleware.ClientIP(r))
+	return err
+}
+
+// loadAdminTarget fetches the user an admin action applies to and writes the
+// error response when it cannot.
+func loadAdminTarget(w http.ResponseWriter, q queryRower, userID string) (*models.User, bool) {
+	user, err := scanAdminUser(q.QueryRow("SELECT "+adminUserColumns+" FROM users WHERE id = $1", userID))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrUserNotFound)
+		return nil, false
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch user")
+		return nil, false
+	}
+	return user, true
+}
+
+func AdminSearchUsers(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	search := strings.TrimSpace(r.URL.Query().Get("q"))
+	userType := r.URL.Query().Get("user_type")
+
+	if userType != "" && userType != string(models.UserTypeHouseOwner) && userType != string(models.UserTypeContractor) &&
+		userType != string(models.UserTypeEmployee) && userType != string(models.UserTypeAdmin) {
+		respondWithAPIError(w, apierror.Invalid("user_type", "Invalid user_type filter"))
+		return
+	}
+
+	db := database.GetDB()
+
+	rows, err := db.Query(`
+		SELECT `+adminUserColumns+`
+		FROM users
+		WHERE ($1 = '' OR email ILIKE '%' || $1 || '%' OR name ILIKE '%' || $1 || '%' OR phone ILIKE '%' || $1 || '%'
+		       OR id::text = $1)
+		  AND ($2 = '' OR user_type = $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, search, userType, adminSearchLimit)
+	if err != nil {
+		log.Printf("ERROR AdminSearchUsers: Failed to query users: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to search users")
+		return
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		u, err := scanAdminUser(rows)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan user")
+			return
+		}
+		users = append(users, *u)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating users")
+		return
+	}
+
+	err = recordAdminAction(db, r, userCtx.UserID, models.AdminActionSearchUsers, "", "", map[string]interface{}{
+		"query":     search,
+		"user_type": userType,
+	})
+	if err != nil {
+		log.Printf("ERROR AdminSearchUsers: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, users)
+}
+
+func AdminGetUser(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	user, ok := loadAdminTarget(w, db, targetID)
+	if !ok {
+		return
+	}
+
+	detail := models.AdminUserDetail{
+		User:      *user,
+		Projects:  []models.AdminProjectSummary{},
+		Contracts: []models.AdminContractSummary{},
+	}
+
+	// Projects the user owns or is a member of.
+	projectRows, err := db.Query(`
+		SELECT p.id, p.title, p.status, p.owner_id, u.name, u.email, p.created_at
+		FROM projects p
+		JOIN users u ON p.owner_id = u.id
+		WHERE p.deleted_at IS NULL
+		  AND (p.owner_id = $1
+		       OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $1))
+		ORDER BY p.created_at DESC
+	`, targetID)
+	if err != nil {
+		log.Printf("ERROR AdminGetUser: Failed to query projects: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+	defer projectRows.Close()
+
+	for projectRows.Next() {
+		var p models.AdminProjectSummary
+		if err := projectRows.Scan(&p.ID, &p.Title, &p.Status, &p.OwnerID, &p.OwnerName, &p.OwnerEmail, &p.CreatedAt); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan project")
+			return
+		}
+		detail.Projects = append(detail.Projects, p)
+	}
+
+	if err = projectRows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating projects")
+		return
+	}
+
+	// Contracts on the user's own projects plus those held by the
+	// organization they belong to or work for.
+	contractRows, err := db.Query(`
+		SELECT c.id, c.project_id, p.title, c.organization_id, o.name, c.status, c.created_at
+		FROM contracts c
+		JOIN projects p ON c.project_id = p.id
+		JOIN organizations o ON c.organization_id = o.id
+		WHERE p.deleted_at IS NULL
+		  AND (c.owner_id = $1
+		       OR c.organization_id IN (
+		              SELECT organization_id FROM organization_members WHERE user_id = $1
+		              UNION
+		              SELECT organization_id FROM employees WHERE user_id = $1 AND deleted_at IS NULL
+		          ))
+		ORDER BY c.created_at DESC
+	`, targetID)
+	if err != nil {
+		log.Printf("ERROR AdminGetUser: Failed to query contracts: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch contracts")
+		return
+	}
+	defer contractRows.Close()
+
+	for contractRows.Next() {
+		var c models.AdminContractSummary
+		if err := contractRows.Scan(&c.ID, &c.ProjectID, &c.ProjectTitle, &c.OrganizationID, &c.OrganizationName, &c.Status, &c.CreatedAt); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan contract")
+			return
+		}
+		detail.Contracts = append(detail.Contracts, c)
+	}
+
+	if err = contractRows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating contracts")
+		return
+	}
+
+	if err := recordAdminAction(db, r, userCtx.UserID, models.AdminActionViewUser, targetID, "", nil); err != nil {
+		log.Printf("ERROR AdminGetUser: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, detail)
+}
+
+func AdminSearchProjects(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	search := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	db := database.GetDB()
+
+	rows, err := db.Query(`
+		SELECT p.id, p.title, p.status, p.owner_id, u.name, u.email, p.created_at
+		FROM projects p
+		JOIN users u ON p.owner_id = u.id
+		WHERE p.deleted_at IS NULL
+		  AND ($1 = '' OR p.title ILIKE '%' || $1 || '%' OR p.address ILIKE '%' || $1 || '%'
+		       OR u.email ILIKE '%' || $1 || '%' OR p.id::text = $1)
+		ORDER BY p.created_at DESC
+		LIMIT $2
+	`, search, adminSearchLimit)
+	if err != nil {
+		log.Printf("ERROR AdminSearchProjects: Failed to query projects: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to search projects")
+		return
+	}
+	defer rows.Close()
+
+	projects := []models.AdminProjectSummary{}
+	for rows.Next() {
+		var p models.AdminProjectSummary
+		if err := rows.Scan(&p.ID, &p.Title, &p.Status, &p.OwnerID, &p.OwnerName, &p.OwnerEmail, &p.CreatedAt); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan project")
+			return
+		}
+		projects = append(projects, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating projects")
+		return
+	}
+
+	err = recordAdminAction(db, r, userCtx.UserID, models.AdminActionSearchProjects, "", "", map[string]interface{}{
+		"query": search,
+	})
+	if err != nil {
+		log.Printf("ERROR AdminSearchProjects: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, projects)
+}
+
+func AdminDisableUser(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	var req models.AdminReasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		respondWithAPIError(w, apierror.Invalid("reason", "Reason is required"))
+		return
+	}
+
+	if targetID == userCtx.UserID {
+		respondWithError(w, http.StatusBadRequest, "You cannot disable your own account")
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	user, err := scanAdminUser(tx.QueryRow(`
+		UPDATE users SET disabled_at = COALESCE(disabled_at, NOW()), disabled_reason = $2
+		WHERE id = $1
+		RETURNING `+adminUserColumns, targetID, req.Reason))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrUserNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR AdminDisableUser: Failed to disable user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to disable user")
+		return
+	}
+
+	err = recordAdminAction(tx, r, userCtx.UserID, models.AdminActionDisableUser, targetID, "", map[string]interface{}{
+		"reason": req.Reason,
+	})
+	if err != nil {
+		log.Printf("ERROR AdminDisableUser: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	log.Printf("SUCCESS AdminDisableUser: Admin %s disabled user %s", userCtx.UserID, targetID)
+	respondWithJSON(w, http.StatusOK, user)
+}
+
+func AdminEnableUser(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	user, err := scanAdminUser(tx.QueryRow(`
+		UPDATE users SET disabled_at = NULL, disabled_reason = NULL
+		WHERE id = $1
+		RETURNING `+adminUserColumns, targetID))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrUserNotFound)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR AdminEnableUser: Failed to enable user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to enable user")
+		return
+	}
+
+	if err := recordAdminAction(tx, r, userCtx.UserID, models.AdminActionEnableUser, targetID, "", nil); err != nil {
+		log.Printf("ERROR AdminEnableUser: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	log.Printf("SUCCESS AdminEnableUser: Admin %s enabled user %s", userCtx.UserID, targetID)
+	respondWithJSON(w, http.StatusOK, user)
+}
+
+func AdminResendVerification(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	user, ok := loadAdminTarget(w, db, targetID)
+	if !ok {
+		return
+	}
+
+	if user.EmailVerified {
+		respondWithError(w, http.StatusBadRequest, "Email is already verified")
+		return
+	}
+
+	token, err := utils.GenerateVerificationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate verification token")
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE users SET verification_token = $1, verification_token_expires_at = $2
+		WHERE id = $3
+	`, token, time.Now().Add(24*time.Hour), targetID)
+	if err != nil {
+		log.Printf("ERROR AdminResendVerification: Failed to store verification token: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create verification token")
+		return
+	}
+
+	if err := recordAdminAction(tx, r, userCtx.UserID, models.AdminActionResendVerification, targetID, "", nil); err != nil {
+		log.Printf("ERROR AdminResendVerification: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if err := utils.SendVerificationEmail(user.Email, token); err != nil {
+		log.Printf("ERROR AdminResendVerification: Failed to send verification email: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+
+	log.Printf("SUCCESS AdminResendVerification: Admin %s resent verification to user %s", userCtx.UserID, targetID)
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Verification email sent",
+	})
+}
+
+// AdminForcePasswordReset locks the user out of their current password and
+// API tokens and mails them a reset link.
+func AdminForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	var req models.AdminReasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		respondWithAPIError(w, apierror.Invalid("reason", "Reason is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	user, ok := loadAdminTarget(w, db, targetID)
+	if !ok {
+		return
+	}
+
+	placeholderPassword, err := utils.GenerateRandomPassword()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate password")
+		return
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(placeholderPassword), bcrypt.DefaultCost)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to process password")
+		return
+	}
+
+	resetToken, err := utils.GenerateResetToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate reset token")
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("UPDATE users SET password_hash = $1 WHERE id = $2", string(hashedPassword), targetID); err != nil {
+		log.Printf("ERROR AdminForcePasswordReset: Failed to reset password: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE password_resets SET used_at = NOW() WHERE user_id = $1 AND used_at IS NULL", targetID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to invalidate reset tokens")
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE api_tokens SET revoked_at = NOW() WHERE user_id = $1 AND revoked_at IS NULL", targetID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to revoke API tokens")
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO password_resets (user_id, reset_token, expires_at)
+		VALUES ($1, $2, $3)
+	`, targetID, resetToken, time.Now().UTC().Add(1*time.Hour))
+	if err != nil {
+		log.Printf("ERROR AdminForcePasswordReset: Failed to insert reset token: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create reset token")
+		return
+	}
+
+	err = recordAdminAction(tx, r, userCtx.UserID, models.AdminActionForcePasswordReset, targetID, "", map[string]interface{}{
+		"reason": req.Reason,
+	})
+	if err != nil {
+		log.Printf("ERROR AdminForcePasswordReset: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if err := utils.SendPasswordResetEmail(user.Email, resetToken); err != nil {
+		log.Printf("ERROR AdminForcePasswordReset: Failed to send reset email: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Password was reset but the email could not be sent")
+		return
+	}
+
+	log.Printf("SUCCESS AdminForcePasswordReset: Admin %s forced a password reset for user %s", userCtx.UserID, targetID)
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message": "Password reset email sent",
+	})
+}
+
+// AdminImpersonateUser mints a short-lived token that acts as the user. The
+// token carries the admin's ID so every write made with it is audited, and it
+// can never reach the admin endpoints.
+func AdminImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	targetID := mux.Vars(r)["id"]
+
+	var req models.AdminReasonRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		respondWithAPIError(w, apierror.Invalid("reason", "Reason is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	user, ok := loadAdminTarget(w, db, targetID)
+	if !ok {
+		return
+	}
+
+	if user.UserType == models.UserTypeAdmin {
+		respondWithError(w, http.StatusForbidden, "Admins cannot be impersonated")
+		return
+	}
+
+	if user.DisabledAt != nil {
+		respondWithError(w, http.StatusBadRequest, "Enable the account before impersonating it")
+		return
+	}
+
+	token, expiresAt, err := utils.GenerateImpersonationToken(user.ID, user.Email, string(user.UserType), userCtx.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	err = recordAdminAction(db, r, userCtx.UserID, models.AdminActionImpersonate, targetID, "", map[string]interface{}{
+		"reason":     req.Reason,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		log.Printf("ERROR AdminImpersonateUser: Failed to record audit log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+		return
+	}
+
+	log.Printf("SUCCESS AdminImpersonateUser: Admin %s is impersonating user %s", userCtx.UserID, targetID)
+	respondWithJSON(w, http.StatusOK, models.ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      *user,
+	})
+}
+
+func AdminListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	adminFilter := r.URL.Query().Get("admin_id")
+	userFilter := r.URL.Query().Get("user_id")
+	actionFilter := r.URL.Query().Get("action")
+
+	db := database.GetDB()
+
+	rows, err := db.Query(`
+		SELECT l.id, l.admin_id, u.name, l.action, l.target_user_id, l.target_project_id, l.details, l.ip_address, l.created_at
+		FROM admin_audit_logs l
+		JOIN users u ON l.admin_id = u.id
+		WHERE ($1 = '' OR l.admin_id::text = $1)
+		  AND ($2 = '' OR l.target_user_id::text = $2)
+		  AND ($3 = '' OR l.action = $3)
+		ORDER BY l.created_at DESC
+		LIMIT 200
+	`, adminFilter, userFilter, actionFilter)
+	if err != nil {
+		log.Printf("ERROR AdminListAuditLogs: Failed to query audit logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch audit logs")
+		return
+	}
+	defer rows.Close()
+
+	logs := []models.AdminAuditLog{}
+	for rows.Next() {
+		var entry models.AdminAuditLog
+		var details []byte
+		err := rows.Scan(&entry.ID, &entry.AdminID, &entry.AdminName, &entry.Action, &entry.TargetUserID,
+			&entry.TargetProjectID, &details, &entry.IPAddress, &entry.CreatedAt)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan audit log")
+			return
+		}
+		if len(details) > 0 {
+			entry.Details = details
+		}
+		logs = append(logs, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating audit logs")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, logs)
+}
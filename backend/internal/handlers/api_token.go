@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+	"github.com/lib/pq"
+)
+
+const (
+	defaultAPITokenExpiryDays = 90
+	maxAPITokenExpiryDays     = 365
+)
+
+func CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.IsImpersonated() {
+		respondWithError(w, http.StatusForbidden, "API tokens cannot be created while impersonating")
+		return
+	}
+
+	var req models.CreateAPITokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required"))
+		return
+	}
+
+	if len(req.Name) > 100 {
+		respondWithAPIError(w, apierror.Invalid("name", "Name must be at most 100 characters"))
+		return
+	}
+
+	if len(req.Scopes) == 0 {
+		respondWithAPIError(w, apierror.Invalid("scopes", "At least one scope is required"))
+		return
+	}
+
+	for _, scope := range req.Scopes {
+		if !models.ValidAPITokenScopes[models.APITokenScope(scope)] {
+			respondWithError(w, http.StatusBadRequest, "Invalid scope: "+scope)
+			return
+		}
+	}
+
+	if req.ExpiresInDays == 0 {
+		req.ExpiresInDays = defaultAPITokenExpiryDays
+	}
+
+	if req.ExpiresInDays < 1 || req.ExpiresInDays > maxAPITokenExpiryDays {
+		respondWithAPIError(w, apierror.Invalid("expires_in_days", "expires_in_days must be between 1 and 365"))
+		return
+	}
+
+	token, err := utils.GenerateAPIToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	prefix := token[:len(utils.APITokenPrefix)+8]
+	expiresAt := time.Now().UTC().AddDate(0, 0, req.ExpiresInDays)
+
+	db := database.GetDB()
+
+	query := `
+		INSERT INTO api_tokens (user_id, name, token_prefix, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, user_id, name, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+	`
+
+	var apiToken models.APIToken
+	err = db.QueryRow(query, userCtx.UserID, req.Name, prefix, utils.HashAPIToken(token), pq.Array(req.Scopes), expiresAt).
+		Scan(&apiToken.ID, &apiToken.UserID, &apiToken.Name, &apiToken.TokenPrefix, pq.Array(&apiToken.Scopes),
+			&apiToken.ExpiresAt, &apiToken.LastUsedAt, &apiToken.RevokedAt, &apiToken.CreatedAt)
+	if err != nil {
+		log.Printf("ERROR CreateAPIToken: Failed to store token: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create token")
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, models.CreateAPITokenResponse{
+		Token:    token,
+		APIToken: apiToken,
+	})
+}
+
+func ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	db := database.GetDB()
+
+	query := `
+		SELECT id, user_id, name, token_prefix, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.Query(query, userCtx.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch tokens")
+		return
+	}
+	defer rows.Close()
+
+	tokens := []models.APIToken{}
+	for rows.Next() {
+		var t models.APIToken
+		err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.TokenPrefix, pq.Array(&t.Scopes),
+			&t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt, &t.CreatedAt)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan token")
+			return
+		}
+		tokens = append(tokens, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating tokens")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, tokens)
+}
+
+func RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	tokenID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	result, err := db.Exec(`
+		UPDATE api_tokens
+		SET revoked_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+	`, tokenID, userCtx.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to revoke token")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithError(w, http.StatusNotFound, "Token not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
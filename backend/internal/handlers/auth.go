@@ -8,6 +8,8 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
@@ -20,68 +22,134 @@ var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-
 func Register(w http.ResponseWriter, r *http.Request) {
 	var req models.RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if !emailRegex.MatchString(req.Email) {
-		respondWithError(w, http.StatusBadRequest, "Invalid email format")
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
 		return
 	}
 
 	if len(req.Password) < 8 {
-		respondWithError(w, http.StatusBadRequest, "Password must be at least 8 characters")
+		respondWithAPIError(w, apierror.Invalid("password", "Password must be at least 8 characters"))
 		return
 	}
 
 	if req.Name == "" {
-		respondWithError(w, http.StatusBadRequest, "Name is required")
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required"))
 		return
 	}
 
 	if req.UserType != models.UserTypeHouseOwner &&
 		req.UserType != models.UserTypeContractor &&
 		req.UserType != models.UserTypeEmployee {
-		respondWithError(w, http.StatusBadRequest, "Invalid user type")
+		respondWithAPIError(w, apierror.Invalid("user_type", "Invalid user type"))
 		return
 	}
 
+	timeZone := calendar.UTC
+	if req.TimeZone != "" {
+		var err error
+		timeZone, err = calendar.ParseZone(string(req.TimeZone))
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("time_zone", "time_zone "+err.Error()))
+			return
+		}
+	}
+
+	db := database.GetDB()
+
+	var invitation *models.ContractorInvitation
+	if req.InvitationToken != nil && *req.InvitationToken != "" {
+		if req.UserType != models.UserTypeContractor {
+			respondWithError(w, http.StatusBadRequest, "Invitations can only be accepted by contractor accounts")
+			return
+		}
+
+		inv, status, message := findPendingInvitation(db, *req.InvitationToken, req.Email)
+		if inv == nil {
+			respondWithError(w, status, message)
+			return
+		}
+		invitation = inv
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to process password")
 		return
 	}
 
-	verificationToken, err := utils.GenerateVerificationToken()
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to generate verification token")
-		return
+	// An invitation link proves ownership of the email address, so invited
+	// contractors skip the separate verification step.
+	emailVerified := invitation != nil
+	var verificationToken *string
+	var tokenExpiry *time.Time
+	if !emailVerified {
+		token, err := utils.GenerateVerificationToken()
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to generate verification token")
+			return
+		}
+		expiry := time.Now().Add(24 * time.Hour)
+		verificationToken = &token
+		tokenExpiry = &expiry
 	}
 
-	tokenExpiry := time.Now().Add(24 * time.Hour)
-
-	db := database.GetDB()
 	var user models.User
 
 	query := `
-		INSERT INTO users (email, password_hash, name, phone, user_type, email_verified, verification_token, verification_token_expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, email, name, phone, user_type, email_verified, created_at, updated_at
+		INSERT INTO users (email, password_hash, name, phone, user_type, email_verified, verification_token, verification_token_expires_at, time_zone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, email, name, phone, user_type, email_verified, time_zone, created_at, updated_at
 	`
 
-	err = db.QueryRow(query, req.Email, string(hashedPassword), req.Name, req.Phone, req.UserType, false, verificationToken, tokenExpiry).
-		Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.CreatedAt, &user.UpdatedAt)
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(query, req.Email, string(hashedPassword), req.Name, req.Phone, req.UserType, emailVerified, verificationToken, tokenExpiry, timeZone).
+		Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.TimeZone, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
-		if err.Error() == "pq: duplicate key value violates unique constraint \"users_email_key\"" {
-			respondWithError(w, http.StatusConflict, "Email already registered")
+		if apierror.IsUniqueViolation(err, "users_email_key") {
+			respondWithAPIError(w, apierror.ErrEmailTaken)
+			return
+		}
+		respondWithDBError(w, err, "Failed to create user")
+		return
+	}
+
+	if user.UserType == models.UserTypeContractor {
+		if _, err := createOrganization(tx, user.ID, user.Name); err != nil {
+			log.Printf("ERROR Register: Failed to create organization: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to create organization")
 			return
 		}
+	}
+
+	if err := tx.Commit(); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to create user")
 		return
 	}
 
-	if err := utils.SendVerificationEmail(user.Email, verificationToken); err != nil {
+	if invitation != nil {
+		if err := acceptContractorInvitation(db, invitation, user.ID); err != nil {
+			log.Printf("ERROR Register: Failed to accept invitation %s: %v", invitation.ID, err)
+		}
+
+		respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+			"message": "Registration successful. You can now log in.",
+			"user":    user,
+		})
+		return
+	}
+
+	if err := utils.SendVerificationEmail(user.Email, *verificationToken); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to send verification email")
 		return
 	}
@@ -95,7 +163,7 @@ func Register(w http.ResponseWriter, r *http.Request) {
 func Login(w http.ResponseWriter, r *http.Request) {
 	var req models.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
@@ -109,17 +177,17 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	var passwordHash string
 
 	query := `
-		SELECT id, email, password_hash, name, phone, user_type, email_verified, created_at, updated_at
+		SELECT id, email, password_hash, name, phone, user_type, email_verified, time_zone, disabled_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
 
 	err := db.QueryRow(query, req.Email).
-		Scan(&user.ID, &user.Email, &passwordHash, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.CreatedAt, &user.UpdatedAt)
+		Scan(&user.ID, &user.Email, &passwordHash, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.TimeZone, &user.DisabledAt, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			respondWithError(w, http.StatusUnauthorized, "Invalid email or password")
+			respondWithAPIError(w, apierror.ErrInvalidCredentials)
 			return
 		}
 		respondWithError(w, http.StatusInternalServerError, "Failed to query user")
@@ -127,12 +195,17 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password)); err != nil {
-		respondWithError(w, http.StatusUnauthorized, "Invalid email or password")
+		respondWithAPIError(w, apierror.ErrInvalidCredentials)
+		return
+	}
+
+	if user.DisabledAt != nil {
+		respondWithAPIError(w, apierror.ErrAccountDisabled)
 		return
 	}
 
 	if !user.EmailVerified {
-		respondWithError(w, http.StatusForbidden, "Please verify your email before logging in")
+		respondWithAPIError(w, apierror.ErrEmailNotVerified)
 		return
 	}
 
@@ -151,7 +224,7 @@ func Login(w http.ResponseWriter, r *http.Request) {
 func VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 	if token == "" {
-		respondWithError(w, http.StatusBadRequest, "Verification token is required")
+		respondWithAPIError(w, apierror.Invalid("token", "Verification token is required"))
 		return
 	}
 
@@ -174,7 +247,7 @@ func VerifyEmail(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			respondWithError(w, http.StatusBadRequest, "Invalid or expired verification token")
+			respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired verification token"))
 			return
 		}
 		respondWithError(w, http.StatusInternalServerError, "Failed to verify email")
@@ -187,13 +260,27 @@ func VerifyEmail(w http.ResponseWriter, r *http.Request) {
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
-	respondWithJSON(w, code, map[string]string{"error": message})
+	respondWithAPIError(w, apierror.FromStatus(code, message))
+}
+
+func respondWithAPIError(w http.ResponseWriter, err *apierror.Error) {
+	respondWithJSON(w, err.Status, err)
+}
+
+// respondWithDBError reports constraint violations and malformed values as
+// client errors and anything else as a 500 with message.
+func respondWithDBError(w http.ResponseWriter, err error, message string) {
+	if apiErr := apierror.FromDB(err); apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+	respondWithError(w, http.StatusInternalServerError, message)
 }
 
 func GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -201,17 +288,17 @@ func GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	var user models.User
 
 	query := `
-		SELECT id, email, name, phone, user_type, created_at, updated_at
+		SELECT id, email, name, phone, user_type, time_zone, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
 
 	err := db.QueryRow(query, userCtx.UserID).
-		Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.CreatedAt, &user.UpdatedAt)
+		Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.TimeZone, &user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			respondWithError(w, http.StatusNotFound, "User not found")
+			respondWithAPIError(w, apierror.ErrUserNotFound)
 			return
 		}
 		respondWithError(w, http.StatusInternalServerError, "Failed to query user")
@@ -221,6 +308,67 @@ func GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, user)
 }
 
+// UpdateCurrentUser changes the caller's name or time zone. The zone is used
+// for the caller's own summaries and as the default for projects they create.
+func UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req models.UpdateCurrentUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Name != nil && *req.Name == "" {
+		respondWithAPIError(w, apierror.Invalid("name", "Name cannot be empty"))
+		return
+	}
+
+	if req.TimeZone != nil {
+		timeZone, err := calendar.ParseZone(string(*req.TimeZone))
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("time_zone", "time_zone "+err.Error()))
+			return
+		}
+		*req.TimeZone = timeZone
+	}
+
+	db := database.GetDB()
+	var user models.User
+
+	err := db.QueryRow(`
+		UPDATE users
+		SET name = COALESCE($1, name),
+		    time_zone = COALESCE($2, time_zone),
+		    updated_at = NOW()
+		WHERE id = $3
+		RETURNING id, email, name, phone, user_type, time_zone, created_at, updated_at
+	`, req.Name, req.TimeZone, userCtx.UserID).
+		Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.TimeZone, &user.CreatedAt, &user.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrUserNotFound)
+		return
+	}
+	if err != nil {
+		respondWithDBError(w, err, "Failed to update user")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, user)
+}
+
+// userTimeZone returns the zone a user's own summaries are counted in.
+func userTimeZone(q queryRower, userID string) (calendar.Zone, error) {
+	var zone calendar.Zone
+	err := q.QueryRow("SELECT time_zone FROM users WHERE id = $1", userID).Scan(&zone)
+	return zone, err
+}
+
 func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
@@ -284,10 +432,15 @@ type ProjectParticipants struct {
 func getProjectParticipants(db *sql.DB, projectID string) (*ProjectParticipants, error) {
 	var participants ProjectParticipants
 	err := db.QueryRow(`
-		SELECT p.title, p.owner_id, u1.email, u1.name, p.contractor_id, u2.email, u2.name
+		SELECT p.title, p.owner_id, u1.email, u1.name, c.contractor_id, u2.email, u2.name
 		FROM projects p
 		LEFT JOIN users u1 ON p.owner_id = u1.id
-		LEFT JOIN users u2 ON p.contractor_id = u2.id
+		LEFT JOIN LATERAL (
+		    SELECT contractor_id FROM contracts
+		    WHERE project_id = p.id AND status != 'terminated'
+		    ORDER BY created_at ASC LIMIT 1
+		) c ON true
+		LEFT JOIN users u2 ON c.contractor_id = u2.id
 		WHERE p.id = $1
 	`, projectID).Scan(
 		&participants.ProjectTitle,
@@ -322,12 +475,12 @@ func getUserInfo(db *sql.DB, userID string) (*UserNotificationInfo, error) {
 func ForgotPassword(w http.ResponseWriter, r *http.Request) {
 	var req models.ForgotPasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if !emailRegex.MatchString(req.Email) {
-		respondWithError(w, http.StatusBadRequest, "Invalid email format")
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
 		return
 	}
 
@@ -387,19 +540,19 @@ func ForgotPassword(w http.ResponseWriter, r *http.Request) {
 func ResetPassword(w http.ResponseWriter, r *http.Request) {
 	var req models.ResetPasswordRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	log.Printf("🔐 Reset Password Request - Token: %s", req.Token)
 
 	if req.Token == "" {
-		respondWithError(w, http.StatusBadRequest, "Reset token is required")
+		respondWithAPIError(w, apierror.Invalid("token", "Reset token is required"))
 		return
 	}
 
 	if len(req.NewPassword) < 8 {
-		respondWithError(w, http.StatusBadRequest, "Password must be at least 8 characters")
+		respondWithAPIError(w, apierror.Invalid("password", "Password must be at least 8 characters"))
 		return
 	}
 
@@ -418,7 +571,7 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	if err == sql.ErrNoRows {
 		log.Printf("❌ ERROR: Reset token not found - Token: %s", req.Token)
-		respondWithError(w, http.StatusBadRequest, "Invalid or expired reset token")
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired reset token"))
 		return
 	}
 
@@ -433,7 +586,7 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	if currentTime.After(expiresAt) {
 		log.Printf("❌ ERROR: Token expired - Expired at: %v (UTC), Current time: %v (UTC)", expiresAt, currentTime)
-		respondWithError(w, http.StatusBadRequest, "Invalid or expired reset token")
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired reset token"))
 		return
 	}
 
@@ -458,7 +611,9 @@ func ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	updateUserQuery := "UPDATE users SET password_hash = $1, updated_at = NOW() WHERE id = $2"
+	// The reset link was delivered to the inbox, which also proves the address
+	// for staff accounts created by an organization invite.
+	updateUserQuery := "UPDATE users SET password_hash = $1, email_verified = true, updated_at = NOW() WHERE id = $2"
 	_, err = tx.Exec(updateUserQuery, string(hashedPassword), userID)
 	if err != nil {
 		log.Printf("❌ ERROR: Failed to update password: %v", err)
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// maxBulkItems bounds the number of items in one bulk request.
+const maxBulkItems = 100
+
+// validateBulkIDs checks the ids of a bulk request before any are acted on:
+// there must be at least one and at most maxBulkItems, none empty or repeated.
+func validateBulkIDs(field string, ids []string) *apierror.Error {
+	if len(ids) == 0 {
+		return apierror.Invalid(field, field+" is required")
+	}
+	if len(ids) > maxBulkItems {
+		return apierror.Invalid(field, fmt.Sprintf("%s has more than %d items", field, maxBulkItems))
+	}
+
+	var problems []apierror.FieldError
+	seen := make(map[string]int)
+	for i, id := range ids {
+		name := fmt.Sprintf("%s[%d]", field, i)
+		if strings.TrimSpace(id) == "" {
+			problems = append(problems, apierror.FieldError{Field: name, Message: "is required"})
+			continue
+		}
+		if first, ok := seen[id]; ok {
+			problems = append(problems, apierror.FieldError{Field: name, Message: fmt.Sprintf("repeats %s[%d]", field, first)})
+			continue
+		}
+		seen[id] = i
+	}
+
+	if len(problems) > 0 {
+		return apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "The request has invalid items. Nothing was changed.").WithDetails(problems...)
+	}
+	return nil
+}
+
+// itemProblems returns the field errors of one item's validation error with
+// the item's position prefixed, so they can be reported with the others.
+func itemProblems(prefix string, apiErr *apierror.Error) []apierror.FieldError {
+	if len(apiErr.Details) == 0 {
+		return []apierror.FieldError{{Field: strings.TrimSuffix(prefix, "."), Message: apiErr.Message}}
+	}
+	problems := make([]apierror.FieldError, len(apiErr.Details))
+	for i, d := range apiErr.Details {
+		problems[i] = apierror.FieldError{Field: prefix + d.Field, Message: d.Message}
+	}
+	return problems
+}
+
+// bulkResults collects the outcome of each item of a bulk request.
+type bulkResults struct {
+	resp models.BulkResponse
+}
+
+func newBulkResults(n int) *bulkResults {
+	return &bulkResults{resp: models.BulkResponse{Results: make([]models.BulkResult, 0, n)}}
+}
+
+func (b *bulkResults) succeed(index int, id string, status int) {
+	b.resp.Results = append(b.resp.Results, models.BulkResult{Index: index, ID: id, Status: status})
+	b.resp.Succeeded++
+}
+
+func (b *bulkResults) fail(index int, id string, apiErr *apierror.Error) {
+	b.resp.Results = append(b.resp.Results, models.BulkResult{Index: index, ID: id, Status: apiErr.Status, Error: apiErr})
+	b.resp.Failed++
+}
+
+// respond writes the results. The request itself succeeded even when some of
+// its items failed, so the status is always 200.
+func (b *bulkResults) respond(w http.ResponseWriter) {
+	respondWithJSON(w, http.StatusOK, b.resp)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
@@ -16,7 +17,7 @@ import (
 func GetContractsByProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 	log.Printf("Error scanning contract: %v", userCtx)
@@ -26,7 +27,7 @@ func GetContractsByProject(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 	query := `
-		SELECT c.id, c.project_id, c.contractor_id, c.owner_id, c.status,
+		SELECT c.id, c.project_id, c.contractor_id, c.organization_id, c.owner_id, c.status,
 		       c.start_date, c.end_date, c.terms, c.created_at, c.updated_at,
 		       u.name as contractor_name, u.email as contractor_email
 		FROM contracts c
@@ -53,7 +54,7 @@ func GetContractsByProject(w http.ResponseWriter, r *http.Request) {
 	for rows.Next() {
 		var c ContractWithContractor
 		err := rows.Scan(
-			&c.ID, &c.ProjectID, &c.ContractorID, &c.OwnerID, &c.Status,
+			&c.ID, &c.ProjectID, &c.ContractorID, &c.OrganizationID, &c.OwnerID, &c.Status,
 			&c.StartDate, &c.EndDate, &c.Terms, &c.CreatedAt, &c.UpdatedAt,
 			&c.ContractorName, &c.ContractorEmail,
 		)
@@ -70,7 +71,7 @@ func GetContractsByProject(w http.ResponseWriter, r *http.Request) {
 func GetContract(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -79,7 +80,7 @@ func GetContract(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 	query := `
-		SELECT id, project_id, contractor_id, owner_id, status,
+		SELECT id, project_id, contractor_id, organization_id, owner_id, status,
 		       start_date, end_date, terms, created_at, updated_at
 		FROM contracts
 		WHERE id = $1
@@ -87,13 +88,13 @@ func GetContract(w http.ResponseWriter, r *http.Request) {
 
 	var contract models.Contract
 	err := db.QueryRow(query, contractID).Scan(
-		&contract.ID, &contract.ProjectID, &contract.ContractorID, &contract.OwnerID,
+		&contract.ID, &contract.ProjectID, &contract.ContractorID, &contract.OrganizationID, &contract.OwnerID,
 		&contract.Status, &contract.StartDate, &contract.EndDate, &contract.Terms,
 		&contract.CreatedAt, &contract.UpdatedAt,
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Contract not found")
+		respondWithAPIError(w, apierror.ErrContractNotFound)
 		return
 	} else if err != nil {
 		log.Printf("Error querying contract: %v", err)
@@ -101,8 +102,9 @@ func GetContract(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if contract.ContractorID != userCtx.UserID && contract.OwnerID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if !userCtx.InOrganization(contract.OrganizationID) && contract.OwnerID != userCtx.UserID &&
+		!isProjectMember(db, contract.ProjectID, userCtx.UserID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -112,7 +114,7 @@ func GetContract(w http.ResponseWriter, r *http.Request) {
 func UpdateContractStatus(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -121,25 +123,25 @@ func UpdateContractStatus(w http.ResponseWriter, r *http.Request) {
 
 	var req models.UpdateContractStatusRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	db := database.GetDB()
 
-	var ownerID, contractorID string
-	err := db.QueryRow("SELECT owner_id, contractor_id FROM contracts WHERE id = $1", contractID).
-		Scan(&ownerID, &contractorID)
+	var ownerID, organizationID string
+	err := db.QueryRow("SELECT owner_id, organization_id FROM contracts WHERE id = $1", contractID).
+		Scan(&ownerID, &organizationID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Contract not found")
+		respondWithAPIError(w, apierror.ErrContractNotFound)
 		return
 	} else if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve contract")
 		return
 	}
 
-	if ownerID != userCtx.UserID && contractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if ownerID != userCtx.UserID && !(userCtx.InOrganization(organizationID) && userCtx.CanManageBusiness()) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -147,13 +149,13 @@ func UpdateContractStatus(w http.ResponseWriter, r *http.Request) {
 		UPDATE contracts
 		SET status = $1, end_date = $2, updated_at = $3
 		WHERE id = $4
-		RETURNING id, project_id, contractor_id, owner_id, status,
+		RETURNING id, project_id, contractor_id, organization_id, owner_id, status,
 		          start_date, end_date, terms, created_at, updated_at
 	`
 
 	var contract models.Contract
 	err = db.QueryRow(query, req.Status, req.EndDate, time.Now(), contractID).Scan(
-		&contract.ID, &contract.ProjectID, &contract.ContractorID, &contract.OwnerID,
+		&contract.ID, &contract.ProjectID, &contract.ContractorID, &contract.OrganizationID, &contract.OwnerID,
 		&contract.Status, &contract.StartDate, &contract.EndDate, &contract.Terms,
 		&contract.CreatedAt, &contract.UpdatedAt,
 	)
@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
 )
 
 type ProjectDashboard struct {
@@ -54,9 +56,12 @@ type UpdatePhoto struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// WorkLogsSummary covers the week so far, starting Sunday in the project's
+// time zone.
 type WorkLogsSummary struct {
-	TotalHoursThisWeek float64 `json:"total_hours_this_week"`
-	ActiveEmployees    int     `json:"active_employees"`
+	TotalHoursThisWeek float64   `json:"total_hours_this_week"`
+	ActiveEmployees    int       `json:"active_employees"`
+	WeekStart          time.Time `json:"week_start"`
 }
 
 type RecentCheckIn struct {
@@ -66,30 +71,33 @@ type RecentCheckIn struct {
 	CheckInPhotoURL string    `json:"check_in_photo_url"`
 }
 
+// ExpenseSummary totals are in the project currency.
 type ExpenseSummary struct {
-	TotalSpent        float64            `json:"total_spent"`
-	TotalByOwner      float64            `json:"total_by_owner"`
-	TotalByContractor float64            `json:"total_by_contractor"`
-	ByCategory        map[string]float64 `json:"by_category"`
+	TotalSpent        money.Amount            `json:"total_spent"`
+	TotalByOwner      money.Amount            `json:"total_by_owner"`
+	TotalByContractor money.Amount            `json:"total_by_contractor"`
+	ByCategory        map[string]money.Amount `json:"by_category"`
 }
 
 type RecentExpense struct {
-	ID              string    `json:"id"`
-	Amount          float64   `json:"amount"`
-	Vendor          string    `json:"vendor"`
-	Date            string    `json:"date"`
-	Category        string    `json:"category"`
-	Description     *string   `json:"description"`
-	PaidBy          string    `json:"paid_by"`
-	ReceiptPhotoURL *string   `json:"receipt_photo_url"`
-	AddedByName     string    `json:"added_by_name"`
-	CreatedAt       time.Time `json:"created_at"`
+	ID              string         `json:"id"`
+	Amount          money.Amount   `json:"amount"`
+	Currency        money.Currency `json:"currency"`
+	ConvertedAmount money.Amount   `json:"converted_amount"`
+	Vendor          string         `json:"vendor"`
+	Date            string         `json:"date"`
+	Category        string         `json:"category"`
+	Description     *string        `json:"description"`
+	PaidBy          string         `json:"paid_by"`
+	ReceiptPhotoURL *string        `json:"receipt_photo_url"`
+	AddedByName     string         `json:"added_by_name"`
+	CreatedAt       time.Time      `json:"created_at"`
 }
 
 func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -101,14 +109,16 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 
 	var project models.Project
 	err := db.QueryRow(`
-		SELECT id, owner_id, title, description, estimated_cost, address, status, created_at, updated_at
-		FROM projects WHERE id = $1
+		SELECT id, owner_id, title, description, estimated_cost, currency, time_zone, address, status, created_at, updated_at
+		FROM projects WHERE id = $1 AND deleted_at IS NULL
 	`, projectID).Scan(
 		&project.ID,
 		&project.OwnerID,
 		&project.Title,
 		&project.Description,
 		&project.EstimatedCost,
+		&project.Currency,
+		&project.TimeZone,
 		&project.Address,
 		&project.Status,
 		&project.CreatedAt,
@@ -116,7 +126,7 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -128,16 +138,11 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 	isEmployee := false
 	switch models.UserType(userCtx.UserType) {
 	case models.UserTypeHouseOwner:
-		hasAccess = project.OwnerID == userCtx.UserID
+		access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+		hasAccess = err == nil && access.CanView()
 	case models.UserTypeContractor:
-		var isInProjectContractors bool
-		err = db.QueryRow(`
-			SELECT EXISTS(
-				SELECT 1 FROM project_contractors 
-				WHERE project_id = $1 AND contractor_id = $2
-			)
-		`, projectID, userCtx.UserID).Scan(&isInProjectContractors)
-		if err == nil && isInProjectContractors {
+		isMember, err := contractorHasProjectAccess(db, projectID, userCtx)
+		if err == nil && isMember {
 			hasAccess = true
 		}
 	case models.UserTypeEmployee:
@@ -150,7 +155,7 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !hasAccess {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -231,20 +236,21 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isEmployee {
+		weekStart := project.TimeZone.StartOfWeek(time.Now())
 		workLogQuery := `
 			SELECT 
 				COALESCE(SUM(EXTRACT(EPOCH FROM (check_out_time - check_in_time))/3600), 0) as total_hours,
 				COUNT(DISTINCT employee_id) as active_employees
 			FROM work_logs
 			WHERE project_id = $1 
-			AND check_in_time >= NOW() - INTERVAL '7 days'
+			AND check_in_time >= $2
 			AND check_out_time IS NOT NULL
 		`
-		workLogArgs := []interface{}{projectID}
-		argIndex := 2
+		workLogArgs := []interface{}{projectID, weekStart}
+		argIndex := 3
 
 		if contractorFilter != "" {
-			workLogQuery += ` AND employee_id IN (SELECT user_id FROM employees WHERE contractor_id = $` + strconv.Itoa(argIndex) + `)`
+			workLogQuery += ` AND employee_id IN (SELECT emp.user_id FROM employees emp JOIN organization_members om ON om.organization_id = emp.organization_id WHERE emp.deleted_at IS NULL AND om.user_id = $` + strconv.Itoa(argIndex) + `)`
 			workLogArgs = append(workLogArgs, contractorFilter)
 		}
 
@@ -255,6 +261,7 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 		dashboard.WorkLogsSummary = WorkLogsSummary{
 			TotalHoursThisWeek: totalHours,
 			ActiveEmployees:    activeEmployees,
+			WeekStart:          weekStart,
 		}
 
 		checkInQuery := `
@@ -267,7 +274,7 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 		argIndex = 2
 
 		if contractorFilter != "" {
-			checkInQuery += ` AND wl.employee_id IN (SELECT user_id FROM employees WHERE contractor_id = $` + strconv.Itoa(argIndex) + `)`
+			checkInQuery += ` AND wl.employee_id IN (SELECT emp.user_id FROM employees emp JOIN organization_members om ON om.organization_id = emp.organization_id WHERE emp.deleted_at IS NULL AND om.user_id = $` + strconv.Itoa(argIndex) + `)`
 			checkInArgs = append(checkInArgs, contractorFilter)
 		}
 
@@ -285,52 +292,50 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 
 		expenseQuery := `
 			SELECT 
-				COALESCE(SUM(amount), 0) as total_spent,
-				COALESCE(SUM(CASE WHEN paid_by = 'owner' THEN amount ELSE 0 END), 0) as total_by_owner,
-				COALESCE(SUM(CASE WHEN paid_by = 'contractor' THEN amount ELSE 0 END), 0) as total_by_contractor
+				COALESCE(SUM(converted_amount), 0) as total_spent,
+				COALESCE(SUM(CASE WHEN paid_by = 'owner' THEN converted_amount ELSE 0 END), 0) as total_by_owner,
+				COALESCE(SUM(CASE WHEN paid_by = 'contractor' THEN converted_amount ELSE 0 END), 0) as total_by_contractor
 			FROM expenses
+			WHERE project_id = $1 AND deleted_at IS NULL
 		`
 		expenseArgs := []interface{}{projectID}
 		argIndex = 2
 
 		if contractorFilter != "" {
 			expenseQuery += ` 
-				WHERE project_id = $1 
 				AND (added_by = $` + strconv.Itoa(argIndex) +
-				` OR added_by IN (SELECT user_id FROM employees WHERE contractor_id = $` + strconv.Itoa(argIndex) + `))`
+				` OR added_by IN (SELECT emp.user_id FROM employees emp JOIN organization_members om ON om.organization_id = emp.organization_id WHERE emp.deleted_at IS NULL AND om.user_id = $` + strconv.Itoa(argIndex) + `))`
 			expenseArgs = append(expenseArgs, contractorFilter)
-		} else {
-			expenseQuery += ` WHERE project_id = $1`
 		}
 
-		var totalSpent, totalByOwner, totalByContractor float64
+		var totalSpent, totalByOwner, totalByContractor money.Amount
 		db.QueryRow(expenseQuery, expenseArgs...).Scan(&totalSpent, &totalByOwner, &totalByContractor)
 
 		byCategoryQuery := `
-			SELECT category, COALESCE(SUM(amount), 0)
+			SELECT category, COALESCE(SUM(converted_amount), 0)
 			FROM expenses
+			WHERE project_id = $1 AND deleted_at IS NULL
 		`
 		byCategoryArgs := []interface{}{projectID}
 		argIndex = 2
 
 		if contractorFilter != "" {
 			byCategoryQuery += ` 
-				WHERE project_id = $1 
 				AND (added_by = $` + strconv.Itoa(argIndex) +
-				` OR added_by IN (SELECT user_id FROM employees WHERE contractor_id = $` + strconv.Itoa(argIndex) + `))
+				` OR added_by IN (SELECT emp.user_id FROM employees emp JOIN organization_members om ON om.organization_id = emp.organization_id WHERE emp.deleted_at IS NULL AND om.user_id = $` + strconv.Itoa(argIndex) + `))
 				GROUP BY category`
 			byCategoryArgs = append(byCategoryArgs, contractorFilter)
 		} else {
-			byCategoryQuery += ` WHERE project_id = $1 GROUP BY category`
+			byCategoryQuery += ` GROUP BY category`
 		}
 
 		categoryRows, err := db.Query(byCategoryQuery, byCategoryArgs...)
-		byCategory := make(map[string]float64)
+		byCategory := make(map[string]money.Amount)
 		if err == nil {
 			defer categoryRows.Close()
 			for categoryRows.Next() {
 				var category string
-				var amount float64
+				var amount money.Amount
 				categoryRows.Scan(&category, &amount)
 				byCategory[category] = amount
 			}
@@ -344,16 +349,16 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 		}
 
 		expListQuery := `
-			SELECT e.id, e.amount, e.vendor, e.date, e.category, e.description, 
+			SELECT e.id, e.amount, e.currency, e.converted_amount, e.vendor, e.date::text, e.category, e.description, 
 			       e.paid_by, e.receipt_photo_url, u.name, e.created_at
 			FROM expenses e
 			JOIN users u ON e.added_by = u.id
-			WHERE e.project_id = $1
+			WHERE e.project_id = $1 AND e.deleted_at IS NULL
 		`
 		expListArgs := []interface{}{projectID}
 
 		if contractorFilter != "" {
-			expListQuery += ` AND (e.added_by = $2 OR e.added_by IN (SELECT user_id FROM employees WHERE contractor_id = $2))`
+			expListQuery += ` AND (e.added_by = $2 OR e.added_by IN (SELECT emp.user_id FROM employees emp JOIN organization_members om ON om.organization_id = emp.organization_id WHERE emp.deleted_at IS NULL AND om.user_id = $2))`
 			expListArgs = append(expListArgs, contractorFilter)
 		}
 
@@ -364,7 +369,7 @@ func GetProjectDashboard(w http.ResponseWriter, r *http.Request) {
 			defer expRows.Close()
 			for expRows.Next() {
 				var expense RecentExpense
-				expRows.Scan(&expense.ID, &expense.Amount, &expense.Vendor, &expense.Date,
+				expRows.Scan(&expense.ID, &expense.Amount, &expense.Currency, &expense.ConvertedAmount, &expense.Vendor, &expense.Date,
 					&expense.Category, &expense.Description, &expense.PaidBy,
 					&expense.ReceiptPhotoURL, &expense.AddedByName, &expense.CreatedAt)
 				dashboard.RecentExpenses = append(dashboard.RecentExpenses, expense)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
@@ -18,7 +19,7 @@ func ListEmployees(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
 		log.Printf("ERROR ListEmployees: User not found in context")
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -30,13 +31,13 @@ func ListEmployees(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 	query := `
-		SELECT id, contractor_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at
+		SELECT id, contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at, version
 		FROM employees
-		WHERE contractor_id = $1 AND is_active = true
+		WHERE organization_id = $1 AND is_active = true AND deleted_at IS NULL
 		ORDER BY created_at DESC
 	`
 
-	rows, err := db.Query(query, userCtx.UserID)
+	rows, err := db.Query(query, userCtx.OrganizationID)
 	if err != nil {
 		log.Printf("ERROR ListEmployees: Failed to query database: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch employees")
@@ -50,6 +51,7 @@ func ListEmployees(w http.ResponseWriter, r *http.Request) {
 		err := rows.Scan(
 			&employee.ID,
 			&employee.ContractorID,
+			&employee.OrganizationID,
 			&employee.UserID,
 			&employee.Name,
 			&employee.Email,
@@ -58,6 +60,7 @@ func ListEmployees(w http.ResponseWriter, r *http.Request) {
 			&employee.IsActive,
 			&employee.CreatedAt,
 			&employee.UpdatedAt,
+			&employee.Version,
 		)
 		if err != nil {
 			log.Printf("ERROR ListEmployees: Failed to scan employee: %v", err)
@@ -85,11 +88,11 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
 		log.Printf("ERROR AddEmployee: User not found in context")
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	if userCtx.UserType != string(models.UserTypeContractor) {
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
 		log.Printf("ERROR AddEmployee: User type is %s, not contractor", userCtx.UserType)
 		respondWithError(w, http.StatusForbidden, "Only contractors can add employees")
 		return
@@ -98,31 +101,38 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 	var req models.AddEmployeeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		log.Printf("ERROR AddEmployee: Invalid request body: %v", err)
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if req.Name == "" {
-		respondWithError(w, http.StatusBadRequest, "Name is required")
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required"))
 		return
 	}
 
 	if !emailRegex.MatchString(req.Email) {
-		respondWithError(w, http.StatusBadRequest, "Invalid email format")
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
 		return
 	}
 
 	if req.HourlyRate <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Hourly rate must be positive")
+		respondWithAPIError(w, apierror.Invalid("hourly_rate", "Hourly rate must be positive"))
 		return
 	}
 
 	db := database.GetDB()
 
-	var existingUserID string
-	err := db.QueryRow("SELECT id FROM users WHERE email = $1", req.Email).Scan(&existingUserID)
-	if err != sql.ErrNoRows {
-		respondWithError(w, http.StatusConflict, "Email already registered")
+	var existingUserID, existingUserType string
+	err := db.QueryRow("SELECT id, user_type FROM users WHERE LOWER(email) = LOWER($1)", req.Email).
+		Scan(&existingUserID, &existingUserType)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("ERROR AddEmployee: Failed to look up user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+
+	if err == nil {
+		inviteExistingEmployee(w, db, userCtx, existingUserID, existingUserType, req)
 		return
 	}
 
@@ -134,14 +144,14 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	tempPassword, err := utils.GenerateRandomPassword()
+	placeholderPassword, err := utils.GenerateRandomPassword()
 	if err != nil {
 		log.Printf("ERROR AddEmployee: Failed to generate password: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to generate password")
 		return
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(tempPassword), bcrypt.DefaultCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(placeholderPassword), bcrypt.DefaultCost)
 	if err != nil {
 		log.Printf("ERROR AddEmployee: Failed to hash password: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to process password")
@@ -164,12 +174,12 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 
 	var employee models.Employee
 	employeeQuery := `
-		INSERT INTO employees (contractor_id, user_id, name, email, phone, hourly_rate, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, contractor_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at
+		INSERT INTO employees (contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at
 	`
-	err = tx.QueryRow(employeeQuery, userCtx.UserID, userID, req.Name, req.Email, req.Phone, req.HourlyRate, true).
-		Scan(&employee.ID, &employee.ContractorID, &employee.UserID, &employee.Name,
+	err = tx.QueryRow(employeeQuery, userCtx.UserID, userCtx.OrganizationID, userID, req.Name, req.Email, req.Phone, req.HourlyRate, true).
+		Scan(&employee.ID, &employee.ContractorID, &employee.OrganizationID, &employee.UserID, &employee.Name,
 			&employee.Email, &employee.Phone, &employee.HourlyRate, &employee.IsActive,
 			&employee.CreatedAt, &employee.UpdatedAt)
 	if err != nil {
@@ -178,22 +188,96 @@ func AddEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	invitation, err := createEmployeeInvitation(tx, userCtx.UserID, userCtx.OrganizationID, userID, &employee.ID, req, models.EmployeeInvitationSetPassword)
+	if err != nil {
+		log.Printf("ERROR AddEmployee: Failed to create invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Printf("ERROR AddEmployee: Failed to commit transaction: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
 		return
 	}
 
-	utils.SendEmployeeWelcomeEmail(req.Email, req.Name, tempPassword)
+	sendEmployeeInvitation(db, invitation)
 
 	log.Printf("SUCCESS AddEmployee: Created employee %s with ID %s", req.Name, employee.ID)
 	respondWithJSON(w, http.StatusCreated, employee)
 }
 
+func inviteExistingEmployee(w http.ResponseWriter, db *sql.DB, userCtx middleware.UserContext, userID, userType string, req models.AddEmployeeRequest) {
+	if userType != string(models.UserTypeEmployee) {
+		respondWithAPIError(w, apierror.ErrEmailTaken)
+		return
+	}
+
+	var alreadyEmployed bool
+	err := db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM employees
+			WHERE organization_id = $1 AND user_id = $2 AND is_active = true AND deleted_at IS NULL
+		)
+	`, userCtx.OrganizationID, userID).Scan(&alreadyEmployed)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check existing employee")
+		return
+	}
+
+	if alreadyEmployed {
+		respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeAlreadyMember, "This person is already on your crew"))
+		return
+	}
+
+	var pendingExists bool
+	err = db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM employee_invitations
+			WHERE organization_id = $1 AND user_id = $2 AND status = $3
+		)
+	`, userCtx.OrganizationID, userID, models.InvitationStatusPending).Scan(&pendingExists)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check existing invitations")
+		return
+	}
+
+	if pendingExists {
+		respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeInvitationPending, "An invitation is already pending for this email. Resend it instead."))
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	invitation, err := createEmployeeInvitation(tx, userCtx.UserID, userCtx.OrganizationID, userID, nil, req, models.EmployeeInvitationJoinCrew)
+	if err != nil {
+		log.Printf("ERROR AddEmployee: Failed to create invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	sendEmployeeInvitation(db, invitation)
+
+	respondWithJSON(w, http.StatusAccepted, map[string]interface{}{
+		"message":    "This email already has a Managrr account. They will join your crew once they accept the invitation.",
+		"invitation": invitation,
+	})
+}
+
 func GetEmployee(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -211,15 +295,16 @@ func GetEmployee(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 	query := `
-		SELECT id, contractor_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at
+		SELECT id, contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at, version
 		FROM employees
-		WHERE id = $1
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 
 	var employee models.Employee
 	err := db.QueryRow(query, employeeID).Scan(
 		&employee.ID,
 		&employee.ContractorID,
+		&employee.OrganizationID,
 		&employee.UserID,
 		&employee.Name,
 		&employee.Email,
@@ -228,10 +313,11 @@ func GetEmployee(w http.ResponseWriter, r *http.Request) {
 		&employee.IsActive,
 		&employee.CreatedAt,
 		&employee.UpdatedAt,
+		&employee.Version,
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Employee not found")
+		respondWithAPIError(w, apierror.ErrEmployeeNotFound)
 		return
 	}
 	if err != nil {
@@ -239,8 +325,8 @@ func GetEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if employee.ContractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if !userCtx.InOrganization(employee.OrganizationID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -248,7 +334,7 @@ func GetEmployee(w http.ResponseWriter, r *http.Request) {
     SELECT p.id, p.title
     FROM projects p
     INNER JOIN employee_projects ep ON p.id = ep.project_id
-    WHERE ep.employee_id = $1
+    WHERE ep.employee_id = $1 AND p.deleted_at IS NULL
     ORDER BY ep.assigned_at DESC
 `
 	projectRows, err := db.Query(projectQuery, employeeID)
@@ -279,17 +365,18 @@ func GetEmployee(w http.ResponseWriter, r *http.Request) {
 		AssignedProjects: projects,
 	}
 
+	setVersionETag(w, employee.Version)
 	respondWithJSON(w, http.StatusOK, response)
 }
 
 func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	if userCtx.UserType != string(models.UserTypeContractor) {
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
 		respondWithError(w, http.StatusForbidden, "Only contractors can update employees")
 		return
 	}
@@ -303,26 +390,27 @@ func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 
 	var req models.UpdateEmployeeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if req.Name == "" {
-		respondWithError(w, http.StatusBadRequest, "Name is required")
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required"))
 		return
 	}
 
 	if req.HourlyRate <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Hourly rate must be positive")
+		respondWithAPIError(w, apierror.Invalid("hourly_rate", "Hourly rate must be positive"))
 		return
 	}
 
 	db := database.GetDB()
 
-	var contractorID string
-	err := db.QueryRow("SELECT contractor_id FROM employees WHERE id = $1", employeeID).Scan(&contractorID)
+	var organizationID string
+	var version int
+	err := db.QueryRow("SELECT organization_id, version FROM employees WHERE id = $1 AND deleted_at IS NULL", employeeID).Scan(&organizationID, &version)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Employee not found")
+		respondWithAPIError(w, apierror.ErrEmployeeNotFound)
 		return
 	}
 	if err != nil {
@@ -330,22 +418,28 @@ func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if contractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if !userCtx.InOrganization(organizationID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	if versionMismatch(r, version) {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
 		return
 	}
 
 	query := `
     UPDATE employees 
     SET name = $1, phone = $2, hourly_rate = $3, updated_at = CURRENT_TIMESTAMP
-    WHERE id = $4
-    RETURNING id, contractor_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at
+    WHERE id = $4 AND deleted_at IS NULL AND ($5::int IS NULL OR version = $5)
+    RETURNING id, contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active, created_at, updated_at, version
 `
 
 	var employee models.Employee
-	err = db.QueryRow(query, req.Name, req.Phone, req.HourlyRate, employeeID).Scan(
+	err = db.QueryRow(query, req.Name, req.Phone, req.HourlyRate, employeeID, ifMatchVersion(r)).Scan(
 		&employee.ID,
 		&employee.ContractorID,
+		&employee.OrganizationID,
 		&employee.UserID,
 		&employee.Name,
 		&employee.Email,
@@ -354,24 +448,30 @@ func UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 		&employee.IsActive,
 		&employee.CreatedAt,
 		&employee.UpdatedAt,
+		&employee.Version,
 	)
 
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to update employee")
+		respondWithDBError(w, err, "Failed to update employee")
 		return
 	}
 
+	setVersionETag(w, employee.Version)
 	respondWithJSON(w, http.StatusOK, employee)
 }
 
 func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	if userCtx.UserType != string(models.UserTypeContractor) {
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
 		respondWithError(w, http.StatusForbidden, "Only contractors can delete employees")
 		return
 	}
@@ -385,10 +485,10 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 
-	var contractorID string
-	err := db.QueryRow("SELECT contractor_id FROM employees WHERE id = $1", employeeID).Scan(&contractorID)
+	var organizationID string
+	err := db.QueryRow("SELECT organization_id FROM employees WHERE id = $1 AND deleted_at IS NULL", employeeID).Scan(&organizationID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Employee not found")
+		respondWithAPIError(w, apierror.ErrEmployeeNotFound)
 		return
 	}
 	if err != nil {
@@ -396,13 +496,13 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if contractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if !userCtx.InOrganization(organizationID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
-	query := `UPDATE employees SET is_active = false, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
-	_, err = db.Exec(query, employeeID)
+	query := `UPDATE employees SET deleted_at = NOW(), deleted_by = $2 WHERE id = $1 AND deleted_at IS NULL`
+	_, err = db.Exec(query, employeeID, userCtx.UserID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to delete employee")
 		return
@@ -412,13 +512,23 @@ func DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 }
 
 func AssignProject(w http.ResponseWriter, r *http.Request) {
+	setProjectAssignment(w, r, true)
+}
+
+// UnassignProject takes an employee off a project. It is allowed to whoever
+// may assign them.
+func UnassignProject(w http.ResponseWriter, r *http.Request) {
+	setProjectAssignment(w, r, false)
+}
+
+func setProjectAssignment(w http.ResponseWriter, r *http.Request, assign bool) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	if userCtx.UserType != string(models.UserTypeContractor) {
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
 		respondWithError(w, http.StatusForbidden, "Only contractors can assign projects")
 		return
 	}
@@ -432,59 +542,165 @@ func AssignProject(w http.ResponseWriter, r *http.Request) {
 
 	var req models.AssignProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if req.ProjectID == "" {
-		respondWithError(w, http.StatusBadRequest, "project_id is required")
+		respondWithAPIError(w, apierror.Invalid("project_id", "project_id is required"))
 		return
 	}
 
 	db := database.GetDB()
 
-	var contractorID string
-	err := db.QueryRow("SELECT contractor_id FROM employees WHERE id = $1", employeeID).Scan(&contractorID)
-	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Employee not found")
+	if apiErr := checkOwnEmployee(db, userCtx, employeeID); apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to verify employee ownership")
+
+	if apiErr := checkAssignableProject(db, userCtx, req.ProjectID); apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
-	if contractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if err := saveProjectAssignment(db, employeeID, req.ProjectID, assign); err != nil {
+		if assign {
+			respondWithError(w, http.StatusInternalServerError, "Failed to assign project")
+		} else {
+			respondWithError(w, http.StatusInternalServerError, "Failed to unassign project")
+		}
 		return
 	}
 
-	var projectContractorID sql.NullString
-	err = db.QueryRow("SELECT contractor_id FROM projects WHERE id = $1", req.ProjectID).Scan(&projectContractorID)
-	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+	if assign {
+		respondWithJSON(w, http.StatusOK, map[string]string{"message": "Project assigned successfully"})
+	} else {
+		respondWithJSON(w, http.StatusOK, map[string]string{"message": "Project unassigned successfully"})
+	}
+}
+
+// BulkAssignProject assigns several employees to a project. The project is
+// checked once; each employee is checked and assigned on its own and the
+// response reports how each went.
+func BulkAssignProject(w http.ResponseWriter, r *http.Request) {
+	bulkSetProjectAssignment(w, r, true)
+}
+
+// BulkUnassignProject takes several employees off a project, reporting how
+// each went.
+func BulkUnassignProject(w http.ResponseWriter, r *http.Request) {
+	bulkSetProjectAssignment(w, r, false)
+}
+
+func bulkSetProjectAssignment(w http.ResponseWriter, r *http.Request, assign bool) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to verify project")
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can assign projects")
+		return
+	}
+
+	var req models.BulkAssignProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
-	if !projectContractorID.Valid || projectContractorID.String != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "You can only assign employees to your own projects")
+	if req.ProjectID == "" {
+		respondWithAPIError(w, apierror.Invalid("project_id", "project_id is required"))
+		return
+	}
+	if apiErr := validateBulkIDs("employee_ids", req.EmployeeIDs); apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
+	db := database.GetDB()
+
+	if apiErr := checkAssignableProject(db, userCtx, req.ProjectID); apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	results := newBulkResults(len(req.EmployeeIDs))
+	for i, employeeID := range req.EmployeeIDs {
+		if apiErr := checkOwnEmployee(db, userCtx, employeeID); apiErr != nil {
+			results.fail(i, employeeID, apiErr)
+			continue
+		}
+
+		if err := saveProjectAssignment(db, employeeID, req.ProjectID, assign); err != nil {
+			log.Printf("ERROR bulkSetProjectAssignment: Failed to update employee %s: %v", employeeID, err)
+			results.fail(i, employeeID, apierror.FromStatus(http.StatusInternalServerError, "Failed to update assignment"))
+			continue
+		}
+		results.succeed(i, employeeID, http.StatusOK)
+	}
+
+	results.respond(w)
+}
+
+// checkOwnEmployee checks that the employee exists and works for the caller's
+// organization.
+func checkOwnEmployee(db *sql.DB, userCtx middleware.UserContext, employeeID string) *apierror.Error {
+	var organizationID string
+	err := db.QueryRow("SELECT organization_id FROM employees WHERE id = $1 AND deleted_at IS NULL", employeeID).Scan(&organizationID)
+	if err == sql.ErrNoRows {
+		return apierror.ErrEmployeeNotFound
+	}
+	if err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return apiErr
+		}
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to verify employee ownership")
+	}
+
+	if !userCtx.InOrganization(organizationID) {
+		return apierror.ErrAccessDenied
+	}
+	return nil
+}
+
+// checkAssignableProject checks that the project exists and the caller's
+// organization works on it.
+func checkAssignableProject(db *sql.DB, userCtx middleware.UserContext, projectID string) *apierror.Error {
+	var projectExists bool
+	err := db.QueryRow("SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1 AND deleted_at IS NULL)", projectID).Scan(&projectExists)
+	if err != nil {
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to verify project")
+	}
+	if !projectExists {
+		return apierror.ErrProjectNotFound
+	}
+
+	hasAccess, err := contractorHasProjectAccess(db, projectID, userCtx)
+	if err != nil {
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to verify project")
+	}
+
+	if !hasAccess {
+		return apierror.FromStatus(http.StatusForbidden, "You can only assign employees to your own projects")
+	}
+	return nil
+}
+
+// saveProjectAssignment adds or removes an employee's assignment. Both are
+// idempotent.
+func saveProjectAssignment(db *sql.DB, employeeID, projectID string, assign bool) error {
+	if !assign {
+		_, err := db.Exec("DELETE FROM employee_projects WHERE employee_id = $1 AND project_id = $2", employeeID, projectID)
+		return err
+	}
+
 	query := `
 		INSERT INTO employee_projects (employee_id, project_id, assigned_at)
 		VALUES ($1, $2, NOW())
 		ON CONFLICT (employee_id, project_id) DO NOTHING
 	`
-	_, err = db.Exec(query, employeeID, req.ProjectID)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to assign project")
-		return
-	}
-
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Project assigned successfully"})
+	_, err := db.Exec(query, employeeID, projectID)
+	return err
 }
@@ -0,0 +1,396 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const employeeInvitationExpiry = 72 * time.Hour
+
+const employeeInvitationColumns = `id, contractor_id, organization_id, user_id, employee_id, email, name, phone, hourly_rate,
+		       invitation_type, status, expires_at, accepted_at, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEmployeeInvitation(row rowScanner, inv *models.EmployeeInvitation) error {
+	return row.Scan(
+		&inv.ID,
+		&inv.ContractorID,
+		&inv.OrganizationID,
+		&inv.UserID,
+		&inv.EmployeeID,
+		&inv.Email,
+		&inv.Name,
+		&inv.Phone,
+		&inv.HourlyRate,
+		&inv.InvitationType,
+		&inv.Status,
+		&inv.ExpiresAt,
+		&inv.AcceptedAt,
+		&inv.CreatedAt,
+		&inv.UpdatedAt,
+	)
+}
+
+func createEmployeeInvitation(tx *sql.Tx, contractorID, organizationID, userID string, employeeID *string, req models.AddEmployeeRequest, invitationType models.EmployeeInvitationType) (*models.EmployeeInvitation, error) {
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO employee_invitations (contractor_id, organization_id, user_id, employee_id, email, name, phone, hourly_rate,
+		                                  invitation_type, token_hash, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING ` + employeeInvitationColumns
+
+	var invitation models.EmployeeInvitation
+	err = scanEmployeeInvitation(tx.QueryRow(query, contractorID, organizationID, userID, employeeID, req.Email, req.Name, req.Phone,
+		req.HourlyRate, invitationType, utils.HashLoginCode(token), models.InvitationStatusPending, time.Now().UTC().Add(employeeInvitationExpiry)), &invitation)
+	if err != nil {
+		return nil, err
+	}
+	invitation.Token = token
+
+	return &invitation, nil
+}
+
+func sendEmployeeInvitation(db *sql.DB, invitation *models.EmployeeInvitation) error {
+	var organizationName string
+	err := db.QueryRow("SELECT name FROM organizations WHERE id = $1", invitation.OrganizationID).Scan(&organizationName)
+	if err != nil {
+		log.Printf("Failed to load organization for employee invitation: %v", err)
+		return err
+	}
+
+	existingAccount := invitation.InvitationType == models.EmployeeInvitationJoinCrew
+	err = utils.SendEmployeeInvitationEmail(invitation.Email, invitation.Name, organizationName, invitation.Token, existingAccount)
+	if err != nil {
+		log.Printf("Failed to send employee invitation email: %v", err)
+	}
+	return err
+}
+
+func ListEmployeeInvitations(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can view employee invitations")
+		return
+	}
+
+	db := database.GetDB()
+
+	query := `
+		SELECT ` + employeeInvitationColumns + `
+		FROM employee_invitations
+		WHERE organization_id = $1 AND status = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.Query(query, userCtx.OrganizationID, models.InvitationStatusPending)
+	if err != nil {
+		log.Printf("ERROR ListEmployeeInvitations: Failed to query database: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+	defer rows.Close()
+
+	type InvitationResponse struct {
+		models.EmployeeInvitation
+		Expired bool `json:"expired"`
+	}
+
+	invitations := []InvitationResponse{}
+	now := time.Now()
+	for rows.Next() {
+		var inv InvitationResponse
+		if err := scanEmployeeInvitation(rows, &inv.EmployeeInvitation); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan invitation")
+			return
+		}
+		inv.Expired = now.After(inv.ExpiresAt)
+		invitations = append(invitations, inv)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating invitations")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, invitations)
+}
+
+func ResendEmployeeInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can resend employee invitations")
+		return
+	}
+
+	invitationID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var organizationID string
+	var status models.InvitationStatus
+	err := db.QueryRow("SELECT organization_id, status FROM employee_invitations WHERE id = $1", invitationID).
+		Scan(&organizationID, &status)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if !userCtx.InOrganization(organizationID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	if status != models.InvitationStatusPending {
+		respondWithError(w, http.StatusBadRequest, "Only pending invitations can be resent")
+		return
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate invitation token")
+		return
+	}
+
+	query := `
+		UPDATE employee_invitations
+		SET token_hash = $1, expires_at = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING ` + employeeInvitationColumns
+
+	var invitation models.EmployeeInvitation
+	err = scanEmployeeInvitation(db.QueryRow(query, utils.HashLoginCode(token), time.Now().UTC().Add(employeeInvitationExpiry), invitationID), &invitation)
+	if err != nil {
+		log.Printf("ERROR ResendEmployeeInvitation: Failed to refresh invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to refresh invitation")
+		return
+	}
+	invitation.Token = token
+
+	if err := sendEmployeeInvitation(db, &invitation); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to send invitation email")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, invitation)
+}
+
+func RevokeEmployeeInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can revoke employee invitations")
+		return
+	}
+
+	invitationID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	result, err := db.Exec(`
+		UPDATE employee_invitations
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND organization_id = $3 AND status = $4
+	`, models.InvitationStatusRevoked, invitationID, userCtx.OrganizationID, models.InvitationStatusPending)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to revoke invitation")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound.WithMessage("Pending invitation not found"))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func GetEmployeeInvitationByToken(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var invitation models.EmployeeInvitation
+	err := scanEmployeeInvitation(db.QueryRow(`
+		SELECT `+employeeInvitationColumns+`
+		FROM employee_invitations
+		WHERE token_hash = $1
+	`, utils.HashLoginCode(token)), &invitation)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if invitation.Status != models.InvitationStatusPending || time.Now().After(invitation.ExpiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusGone, apierror.CodeInvitationExpired, "This invitation is no longer valid"))
+		return
+	}
+
+	contractorName := ""
+	db.QueryRow("SELECT name FROM organizations WHERE id = $1", invitation.OrganizationID).Scan(&contractorName)
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"email":             invitation.Email,
+		"name":              invitation.Name,
+		"contractor_name":   contractorName,
+		"invitation_type":   invitation.InvitationType,
+		"requires_password": invitation.InvitationType == models.EmployeeInvitationSetPassword,
+		"expires_at":        invitation.ExpiresAt,
+	})
+}
+
+func AcceptEmployeeInvitation(w http.ResponseWriter, r *http.Request) {
+	var req models.AcceptEmployeeInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var invitation models.EmployeeInvitation
+	err := scanEmployeeInvitation(db.QueryRow(`
+		SELECT `+employeeInvitationColumns+`
+		FROM employee_invitations
+		WHERE token_hash = $1
+	`, utils.HashLoginCode(req.Token)), &invitation)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if invitation.Status != models.InvitationStatusPending || time.Now().After(invitation.ExpiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+
+	var hashedPassword []byte
+	if invitation.InvitationType == models.EmployeeInvitationSetPassword {
+		if len(req.Password) < 8 {
+			respondWithAPIError(w, apierror.Invalid("password", "Password must be at least 8 characters"))
+			return
+		}
+
+		hashedPassword, err = bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to process password")
+			return
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	if hashedPassword != nil {
+		_, err = tx.Exec(`
+			UPDATE users
+			SET password_hash = $1, email_verified = true, updated_at = NOW()
+			WHERE id = $2
+		`, string(hashedPassword), invitation.UserID)
+		if err != nil {
+			log.Printf("ERROR AcceptEmployeeInvitation: Failed to set password: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to set password")
+			return
+		}
+	}
+
+	if invitation.EmployeeID == nil {
+		var employeeID string
+		err = tx.QueryRow(`
+			INSERT INTO employees (contractor_id, organization_id, user_id, name, email, phone, hourly_rate, is_active)
+			SELECT $1, $2, u.id, $3, u.email, $4, $5, true
+			FROM users u
+			WHERE u.id = $6
+			RETURNING id
+		`, invitation.ContractorID, invitation.OrganizationID, invitation.Name, invitation.Phone, invitation.HourlyRate, invitation.UserID).Scan(&employeeID)
+		if err != nil {
+			log.Printf("ERROR AcceptEmployeeInvitation: Failed to link employee: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to join crew")
+			return
+		}
+		invitation.EmployeeID = &employeeID
+	}
+
+	result, err := tx.Exec(`
+		UPDATE employee_invitations
+		SET status = $1, employee_id = $2, accepted_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`, models.InvitationStatusAccepted, invitation.EmployeeID, invitation.ID, models.InvitationStatusPending)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message":     "Invitation accepted. You can now log in.",
+		"employee_id": *invitation.EmployeeID,
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
@@ -17,48 +18,53 @@ import (
 func CreateEstimate(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
 	var req models.CreateEstimateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Amount <= 0 {
+		respondWithAPIError(w, apierror.Invalid("amount", "amount must be positive"))
 		return
 	}
 
 	db := database.GetDB()
 
-	var contractorID string
-	err := db.QueryRow("SELECT contractor_id FROM contracts WHERE id = $1", req.ContractID).
-		Scan(&contractorID)
+	var organizationID string
+	err := db.QueryRow("SELECT organization_id FROM contracts WHERE id = $1", req.ContractID).
+		Scan(&organizationID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Contract not found")
+		respondWithAPIError(w, apierror.ErrContractNotFound)
 		return
 	} else if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve contract")
 		return
 	}
 
-	if contractorID != userCtx.UserID {
+	if !userCtx.InOrganization(organizationID) || !userCtx.CanManageBusiness() {
 		respondWithError(w, http.StatusForbidden, "Only the contractor can submit estimates")
 		return
 	}
 
 	estimateID := uuid.New().String()
 	query := `
-		INSERT INTO estimates (id, contract_id, amount, description, submitted_by, submitted_at, status, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, contract_id, amount, description, submitted_by, submitted_at, status,
+		INSERT INTO estimates (id, contract_id, organization_id, amount, description, submitted_by, submitted_at, status, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, contract_id, organization_id, amount, description, submitted_by, submitted_at, status,
 		          approved_by, approved_at, rejected_at, rejection_reason, is_active, created_at, updated_at
 	`
 
 	var estimate models.Estimate
 	err = db.QueryRow(
-		query, estimateID, req.ContractID, req.Amount, req.Description,
+		query, estimateID, req.ContractID, organizationID, req.Amount, req.Description,
 		userCtx.UserID, time.Now(), models.EstimateStatusPending, false,
 	).Scan(
-		&estimate.ID, &estimate.ContractID, &estimate.Amount, &estimate.Description,
+		&estimate.ID, &estimate.ContractID, &estimate.OrganizationID, &estimate.Amount, &estimate.Description,
 		&estimate.SubmittedBy, &estimate.SubmittedAt, &estimate.Status,
 		&estimate.ApprovedBy, &estimate.ApprovedAt, &estimate.RejectedAt,
 		&estimate.RejectionReason, &estimate.IsActive, &estimate.CreatedAt, &estimate.UpdatedAt,
@@ -76,7 +82,7 @@ func CreateEstimate(w http.ResponseWriter, r *http.Request) {
 func GetEstimatesByContract(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -85,24 +91,24 @@ func GetEstimatesByContract(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 
-	var ownerID, contractorID string
-	err := db.QueryRow("SELECT owner_id, contractor_id FROM contracts WHERE id = $1", contractID).
-		Scan(&ownerID, &contractorID)
+	var projectID, ownerID, organizationID string
+	err := db.QueryRow("SELECT project_id, owner_id, organization_id FROM contracts WHERE id = $1", contractID).
+		Scan(&projectID, &ownerID, &organizationID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Contract not found")
+		respondWithAPIError(w, apierror.ErrContractNotFound)
 		return
 	} else if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve contract")
 		return
 	}
 
-	if ownerID != userCtx.UserID && contractorID != userCtx.UserID {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	if ownerID != userCtx.UserID && !userCtx.InOrganization(organizationID) && !isProjectMember(db, projectID, userCtx.UserID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
 	query := `
-		SELECT id, contract_id, amount, description, submitted_by, submitted_at, status,
+		SELECT id, contract_id, organization_id, amount, description, submitted_by, submitted_at, status,
 		       approved_by, approved_at, rejected_at, rejection_reason, is_active, created_at, updated_at
 		FROM estimates
 		WHERE contract_id = $1
@@ -121,7 +127,7 @@ func GetEstimatesByContract(w http.ResponseWriter, r *http.Request) {
 	for rows.Next() {
 		var e models.Estimate
 		err := rows.Scan(
-			&e.ID, &e.ContractID, &e.Amount, &e.Description, &e.SubmittedBy, &e.SubmittedAt,
+			&e.ID, &e.ContractID, &e.OrganizationID, &e.Amount, &e.Description, &e.SubmittedBy, &e.SubmittedAt,
 			&e.Status, &e.ApprovedBy, &e.ApprovedAt, &e.RejectedAt, &e.RejectionReason,
 			&e.IsActive, &e.CreatedAt, &e.UpdatedAt,
 		)
@@ -138,7 +144,7 @@ func GetEstimatesByContract(w http.ResponseWriter, r *http.Request) {
 func ApproveEstimate(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -147,29 +153,30 @@ func ApproveEstimate(w http.ResponseWriter, r *http.Request) {
 
 	var req models.ApproveEstimateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	db := database.GetDB()
 
-	var ownerID, contractID string
+	var projectID, contractID string
 	err := db.QueryRow(`
-		SELECT c.owner_id, e.contract_id
+		SELECT c.project_id, e.contract_id
 		FROM estimates e
 		JOIN contracts c ON e.contract_id = c.id
 		WHERE e.id = $1
-	`, estimateID).Scan(&ownerID, &contractID)
+	`, estimateID).Scan(&projectID, &contractID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Estimate not found")
+		respondWithAPIError(w, apierror.ErrEstimateNotFound)
 		return
 	} else if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve estimate")
 		return
 	}
 
-	if ownerID != userCtx.UserID {
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+	if err != nil || !access.CanApproveEstimates {
 		respondWithError(w, http.StatusForbidden, "Only the project owner can approve estimates")
 		return
 	}
@@ -193,7 +200,7 @@ func ApproveEstimate(w http.ResponseWriter, r *http.Request) {
 		UPDATE estimates
 		SET status = $1, approved_by = $2, approved_at = $3, is_active = $4, updated_at = $5
 		WHERE id = $6
-		RETURNING id, contract_id, amount, description, submitted_by, submitted_at, status,
+		RETURNING id, contract_id, organization_id, amount, description, submitted_by, submitted_at, status,
 		          approved_by, approved_at, rejected_at, rejection_reason, is_active, created_at, updated_at
 	`
 
@@ -202,7 +209,7 @@ func ApproveEstimate(w http.ResponseWriter, r *http.Request) {
 		query, models.EstimateStatusApproved, userCtx.UserID, time.Now(),
 		req.SetAsActive, time.Now(), estimateID,
 	).Scan(
-		&estimate.ID, &estimate.ContractID, &estimate.Amount, &estimate.Description,
+		&estimate.ID, &estimate.ContractID, &estimate.OrganizationID, &estimate.Amount, &estimate.Description,
 		&estimate.SubmittedBy, &estimate.SubmittedAt, &estimate.Status,
 		&estimate.ApprovedBy, &estimate.ApprovedAt, &estimate.RejectedAt,
 		&estimate.RejectionReason, &estimate.IsActive, &estimate.CreatedAt, &estimate.UpdatedAt,
@@ -225,7 +232,7 @@ func ApproveEstimate(w http.ResponseWriter, r *http.Request) {
 func RejectEstimate(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -234,29 +241,30 @@ func RejectEstimate(w http.ResponseWriter, r *http.Request) {
 
 	var req models.RejectEstimateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	db := database.GetDB()
 
-	var ownerID string
+	var projectID string
 	err := db.QueryRow(`
-		SELECT c.owner_id
+		SELECT c.project_id
 		FROM estimates e
 		JOIN contracts c ON e.contract_id = c.id
 		WHERE e.id = $1
-	`, estimateID).Scan(&ownerID)
+	`, estimateID).Scan(&projectID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Estimate not found")
+		respondWithAPIError(w, apierror.ErrEstimateNotFound)
 		return
 	} else if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to retrieve estimate")
 		return
 	}
 
-	if ownerID != userCtx.UserID {
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+	if err != nil || !access.CanApproveEstimates {
 		respondWithError(w, http.StatusForbidden, "Only the project owner can reject estimates")
 		return
 	}
@@ -265,7 +273,7 @@ func RejectEstimate(w http.ResponseWriter, r *http.Request) {
 		UPDATE estimates
 		SET status = $1, rejected_at = $2, rejection_reason = $3, is_active = false, updated_at = $4
 		WHERE id = $5
-		RETURNING id, contract_id, amount, description, submitted_by, submitted_at, status,
+		RETURNING id, contract_id, organization_id, amount, description, submitted_by, submitted_at, status,
 		          approved_by, approved_at, rejected_at, rejection_reason, is_active, created_at, updated_at
 	`
 
@@ -273,7 +281,7 @@ func RejectEstimate(w http.ResponseWriter, r *http.Request) {
 	err = db.QueryRow(
 		query, models.EstimateStatusRejected, time.Now(), req.Reason, time.Now(), estimateID,
 	).Scan(
-		&estimate.ID, &estimate.ContractID, &estimate.Amount, &estimate.Description,
+		&estimate.ID, &estimate.ContractID, &estimate.OrganizationID, &estimate.Amount, &estimate.Description,
 		&estimate.SubmittedBy, &estimate.SubmittedAt, &estimate.Status,
 		&estimate.ApprovedBy, &estimate.ApprovedAt, &estimate.RejectedAt,
 		&estimate.RejectionReason, &estimate.IsActive, &estimate.CreatedAt, &estimate.UpdatedAt,
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Projects, expenses, payment summaries and employees carry a version that a
+// trigger bumps on every update. GET and PUT send it as a strong ETag and PUT
+// honours If-Match, so two people editing the same record cannot silently
+// overwrite each other: the second save fails with 412 instead.
+
+func setVersionETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatchVersion returns the version a request's If-Match header requires, or
+// nil when the request is unconditional. A header that is not one of our
+// ETags requires version 0, which no row has.
+func ifMatchVersion(r *http.Request) *int {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	version := 0
+	if len(header) > 2 && header[0] == '"' && header[len(header)-1] == '"' {
+		if v, err := strconv.Atoi(header[1 : len(header)-1]); err == nil {
+			version = v
+		}
+	}
+	return &version
+}
+
+// versionMismatch reports whether current fails the request's If-Match.
+func versionMismatch(r *http.Request, current int) bool {
+	expected := ifMatchVersion(r)
+	return expected != nil && *expected != current
+}
@@ -0,0 +1,488 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
+)
+
+const exchangeRateColumns = `id, project_id, currency, rate, effective_date::text, source, created_by, created_at, updated_at`
+
+// conversion is an amount recorded in one currency and its value in the
+// project currency at the rate in effect on the day it was paid.
+type conversion struct {
+	Currency        money.Currency
+	Rate            money.Rate
+	Converted       money.Amount
+	ProjectCurrency money.Currency
+}
+
+// parseCurrencyField reads an optional currency form field, returning def
+// when it is empty.
+func parseCurrencyField(field, value string, def money.Currency) (money.Currency, *apierror.Error) {
+	if value == "" {
+		return def, nil
+	}
+	currency, err := money.ParseCurrency(value)
+	if err != nil {
+		return "", apierror.Invalid(field, field+" "+err.Error())
+	}
+	return currency, nil
+}
+
+// convertToProject prices amount in the project currency using the latest
+// rate effective on or before date. An empty currency means the project
+// currency.
+func convertToProject(q queryRower, projectID string, currency money.Currency, amount money.Amount, date string) (conversion, *apierror.Error) {
+	var c conversion
+	err := q.QueryRow("SELECT currency FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&c.ProjectCurrency)
+	if err == sql.ErrNoRows {
+		return c, apierror.ErrProjectNotFound
+	}
+	if err != nil {
+		log.Printf("ERROR convertToProject: Failed to fetch project currency: %v", err)
+		return c, apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch project")
+	}
+
+	c.Currency = currency
+	if c.Currency == "" {
+		c.Currency = c.ProjectCurrency
+	}
+	if err := c.Currency.Check(amount); err != nil {
+		return c, apierror.Invalid("amount", "amount "+err.Error())
+	}
+
+	if c.Currency == c.ProjectCurrency {
+		c.Rate = money.OneToOne
+		c.Converted = amount
+		return c, nil
+	}
+
+	err = q.QueryRow(`
+		SELECT rate FROM project_exchange_rates
+		WHERE project_id = $1 AND currency = $2 AND effective_date <= $3::date
+		ORDER BY effective_date DESC
+		LIMIT 1
+	`, projectID, c.Currency, date).Scan(&c.Rate)
+	if err == sql.ErrNoRows {
+		message := fmt.Sprintf("No exchange rate from %s to %s is in effect on %s. Add one to the project first.", c.Currency, c.ProjectCurrency, date)
+		return c, apierror.New(http.StatusUnprocessableEntity, apierror.CodeExchangeRateMissing, message).
+			WithDetails(apierror.FieldError{Field: "currency", Message: "has no exchange rate on this date"})
+	}
+	if err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return c, apiErr
+		}
+		log.Printf("ERROR convertToProject: Failed to fetch exchange rate: %v", err)
+		return c, apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch exchange rate")
+	}
+
+	c.Converted, err = amount.Convert(c.Rate, c.ProjectCurrency)
+	if err == money.ErrTooLarge {
+		return c, apierror.Invalid("amount", fmt.Sprintf("Amount is too large once converted to %s", c.ProjectCurrency))
+	}
+	if err != nil {
+		log.Printf("ERROR convertToProject: Stored rate %q for project %s is invalid: %v", c.Rate, projectID, err)
+		return c, apierror.FromStatus(http.StatusInternalServerError, "Failed to apply exchange rate")
+	}
+	return c, nil
+}
+
+// exchangeRateAccess reports whether the user can see a project's rates and
+// whether they can change them. Changing rates is limited to those who can
+// confirm payments; anyone who records expenses can see them.
+func exchangeRateAccess(db *sql.DB, projectID string, userCtx middleware.UserContext) (canView, canManage bool, err error) {
+	switch models.UserType(userCtx.UserType) {
+	case models.UserTypeHouseOwner:
+		access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+		if err != nil {
+			return false, false, err
+		}
+		return access.CanView(), access.CanConfirmPayments, nil
+	case models.UserTypeContractor:
+		canView, err = contractorHasProjectAccess(db, projectID, userCtx)
+		return canView, false, err
+	case models.UserTypeEmployee:
+		foreman, err := foremanAccess(db, projectID, userCtx.UserID)
+		if err == sql.ErrNoRows {
+			return false, false, nil
+		}
+		return err == nil && foreman.CanLogExpenses, false, err
+	}
+	return false, false, nil
+}
+
+var exchangeRateListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"effective_date": {Column: "effective_date", Type: "date"},
+		"currency":       {Column: "currency", Type: "text"},
+	},
+	DefaultSort: "-effective_date",
+	IDColumn:    "id",
+	DateColumn:  "effective_date",
+	Filters: map[string]string{
+		"currency": "currency",
+		"source":   "source",
+	},
+}
+
+func ListExchangeRates(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+	db := database.GetDB()
+
+	canView, _, err := exchangeRateAccess(db, projectID, userCtx)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to verify project access")
+		return
+	}
+	if !canView {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	params, err := pagination.Parse(r, exchangeRateListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
+
+	from := "FROM project_exchange_rates"
+	q := &pagination.Query{}
+	q.Where("project_id = " + q.Arg(projectID))
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		log.Printf("ERROR ListExchangeRates: Failed to count rates: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch exchange rates")
+		return
+	}
+
+	query, args := params.Select(exchangeRateColumns+", "+params.CursorColumn(), from, q)
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR ListExchangeRates: Failed to fetch rates: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch exchange rates")
+		return
+	}
+	defer rows.Close()
+
+	rates := []models.ProjectExchangeRate{}
+	var cursors []string
+	for rows.Next() {
+		var rate models.ProjectExchangeRate
+		var cursor string
+		err := rows.Scan(&rate.ID, &rate.ProjectID, &rate.Currency, &rate.Rate, &rate.EffectiveDate,
+			&rate.Source, &rate.CreatedBy, &rate.CreatedAt, &rate.UpdatedAt, &cursor)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to parse exchange rate")
+			return
+		}
+		rates = append(rates, rate)
+		cursors = append(cursors, cursor)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating exchange rates")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, rates, cursors, total))
+}
+
+// manageExchangeRates checks the caller may change the project's rates and
+// returns the project currency.
+func manageExchangeRates(w http.ResponseWriter, r *http.Request, db *sql.DB, projectID string) (money.Currency, bool) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return "", false
+	}
+
+	_, canManage, err := exchangeRateAccess(db, projectID, userCtx)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return "", false
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to verify project access")
+		return "", false
+	}
+	if !canManage {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can manage exchange rates")
+		return "", false
+	}
+
+	var currency money.Currency
+	err = db.QueryRow("SELECT currency FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&currency)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return "", false
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return "", false
+	}
+	return currency, true
+}
+
+// validateExchangeRate checks one rate for a project in projectCurrency. The
+// field names are prefixed so import errors can point at a CSV row.
+func validateExchangeRate(prefix string, projectCurrency money.Currency, currency, rate, effectiveDate string) (money.Currency, money.Rate, []apierror.FieldError) {
+	var problems []apierror.FieldError
+
+	c, err := money.ParseCurrency(currency)
+	if err != nil {
+		problems = append(problems, apierror.FieldError{Field: prefix + "currency", Message: err.Error()})
+	} else if c == projectCurrency {
+		problems = append(problems, apierror.FieldError{Field: prefix + "currency", Message: "is the project currency, which always converts at 1"})
+	}
+
+	parsedRate, err := money.ParseRate(rate)
+	if err != nil {
+		problems = append(problems, apierror.FieldError{Field: prefix + "rate", Message: err.Error()})
+	}
+
+	if _, err := calendar.ParseDate(effectiveDate); err != nil {
+		problems = append(problems, apierror.FieldError{Field: prefix + "effective_date", Message: err.Error()})
+	}
+
+	return c, parsedRate, problems
+}
+
+// saveExchangeRate sets the rate a currency has from a date on, replacing
+// any rate already set for that day. It reports whether the rate is new.
+func saveExchangeRate(q queryRower, projectID string, currency money.Currency, rate money.Rate, effectiveDate string,
+	source models.ExchangeRateSource, userID string) (*models.ProjectExchangeRate, bool, error) {
+	var saved models.ProjectExchangeRate
+	var inserted bool
+	// xmax is only zero on rows the statement inserted.
+	err := q.QueryRow(`
+		INSERT INTO project_exchange_rates (project_id, currency, rate, effective_date, source, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (project_id, currency, effective_date) DO UPDATE
+		SET rate = EXCLUDED.rate, source = EXCLUDED.source, created_by = EXCLUDED.created_by
+		RETURNING `+exchangeRateColumns+`, (xmax = 0)
+	`, projectID, currency, rate, effectiveDate, source, userID).Scan(&saved.ID, &saved.ProjectID, &saved.Currency,
+		&saved.Rate, &saved.EffectiveDate, &saved.Source, &saved.CreatedBy, &saved.CreatedAt, &saved.UpdatedAt, &inserted)
+	if err != nil {
+		return nil, false, err
+	}
+	return &saved, inserted, nil
+}
+
+// SetExchangeRate records the rate for a currency from a date on. Setting a
+// rate for a date that already has one replaces it. Expenses and payments
+// already recorded keep the rate they were converted at.
+func SetExchangeRate(w http.ResponseWriter, r *http.Request) {
+	projectID := mux.Vars(r)["id"]
+	db := database.GetDB()
+
+	projectCurrency, ok := manageExchangeRates(w, r, db, projectID)
+	if !ok {
+		return
+	}
+	userCtx, _ := middleware.GetUserFromContext(r.Context())
+
+	var req models.SetExchangeRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		if errors.Is(err, money.ErrInvalidRate) {
+			respondWithAPIError(w, apierror.Invalid("rate", "rate "+err.Error()))
+			return
+		}
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	currency, rate, problems := validateExchangeRate("", projectCurrency, string(req.Currency), string(req.Rate), req.EffectiveDate)
+	if len(problems) > 0 {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid exchange rate").WithDetails(problems...))
+		return
+	}
+
+	saved, inserted, err := saveExchangeRate(db, projectID, currency, rate, req.EffectiveDate, models.ExchangeRateSourceManual, userCtx.UserID)
+	if err != nil {
+		log.Printf("ERROR SetExchangeRate: Failed to save rate: %v", err)
+		respondWithDBError(w, err, "Failed to save exchange rate")
+		return
+	}
+
+	status := http.StatusOK
+	if inserted {
+		status = http.StatusCreated
+	}
+	respondWithJSON(w, status, saved)
+}
+
+// maxImportRates bounds an exchange rate import, which is saved in a single
+// transaction.
+const maxImportRates = 5000
+
+// ImportExchangeRates loads rates from an uploaded CSV file with the columns
+// currency, rate and effective_date. A header row is optional. Nothing is
+// saved unless every row is valid.
+func ImportExchangeRates(w http.ResponseWriter, r *http.Request) {
+	projectID := mux.Vars(r)["id"]
+	db := database.GetDB()
+
+	projectCurrency, ok := manageExchangeRates(w, r, db, projectID)
+	if !ok {
+		return
+	}
+	userCtx, _ := middleware.GetUserFromContext(r.Context())
+
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondWithAPIError(w, apierror.Invalid("file", "file is required"))
+		return
+	}
+	defer file.Close()
+
+	type importRow struct {
+		currency money.Currency
+		rate     money.Rate
+		date     string
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+	reader.TrimLeadingSpace = true
+
+	var rates []importRow
+	var problems []apierror.FieldError
+	seen := make(map[string]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("file", fmt.Sprintf("file is not a valid CSV file: %v", err)))
+			return
+		}
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "currency") {
+			continue
+		}
+		if len(rates)+len(problems) >= maxImportRates {
+			respondWithAPIError(w, apierror.Invalid("file", fmt.Sprintf("file has more than %d rates", maxImportRates)))
+			return
+		}
+
+		prefix := fmt.Sprintf("row %d: ", line)
+		date := strings.TrimSpace(record[2])
+		currency, rate, rowProblems := validateExchangeRate(prefix, projectCurrency, record[0], record[1], date)
+		if len(rowProblems) > 0 {
+			problems = append(problems, rowProblems...)
+			continue
+		}
+
+		key := string(currency) + " " + date
+		if first, ok := seen[key]; ok {
+			problems = append(problems, apierror.FieldError{
+				Field:   prefix + "effective_date",
+				Message: fmt.Sprintf("repeats the %s rate on row %d", currency, first),
+			})
+			continue
+		}
+		seen[key] = line
+
+		rates = append(rates, importRow{currency: currency, rate: rate, date: date})
+	}
+
+	if len(problems) > 0 {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "The file has invalid rates. Nothing was imported.").WithDetails(problems...))
+		return
+	}
+	if len(rates) == 0 {
+		respondWithAPIError(w, apierror.Invalid("file", "file has no rates"))
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	created := 0
+	for _, row := range rates {
+		_, inserted, err := saveExchangeRate(tx, projectID, row.currency, row.rate, row.date, models.ExchangeRateSourceImport, userCtx.UserID)
+		if err != nil {
+			log.Printf("ERROR ImportExchangeRates: Failed to save rate: %v", err)
+			respondWithDBError(w, err, "Failed to import exchange rates")
+			return
+		}
+		if inserted {
+			created++
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"message":  fmt.Sprintf("Imported %d exchange rates", len(rates)),
+		"imported": len(rates),
+		"created":  created,
+		"replaced": len(rates) - created,
+	})
+}
+
+// DeleteExchangeRate removes a rate. Expenses and payments converted with it
+// keep their converted amounts.
+func DeleteExchangeRate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	rateID := vars["rateId"]
+	db := database.GetDB()
+
+	if _, ok := manageExchangeRates(w, r, db, projectID); !ok {
+		return
+	}
+
+	result, err := db.Exec("DELETE FROM project_exchange_rates WHERE id = $1 AND project_id = $2", rateID, projectID)
+	if err != nil {
+		respondWithDBError(w, err, "Failed to delete exchange rate")
+		return
+	}
+
+	if n, _ := result.RowsAffected(); n == 0 {
+		respondWithAPIError(w, apierror.ErrExchangeRateNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
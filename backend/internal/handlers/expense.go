@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"path/filepath"
 	"strconv"
@@ -11,28 +13,41 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 	"github.com/juazsh/managrr/internal/utils"
 	"github.com/xuri/excelize/v2"
 )
 
+// receiptExtensions are the image types accepted as receipt photos.
+var receiptExtensions = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".gif":  true,
+	".webp": true,
+}
+
 func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Println("=== AddExpense Handler Started ===")
 
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
 		log.Println("ERROR: User not found in context")
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 	log.Printf("User ID: %s, User Type: %s", userCtx.UserID, userCtx.UserType)
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
 		log.Printf("ERROR: Failed to parse multipart form: %v", err)
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 	log.Println("Multipart form parsed successfully")
@@ -41,7 +56,7 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Project ID: %s", projectID)
 	if projectID == "" {
 		log.Println("ERROR: project_id is missing")
-		respondWithError(w, http.StatusBadRequest, "project_id is required")
+		respondWithAPIError(w, apierror.Invalid("project_id", "project_id is required"))
 		return
 	}
 
@@ -49,7 +64,7 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Amount: %s", amount)
 	if amount == "" {
 		log.Println("ERROR: amount is missing")
-		respondWithError(w, http.StatusBadRequest, "amount is required")
+		respondWithAPIError(w, apierror.Invalid("amount", "amount is required"))
 		return
 	}
 
@@ -57,7 +72,12 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Date: %s", date)
 	if date == "" {
 		log.Println("ERROR: date is missing")
-		respondWithError(w, http.StatusBadRequest, "date is required")
+		respondWithAPIError(w, apierror.Invalid("date", "date is required"))
+		return
+	}
+	date, err := calendar.ParseDate(date)
+	if err != nil {
+		respondWithAPIError(w, apierror.Invalid("date", "date "+err.Error()))
 		return
 	}
 
@@ -65,105 +85,44 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Category: %s", category)
 	if category == "" {
 		log.Println("ERROR: category is missing")
-		respondWithError(w, http.StatusBadRequest, "category is required")
+		respondWithAPIError(w, apierror.Invalid("category", "category is required"))
 		return
 	}
 
-	if category != string(models.ExpenseCategoryMaterials) &&
-		category != string(models.ExpenseCategoryLabor) &&
-		category != string(models.ExpenseCategoryEquipment) &&
-		category != string(models.ExpenseCategoryOther) {
+	if !validExpenseCategory(category) {
 		log.Printf("ERROR: Invalid category: %s", category)
-		respondWithError(w, http.StatusBadRequest, "Invalid category. Must be one of: materials, labor, equipment, other")
+		respondWithAPIError(w, apierror.Invalid("category", "Invalid category. Must be one of: materials, labor, equipment, other"))
 		return
 	}
 
 	db := database.GetDB()
-	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
-	if err != nil {
-		log.Printf("ERROR: Failed to fetch project: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to verify project access")
+	contractID, paidBy, apiErr := expenseContract(db, projectID, userCtx, r.FormValue("contract_id"))
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	log.Printf("Project found - Owner ID: %v", ownerID)
-
-	isOwner := ownerID == userCtx.UserID
-
-	var isContractor bool
-	if !isOwner {
-		db.QueryRow(`
-			SELECT EXISTS(
-				SELECT 1 FROM contracts
-				WHERE project_id = $1 AND contractor_id = $2
-			)
-		`, projectID, userCtx.UserID).Scan(&isContractor)
-	}
-
-	log.Printf("Access check - isOwner: %v, isContractor: %v", isOwner, isContractor)
 
-	if !isOwner && !isContractor {
-		log.Println("ERROR: User doesn't have access to this project")
-		respondWithError(w, http.StatusForbidden, "Access denied")
+	parsedAmount, apiErr := parseAmount("amount", amount)
+	if apiErr != nil {
+		log.Printf("ERROR: Invalid amount: %s, error: %s", amount, apiErr.Message)
+		respondWithAPIError(w, apiErr)
 		return
 	}
+	log.Printf("Amount parsed: %s", parsedAmount)
 
-	var paidBy string
-	var contractID string
-
-	if isOwner {
-		paidBy = string(models.ExpensePaidByOwner)
-		contractIDParam := r.FormValue("contract_id")
-		if contractIDParam == "" {
-			log.Println("ERROR: contract_id is required for owner")
-			respondWithError(w, http.StatusBadRequest, "contract_id is required")
-			return
-		}
-
-		var exists bool
-		err = db.QueryRow(`
-			SELECT EXISTS(
-				SELECT 1 FROM contracts
-				WHERE id = $1 AND project_id = $2 AND owner_id = $3
-			)
-		`, contractIDParam, projectID, userCtx.UserID).Scan(&exists)
-
-		if err != nil || !exists {
-			log.Printf("ERROR: Invalid contract_id: %v", err)
-			respondWithError(w, http.StatusBadRequest, "Invalid contract_id for this project")
-			return
-		}
-		contractID = contractIDParam
-		log.Printf("Owner adding expense - Contract ID: %s, Paid By: owner", contractID)
-	} else {
-		paidBy = string(models.ExpensePaidByContractor)
-
-		contractQuery := `
-			SELECT c.id
-			FROM contracts c
-			WHERE c.project_id = $1
-			  AND (c.contractor_id = $2
-			       OR c.contractor_id IN (
-			           SELECT contractor_id FROM employees WHERE user_id = $2
-			       ))
-			LIMIT 1
-		`
-		err = db.QueryRow(contractQuery, projectID, userCtx.UserID).Scan(&contractID)
-		if err != nil {
-			log.Printf("ERROR: Failed to determine contract_id for contractor: %v", err)
-			respondWithError(w, http.StatusInternalServerError, "Failed to determine contract")
-			return
-		}
-		log.Printf("Contractor adding expense - Contract ID: %s, Paid By: contractor", contractID)
+	currency, apiErr := parseCurrencyField("currency", r.FormValue("currency"), "")
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
 	}
 
-	amountFloat, err := parseFloat(amount)
-	if err != nil || amountFloat <= 0 {
-		log.Printf("ERROR: Invalid amount: %s, error: %v", amount, err)
-		respondWithError(w, http.StatusBadRequest, "Invalid amount")
+	converted, apiErr := convertToProject(db, projectID, currency, parsedAmount, date)
+	if apiErr != nil {
+		log.Printf("ERROR: Failed to convert amount: %s", apiErr.Message)
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	log.Printf("Amount parsed: %f", amountFloat)
+	log.Printf("Currency: %s, rate: %s, converted amount: %s", converted.Currency, converted.Rate, converted.Converted)
 
 	var receiptPhotoURL *string
 	file, header, err := r.FormFile("receipt_photo")
@@ -174,17 +133,9 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 		ext := strings.ToLower(filepath.Ext(header.Filename))
 		log.Printf("File extension: %s", ext)
 
-		allowedExtensions := map[string]bool{
-			".jpg":  true,
-			".jpeg": true,
-			".png":  true,
-			".gif":  true,
-			".webp": true,
-		}
-
-		if !allowedExtensions[ext] {
+		if !receiptExtensions[ext] {
 			log.Printf("ERROR: Invalid file extension: %s", ext)
-			respondWithError(w, http.StatusBadRequest, "Invalid file type. Allowed: jpg, jpeg, png, gif, webp")
+			respondWithAPIError(w, apierror.ErrUnsupportedImage.WithMessage("Invalid file type. Allowed: jpg, jpeg, png, gif, webp"))
 			return
 		}
 
@@ -211,40 +162,19 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	log.Printf("Vendor: %s, Description: %s", vendor, description)
 
 	log.Println("Inserting expense into database...")
-	query := `
-		INSERT INTO expenses (project_id, contract_id, amount, vendor, date, category, description, paid_by, receipt_photo_url, added_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-		RETURNING id, project_id, contract_id, amount, vendor, date, category, description, paid_by, receipt_photo_url, added_by, created_at
-	`
-
-	var expense models.Expense
-	err = db.QueryRow(
-		query,
-		projectID,
-		contractID,
-		amountFloat,
-		nilIfEmpty(vendor),
-		date,
-		category,
-		nilIfEmpty(description),
-		paidBy,
-		receiptPhotoURL,
-		userCtx.UserID,
-	).Scan(
-		&expense.ID,
-		&expense.ProjectID,
-		&expense.ContractID,
-		&expense.Amount,
-		&expense.Vendor,
-		&expense.Date,
-		&expense.Category,
-		&expense.Description,
-		&expense.PaidBy,
-		&expense.ReceiptPhotoURL,
-		&expense.AddedBy,
-		&expense.CreatedAt,
-	)
-
+	expense, err := insertExpense(db, newExpense{
+		ProjectID:       projectID,
+		ContractID:      contractID,
+		Amount:          parsedAmount,
+		Conversion:      converted,
+		Vendor:          nilIfEmpty(vendor),
+		Date:            date,
+		Category:        category,
+		Description:     nilIfEmpty(description),
+		PaidBy:          paidBy,
+		ReceiptPhotoURL: receiptPhotoURL,
+		AddedBy:         userCtx.UserID,
+	})
 	if err != nil {
 		log.Printf("ERROR: Failed to create expense in database: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to create expense")
@@ -270,7 +200,8 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 						userInfo.Name,
 						userInfo.UserType,
 						participants.ProjectTitle,
-						amountFloat,
+						parsedAmount,
+						converted.Currency,
 						category,
 						descText,
 					)
@@ -287,7 +218,8 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 					userInfo.Name,
 					userInfo.UserType,
 					participants.ProjectTitle,
-					amountFloat,
+					parsedAmount,
+					converted.Currency,
 					category,
 					descText,
 				)
@@ -302,6 +234,323 @@ func AddExpense(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, expense)
 }
 
+// bulkExpense is a validated item of a bulk create.
+type bulkExpense struct {
+	item       models.BulkExpenseItem
+	date       string
+	conversion conversion
+	receipt    *multipart.FileHeader
+	receiptURL *string
+}
+
+// BulkCreateExpenses records several expenses on a project at once, such as a
+// crew's stack of receipts. The expenses field is a JSON array of items; an
+// item's receipt_photo names the file field holding its receipt. Every item is
+// validated before anything is uploaded and all of them are saved in one
+// transaction, so either every expense is created or none is.
+func BulkCreateExpenses(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+	db := database.GetDB()
+
+	contractID, paidBy, apiErr := expenseContract(db, projectID, userCtx, r.FormValue("contract_id"))
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	field := r.FormValue("expenses")
+	if field == "" {
+		respondWithAPIError(w, apierror.Invalid("expenses", "expenses is required"))
+		return
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(field), &items); err != nil {
+		respondWithAPIError(w, apierror.Invalid("expenses", "expenses must be a JSON array of expenses"))
+		return
+	}
+	if len(items) == 0 {
+		respondWithAPIError(w, apierror.Invalid("expenses", "expenses is required"))
+		return
+	}
+	if len(items) > maxBulkItems {
+		respondWithAPIError(w, apierror.Invalid("expenses", fmt.Sprintf("expenses has more than %d items", maxBulkItems)))
+		return
+	}
+
+	expenses := make([]bulkExpense, 0, len(items))
+	var problems []apierror.FieldError
+	for i, raw := range items {
+		prefix := fmt.Sprintf("expenses[%d].", i)
+		var item models.BulkExpenseItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			problems = append(problems, apierror.FieldError{Field: strings.TrimSuffix(prefix, "."), Message: "is not a valid expense: " + err.Error()})
+			continue
+		}
+		expense, found, apiErr := validateBulkExpense(db, projectID, prefix, item, r.MultipartForm.File)
+		if apiErr != nil {
+			respondWithAPIError(w, apiErr)
+			return
+		}
+		problems = append(problems, found...)
+		expenses = append(expenses, expense)
+	}
+
+	if len(problems) > 0 {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "The request has invalid expenses. Nothing was created.").WithDetails(problems...))
+		return
+	}
+
+	supabaseStorage := storage.NewSupabaseStorage()
+	var uploaded []string
+	for i := range expenses {
+		if expenses[i].receipt == nil {
+			continue
+		}
+		url, err := uploadFormFile(supabaseStorage, expenses[i].receipt, projectID)
+		if err != nil {
+			log.Printf("ERROR BulkCreateExpenses: Failed to upload receipt: %v", err)
+			deleteStoredFiles(uploaded...)
+			respondWithError(w, http.StatusInternalServerError, "Failed to upload receipt photo")
+			return
+		}
+		uploaded = append(uploaded, url)
+		expenses[i].receiptURL = &url
+	}
+
+	created, err := insertBulkExpenses(db, projectID, contractID, paidBy, userCtx.UserID, expenses)
+	if err != nil {
+		log.Printf("ERROR BulkCreateExpenses: Failed to create expenses: %v", err)
+		deleteStoredFiles(uploaded...)
+		respondWithDBError(w, err, "Failed to create expenses")
+		return
+	}
+
+	var total money.Amount
+	for _, e := range expenses {
+		total += e.conversion.Converted
+	}
+	projectCurrency := expenses[0].conversion.ProjectCurrency
+
+	notifyExpensesAdded(db, projectID, userCtx.UserID, len(created), total, projectCurrency)
+
+	respondWithJSON(w, http.StatusCreated, models.BulkCreateExpensesResponse{Expenses: created})
+}
+
+// notifyExpensesAdded tells the project's owner and contractor, other than the
+// user who added them, about several expenses in one email.
+func notifyExpensesAdded(db *sql.DB, projectID, userID string, count int, total money.Amount, currency money.Currency) {
+	participants, err := getProjectParticipants(db, projectID)
+	if err != nil {
+		return
+	}
+	userInfo, err := getUserInfo(db, userID)
+	if err != nil {
+		return
+	}
+
+	if participants.ContractorEmail.Valid && participants.ContractorName.Valid && userID != participants.ContractorID.String {
+		err = utils.SendExpensesAddedNotification(
+			participants.ContractorEmail.String,
+			participants.ContractorName.String,
+			userInfo.Name,
+			userInfo.UserType,
+			participants.ProjectTitle,
+			count,
+			total,
+			currency,
+		)
+		if err != nil {
+			log.Printf("Failed to send expenses notification to contractor: %v", err)
+		}
+	}
+
+	if userID != participants.OwnerID {
+		err = utils.SendExpensesAddedNotification(
+			participants.OwnerEmail,
+			participants.OwnerName,
+			userInfo.Name,
+			userInfo.UserType,
+			participants.ProjectTitle,
+			count,
+			total,
+			currency,
+		)
+		if err != nil {
+			log.Printf("Failed to send expenses notification to owner: %v", err)
+		}
+	}
+}
+
+// validateBulkExpense checks one item of a bulk create, returning its problems
+// with their fields prefixed. A non-nil error means the request cannot go on.
+func validateBulkExpense(db *sql.DB, projectID, prefix string, item models.BulkExpenseItem, files map[string][]*multipart.FileHeader) (bulkExpense, []apierror.FieldError, *apierror.Error) {
+	e := bulkExpense{item: item}
+	var problems []apierror.FieldError
+	if e.item.Amount <= 0 {
+		problems = append(problems, apierror.FieldError{Field: prefix + "amount", Message: "must be positive"})
+	}
+
+	if e.item.Date == "" {
+		problems = append(problems, apierror.FieldError{Field: prefix + "date", Message: "is required"})
+	} else if date, err := calendar.ParseDate(e.item.Date); err != nil {
+		problems = append(problems, apierror.FieldError{Field: prefix + "date", Message: err.Error()})
+	} else {
+		e.date = date
+	}
+
+	if e.item.Category == "" {
+		problems = append(problems, apierror.FieldError{Field: prefix + "category", Message: "is required"})
+	} else if !validExpenseCategory(string(e.item.Category)) {
+		problems = append(problems, apierror.FieldError{Field: prefix + "category", Message: "must be one of: materials, labor, equipment, other"})
+	}
+
+	currency, apiErr := parseCurrencyField("currency", string(e.item.Currency), "")
+	if apiErr != nil {
+		problems = append(problems, itemProblems(prefix, apiErr)...)
+	}
+
+	if e.item.ReceiptPhoto != "" {
+		headers := files[e.item.ReceiptPhoto]
+		switch {
+		case len(headers) == 0:
+			problems = append(problems, apierror.FieldError{Field: prefix + "receipt_photo", Message: "names no uploaded file"})
+		case !receiptExtensions[strings.ToLower(filepath.Ext(headers[0].Filename))]:
+			problems = append(problems, apierror.FieldError{Field: prefix + "receipt_photo", Message: "must be a jpg, jpeg, png, gif or webp file"})
+		case headers[0].Size > maxFileSize:
+			problems = append(problems, apierror.FieldError{Field: prefix + "receipt_photo", Message: "must be at most 5MB"})
+		default:
+			e.receipt = headers[0]
+		}
+	}
+
+	if len(problems) > 0 {
+		return e, problems, nil
+	}
+
+	e.conversion, apiErr = convertToProject(db, projectID, currency, e.item.Amount, e.date)
+	if apiErr != nil {
+		if apiErr.Status >= http.StatusInternalServerError || apiErr.Code == apierror.CodeProjectNotFound {
+			return e, nil, apiErr
+		}
+		return e, itemProblems(prefix, apiErr), nil
+	}
+	return e, nil, nil
+}
+
+// uploadFormFile stores an uploaded multipart file in folder.
+func uploadFormFile(store *storage.SupabaseStorage, header *multipart.FileHeader, folder string) (string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return store.UploadFile(file, header, folder)
+}
+
+// insertBulkExpenses saves the expenses of a bulk create in one transaction.
+func insertBulkExpenses(db *sql.DB, projectID, contractID, paidBy, userID string, expenses []bulkExpense) ([]models.Expense, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	created := make([]models.Expense, 0, len(expenses))
+	for _, e := range expenses {
+		expense, err := insertExpense(tx, newExpense{
+			ProjectID:       projectID,
+			ContractID:      contractID,
+			Amount:          e.item.Amount,
+			Conversion:      e.conversion,
+			Vendor:          e.item.Vendor,
+			Date:            e.date,
+			Category:        string(e.item.Category),
+			Description:     e.item.Description,
+			PaidBy:          paidBy,
+			ReceiptPhotoURL: e.receiptURL,
+			AddedBy:         userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, expense)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// newExpense holds the values of an expense about to be recorded.
+type newExpense struct {
+	ProjectID       string
+	ContractID      string
+	Amount          money.Amount
+	Conversion      conversion
+	Vendor          *string
+	Date            string
+	Category        string
+	Description     *string
+	PaidBy          string
+	ReceiptPhotoURL *string
+	AddedBy         string
+}
+
+func insertExpense(q queryRower, e newExpense) (models.Expense, error) {
+	query := `
+		INSERT INTO expenses (project_id, contract_id, amount, currency, exchange_rate, converted_amount, vendor, date, category, description, paid_by, receipt_photo_url, added_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, project_id, contract_id, amount, currency, exchange_rate, converted_amount, vendor, date::text, category, description, paid_by, receipt_photo_url, added_by, created_at
+	`
+
+	var expense models.Expense
+	err := q.QueryRow(
+		query,
+		e.ProjectID,
+		e.ContractID,
+		e.Amount,
+		e.Conversion.Currency,
+		e.Conversion.Rate,
+		e.Conversion.Converted,
+		e.Vendor,
+		e.Date,
+		e.Category,
+		e.Description,
+		e.PaidBy,
+		e.ReceiptPhotoURL,
+		e.AddedBy,
+	).Scan(
+		&expense.ID,
+		&expense.ProjectID,
+		&expense.ContractID,
+		&expense.Amount,
+		&expense.Currency,
+		&expense.ExchangeRate,
+		&expense.ConvertedAmount,
+		&expense.Vendor,
+		&expense.Date,
+		&expense.Category,
+		&expense.Description,
+		&expense.PaidBy,
+		&expense.ReceiptPhotoURL,
+		&expense.AddedBy,
+		&expense.CreatedAt,
+	)
+	return expense, err
+}
+
 func nilIfEmpty(s string) *string {
 	if s == "" {
 		return nil
@@ -309,16 +558,132 @@ func nilIfEmpty(s string) *string {
 	return &s
 }
 
-func parseFloat(s string) (float64, error) {
-	var f float64
-	_, err := fmt.Sscanf(s, "%f", &f)
-	return f, err
+func validExpenseCategory(category string) bool {
+	switch models.ExpenseCategory(category) {
+	case models.ExpenseCategoryMaterials, models.ExpenseCategoryLabor, models.ExpenseCategoryEquipment, models.ExpenseCategoryOther:
+		return true
+	}
+	return false
+}
+
+// parseAmount reads a required money form field, which must be positive.
+func parseAmount(field, value string) (money.Amount, *apierror.Error) {
+	amount, err := money.Parse(value)
+	if err != nil {
+		return 0, apierror.Invalid(field, field+" "+err.Error())
+	}
+	if amount <= 0 {
+		return 0, apierror.Invalid(field, field+" must be positive")
+	}
+	return amount, nil
+}
+
+// expenseContract works out which contract an expense the user records on
+// the project is charged to and who paid it. Owners name the contract; people
+// working for a contractor, including foremen allowed to log expenses,
+// record against their organization's contract.
+func expenseContract(db *sql.DB, projectID string, userCtx middleware.UserContext, contractIDParam string) (string, string, *apierror.Error) {
+	var ownerID string
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		return "", "", apierror.ErrProjectNotFound
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to fetch project: %v", err)
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return "", "", apiErr
+		}
+		return "", "", apierror.FromStatus(http.StatusInternalServerError, "Failed to verify project access")
+	}
+	log.Printf("Project found - Owner ID: %v", ownerID)
+
+	isOwner := ownerID == userCtx.UserID
+
+	var isContractor bool
+	if !isOwner {
+		db.QueryRow(`
+			SELECT EXISTS(
+				SELECT 1 FROM contracts
+				WHERE project_id = $1 AND status != 'terminated'
+				  AND organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
+			)
+		`, projectID, userCtx.UserID).Scan(&isContractor)
+	}
+
+	if !isOwner && !isContractor && userCtx.UserType == string(models.UserTypeEmployee) {
+		foreman, err := foremanAccess(db, projectID, userCtx.UserID)
+		isContractor = err == nil && foreman.CanLogExpenses
+	}
+
+	log.Printf("Access check - isOwner: %v, isContractor: %v", isOwner, isContractor)
+
+	if !isOwner && !isContractor {
+		log.Println("ERROR: User doesn't have access to this project")
+		return "", "", apierror.ErrAccessDenied
+	}
+
+	if isOwner {
+		if contractIDParam == "" {
+			log.Println("ERROR: contract_id is required for owner")
+			return "", "", apierror.ErrContractRequired
+		}
+
+		var exists bool
+		err = db.QueryRow(`
+			SELECT EXISTS(
+				SELECT 1 FROM contracts
+				WHERE id = $1 AND project_id = $2 AND owner_id = $3
+			)
+		`, contractIDParam, projectID, userCtx.UserID).Scan(&exists)
+
+		if err != nil || !exists {
+			log.Printf("ERROR: Invalid contract_id: %v", err)
+			return "", "", apierror.ErrInvalidContract
+		}
+		log.Printf("Owner adding expense - Contract ID: %s, Paid By: owner", contractIDParam)
+		return contractIDParam, string(models.ExpensePaidByOwner), nil
+	}
+
+	var contractID string
+	contractQuery := `
+		SELECT c.id
+		FROM contracts c
+		WHERE c.project_id = $1
+		  AND c.organization_id IN (
+		           SELECT organization_id FROM organization_members WHERE user_id = $2
+		           UNION
+		           SELECT organization_id FROM employees WHERE user_id = $2 AND is_active = true AND deleted_at IS NULL
+		       )
+		LIMIT 1
+	`
+	err = db.QueryRow(contractQuery, projectID, userCtx.UserID).Scan(&contractID)
+	if err != nil {
+		log.Printf("ERROR: Failed to determine contract_id for contractor: %v", err)
+		return "", "", apierror.FromStatus(http.StatusInternalServerError, "Failed to determine contract")
+	}
+	log.Printf("Contractor adding expense - Contract ID: %s, Paid By: contractor", contractID)
+	return contractID, string(models.ExpensePaidByContractor), nil
+}
+
+var expenseListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"date":       {Column: "e.date", Type: "date"},
+		"amount":     {Column: "e.amount", Type: "numeric"},
+		"created_at": {Column: "e.created_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-date",
+	IDColumn:    "e.id",
+	DateColumn:  "e.date",
+	Filters: map[string]string{
+		"paid_by":  "e.paid_by",
+		"category": "e.category",
+	},
 }
 
 func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -331,9 +696,9 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -341,7 +706,7 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -349,7 +714,8 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -359,61 +725,85 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	params, err := pagination.Parse(r, expenseListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
 		return
 	}
 
-	paidByFilter := r.URL.Query().Get("paid_by")
-	categoryFilter := r.URL.Query().Get("category")
-	startDate := r.URL.Query().Get("start_date")
-	endDate := r.URL.Query().Get("end_date")
 	contractFilter := r.URL.Query().Get("contract_id")
 
-	query := `
-		SELECT e.id, e.project_id, e.amount, e.vendor, e.date, e.category, e.description,
-		       e.paid_by, e.receipt_photo_url, e.added_by, e.created_at, u.name
+	from := `
 		FROM expenses e
 		JOIN users u ON e.added_by = u.id
-		WHERE e.project_id = $1
 	`
-	args := []interface{}{projectID}
-	argIndex := 2
+	q := &pagination.Query{}
+	q.Where("e.project_id = " + q.Arg(projectID))
+	q.Where("e.deleted_at IS NULL")
 
 	if isContractor {
-		query += " AND e.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractorContractID)
-		argIndex++
+		q.Where("e.contract_id = " + q.Arg(contractorContractID))
 	} else if contractFilter != "" {
-		query += " AND e.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractFilter)
-		argIndex++
+		q.Where("e.contract_id = " + q.Arg(contractFilter))
 	}
 
-	if paidByFilter != "" {
-		query += " AND e.paid_by = $" + strconv.Itoa(argIndex)
-		args = append(args, paidByFilter)
-		argIndex++
-	}
+	params.Filter(q)
 
-	if categoryFilter != "" {
-		query += " AND e.category = $" + strconv.Itoa(argIndex)
-		args = append(args, categoryFilter)
-		argIndex++
+	// The summary covers every matching expense, not just the current page,
+	// and its row count doubles as the page total. Totals are in the project
+	// currency.
+	summaryRows, err := db.Query(`
+		SELECT e.category, e.paid_by, COALESCE(SUM(e.converted_amount), 0), COUNT(*)
+		`+from+q.WhereSQL()+`
+		GROUP BY e.category, e.paid_by
+	`, q.Args()...)
+	if err != nil {
+		log.Printf("ERROR GetProjectExpenses: Failed to summarize expenses: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch expenses")
+		return
 	}
+	defer summaryRows.Close()
 
-	if startDate != "" {
-		query += " AND e.date >= $" + strconv.Itoa(argIndex)
-		args = append(args, startDate)
-		argIndex++
+	total := 0
+	var totalAmount, totalByOwner, totalByContractor money.Amount
+	categoryTotals := make(map[string]money.Amount)
+
+	for summaryRows.Next() {
+		var category string
+		var paidBy models.ExpensePaidBy
+		var amount money.Amount
+		var count int
+		if err := summaryRows.Scan(&category, &paidBy, &amount, &count); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to parse expense summary")
+			return
+		}
+
+		total += count
+		totalAmount += amount
+
+		if paidBy == models.ExpensePaidByOwner {
+			totalByOwner += amount
+		} else if paidBy == models.ExpensePaidByContractor {
+			totalByContractor += amount
+		}
+
+		categoryTotals[category] += amount
 	}
 
-	if endDate != "" {
-		query += " AND e.date <= $" + strconv.Itoa(argIndex)
-		args = append(args, endDate)
-		argIndex++
+	if err = summaryRows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating expense summary")
+		return
 	}
 
-	query += " ORDER BY e.date DESC, e.created_at DESC"
+	query, args := params.Select(`
+		e.id, e.project_id, e.amount, e.currency, e.exchange_rate, e.converted_amount,
+		e.vendor, e.date::text, e.category, e.description,
+		e.paid_by, e.receipt_photo_url, e.added_by, e.created_at, e.version, u.name,
+		`+params.CursorColumn(), from, q)
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -427,18 +817,19 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 		AddedByName string `json:"added_by_name"`
 	}
 
-	expenses := []ExpenseWithUser{}
-	totalAmount := 0.0
-	totalByOwner := 0.0
-	totalByContractor := 0.0
-	categoryTotals := make(map[string]float64)
+	var expenses []ExpenseWithUser
+	var cursors []string
 
 	for rows.Next() {
 		var exp ExpenseWithUser
+		var cursor string
 		err := rows.Scan(
 			&exp.ID,
 			&exp.ProjectID,
 			&exp.Amount,
+			&exp.Currency,
+			&exp.ExchangeRate,
+			&exp.ConvertedAmount,
 			&exp.Vendor,
 			&exp.Date,
 			&exp.Category,
@@ -447,7 +838,9 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 			&exp.ReceiptPhotoURL,
 			&exp.AddedBy,
 			&exp.CreatedAt,
+			&exp.Version,
 			&exp.AddedByName,
+			&cursor,
 		)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Failed to parse expense")
@@ -455,15 +848,12 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 		}
 
 		expenses = append(expenses, exp)
-		totalAmount += exp.Amount
-
-		if exp.PaidBy == models.ExpensePaidByOwner {
-			totalByOwner += exp.Amount
-		} else if exp.PaidBy == models.ExpensePaidByContractor {
-			totalByContractor += exp.Amount
-		}
+		cursors = append(cursors, cursor)
+	}
 
-		categoryTotals[string(exp.Category)] += exp.Amount
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating expenses")
+		return
 	}
 
 	summary := map[string]interface{}{
@@ -473,9 +863,12 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 		"breakdown_by_category": categoryTotals,
 	}
 
-	response := map[string]interface{}{
-		"expenses": expenses,
-		"summary":  summary,
+	response := struct {
+		pagination.Page[ExpenseWithUser]
+		Summary map[string]interface{} `json:"summary"`
+	}{
+		Page:    pagination.NewPage(params, expenses, cursors, total),
+		Summary: summary,
 	}
 
 	respondWithJSON(w, http.StatusOK, response)
@@ -483,7 +876,11 @@ func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
 
 func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
-	userCtx := r.Context().Value(middleware.UserContextKey).(*middleware.UserContext)
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
 
 	vars := mux.Vars(r)
 	expenseID := vars["id"]
@@ -491,15 +888,19 @@ func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 	var exp models.Expense
 	var addedByName string
 	err := db.QueryRow(`
-		SELECT e.id, e.project_id, e.amount, e.vendor, e.date, e.category, e.description, 
-		       e.paid_by, e.receipt_photo_url, e.added_by, e.created_at, u.name
+		SELECT e.id, e.project_id, e.amount, e.currency, e.exchange_rate, e.converted_amount,
+		       e.vendor, e.date::text, e.category, e.description, 
+		       e.paid_by, e.receipt_photo_url, e.added_by, e.created_at, e.version, u.name
 		FROM expenses e
 		JOIN users u ON e.added_by = u.id
-		WHERE e.id = $1
+		WHERE e.id = $1 AND e.deleted_at IS NULL
 	`, expenseID).Scan(
 		&exp.ID,
 		&exp.ProjectID,
 		&exp.Amount,
+		&exp.Currency,
+		&exp.ExchangeRate,
+		&exp.ConvertedAmount,
 		&exp.Vendor,
 		&exp.Date,
 		&exp.Category,
@@ -508,11 +909,12 @@ func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 		&exp.ReceiptPhotoURL,
 		&exp.AddedBy,
 		&exp.CreatedAt,
+		&exp.Version,
 		&addedByName,
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Expense not found")
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
 		return
 	}
 	if err != nil {
@@ -521,26 +923,31 @@ func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var ownerID string
-	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", exp.ProjectID).Scan(&ownerID)
+	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", exp.ProjectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to verify project access")
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, exp.ProjectID, userCtx.UserID)
 
 	var isContractor bool
 	if !isOwner {
 		db.QueryRow(`
         SELECT EXISTS(
-            SELECT 1 FROM project_contractors 
-            WHERE project_id = $1 AND contractor_id = $2
+            SELECT 1 FROM contracts
+            WHERE project_id = $1 AND status != 'terminated'
+              AND organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
         )
     `, exp.ProjectID, userCtx.UserID).Scan(&isContractor)
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -548,6 +955,9 @@ func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 		"id":                exp.ID,
 		"project_id":        exp.ProjectID,
 		"amount":            exp.Amount,
+		"currency":          exp.Currency,
+		"exchange_rate":     exp.ExchangeRate,
+		"converted_amount":  exp.ConvertedAmount,
 		"vendor":            exp.Vendor,
 		"date":              exp.Date,
 		"category":          exp.Category,
@@ -557,8 +967,10 @@ func GetExpenseByID(w http.ResponseWriter, r *http.Request) {
 		"added_by":          exp.AddedBy,
 		"added_by_name":     addedByName,
 		"created_at":        exp.CreatedAt,
+		"version":           exp.Version,
 	}
 
+	setVersionETag(w, exp.Version)
 	respondWithJSON(w, http.StatusOK, response)
 }
 
@@ -570,11 +982,11 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 	expenseID := vars["id"]
 
 	var existing models.Expense
-	err := db.QueryRow("SELECT id, project_id, added_by, receipt_photo_url FROM expenses WHERE id = $1", expenseID).
-		Scan(&existing.ID, &existing.ProjectID, &existing.AddedBy, &existing.ReceiptPhotoURL)
+	err := db.QueryRow("SELECT id, project_id, added_by, receipt_photo_url, currency, version FROM expenses WHERE id = $1 AND deleted_at IS NULL", expenseID).
+		Scan(&existing.ID, &existing.ProjectID, &existing.AddedBy, &existing.ReceiptPhotoURL, &existing.Currency, &existing.Version)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Expense not found")
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
 		return
 	}
 	if err != nil {
@@ -587,46 +999,61 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if versionMismatch(r, existing.Version) {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
+
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	amount := r.FormValue("amount")
 	if amount == "" {
-		respondWithError(w, http.StatusBadRequest, "amount is required")
+		respondWithAPIError(w, apierror.Invalid("amount", "amount is required"))
 		return
 	}
 
 	date := r.FormValue("date")
 	if date == "" {
-		respondWithError(w, http.StatusBadRequest, "date is required")
+		respondWithAPIError(w, apierror.Invalid("date", "date is required"))
+		return
+	}
+	date, err = calendar.ParseDate(date)
+	if err != nil {
+		respondWithAPIError(w, apierror.Invalid("date", "date "+err.Error()))
 		return
 	}
 
 	category := r.FormValue("category")
 	if category == "" {
-		respondWithError(w, http.StatusBadRequest, "category is required")
+		respondWithAPIError(w, apierror.Invalid("category", "category is required"))
 		return
 	}
 
-	if category != string(models.ExpenseCategoryMaterials) &&
-		category != string(models.ExpenseCategoryLabor) &&
-		category != string(models.ExpenseCategoryEquipment) &&
-		category != string(models.ExpenseCategoryOther) {
-		respondWithError(w, http.StatusBadRequest, "Invalid category. Must be one of: materials, labor, equipment, other")
+	if !validExpenseCategory(category) {
+		respondWithAPIError(w, apierror.Invalid("category", "Invalid category. Must be one of: materials, labor, equipment, other"))
 		return
 	}
 
-	var amountFloat float64
-	if _, err := strconv.ParseFloat(amount, 64); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid amount format")
+	parsedAmount, apiErr := parseAmount("amount", amount)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	amountFloat, _ = strconv.ParseFloat(amount, 64)
 
-	if amountFloat <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Amount must be positive")
+	// The rate is looked up again, since the amount, date or currency may
+	// have changed.
+	currency, apiErr := parseCurrencyField("currency", r.FormValue("currency"), existing.Currency)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	converted, apiErr := convertToProject(db, existing.ProjectID, currency, parsedAmount, date)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
@@ -637,7 +1064,7 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 
 		ext := strings.ToLower(filepath.Ext(header.Filename))
 		if !allowedExtensions[ext] {
-			respondWithError(w, http.StatusBadRequest, "Invalid file type. Allowed: jpg, jpeg, png, gif, webp")
+			respondWithAPIError(w, apierror.ErrUnsupportedImage.WithMessage("Invalid file type. Allowed: jpg, jpeg, png, gif, webp"))
 			return
 		}
 
@@ -653,17 +1080,26 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 	vendor := r.FormValue("vendor")
 	description := r.FormValue("description")
 
-	_, err = db.Exec(`
+	var version int
+	err = db.QueryRow(`
 		UPDATE expenses
 		SET amount = $1, vendor = $2, date = $3, category = $4,
-		    description = $5, receipt_photo_url = $6
-		WHERE id = $7
-	`, amountFloat, nilIfEmpty(vendor), date, category, nilIfEmpty(description), receiptPhotoURL, expenseID)
+		    description = $5, receipt_photo_url = $6,
+		    currency = $9, exchange_rate = $10, converted_amount = $11
+		WHERE id = $7 AND deleted_at IS NULL AND ($8::int IS NULL OR version = $8)
+		RETURNING version
+	`, parsedAmount, nilIfEmpty(vendor), date, category, nilIfEmpty(description), receiptPhotoURL, expenseID, ifMatchVersion(r),
+		converted.Currency, converted.Rate, converted.Converted).Scan(&version)
 
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to update expense")
+		respondWithDBError(w, err, "Failed to update expense")
 		return
 	}
+	setVersionETag(w, version)
 
 	participants, err := getProjectParticipants(db, existing.ProjectID)
 	if err == nil {
@@ -682,7 +1118,8 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 						userInfo.Name,
 						userInfo.UserType,
 						participants.ProjectTitle,
-						amountFloat,
+						parsedAmount,
+						converted.Currency,
 						category,
 						descText,
 					)
@@ -699,7 +1136,8 @@ func UpdateExpense(w http.ResponseWriter, r *http.Request) {
 					userInfo.Name,
 					userInfo.UserType,
 					participants.ProjectTitle,
-					amountFloat,
+					parsedAmount,
+					converted.Currency,
 					category,
 					descText,
 				)
@@ -717,7 +1155,7 @@ func DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -725,11 +1163,11 @@ func DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	expenseID := vars["id"]
 
 	var exp models.Expense
-	err := db.QueryRow("SELECT id, project_id, added_by FROM expenses WHERE id = $1", expenseID).
+	err := db.QueryRow("SELECT id, project_id, added_by FROM expenses WHERE id = $1 AND deleted_at IS NULL", expenseID).
 		Scan(&exp.ID, &exp.ProjectID, &exp.AddedBy)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Expense not found")
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
 		return
 	}
 	if err != nil {
@@ -738,7 +1176,11 @@ func DeleteExpense(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var ownerID sql.NullString
-	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", exp.ProjectID).Scan(&ownerID)
+	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", exp.ProjectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to verify project ownership")
 		return
@@ -752,7 +1194,10 @@ func DeleteExpense(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = db.Exec("DELETE FROM expenses WHERE id = $1", expenseID)
+	_, err = db.Exec(`
+		UPDATE expenses SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, expenseID, userCtx.UserID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to delete expense")
 		return
@@ -764,7 +1209,7 @@ func DeleteExpense(w http.ResponseWriter, r *http.Request) {
 func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -775,13 +1220,16 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 
 	var ownerID string
 	var projectName string
-	err := db.QueryRow("SELECT owner_id, title FROM projects WHERE id = $1", projectID).Scan(&ownerID, &projectName)
+	var projectCurrency money.Currency
+	var timeZone calendar.Zone
+	err := db.QueryRow("SELECT owner_id, title, currency, time_zone FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).
+		Scan(&ownerID, &projectName, &projectCurrency, &timeZone)
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -789,7 +1237,8 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -809,16 +1258,26 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 	endDate := r.URL.Query().Get("end_date")
 	contractFilter := r.URL.Query().Get("contract_id")
 
+	for param, value := range map[string]string{"start_date": startDate, "end_date": endDate} {
+		if value == "" {
+			continue
+		}
+		if _, err := calendar.ParseDate(value); err != nil {
+			respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, param+" "+err.Error()))
+			return
+		}
+	}
+
 	if paidBy == "" {
 		paidBy = "all"
 	}
 
 	query := `
-		SELECT e.id, e.project_id, e.amount, e.vendor, e.date, e.category, e.description,
+		SELECT e.id, e.project_id, e.amount, e.currency, e.converted_amount, e.vendor, e.date::text, e.category, e.description,
 		       e.paid_by, e.added_by, e.created_at, u.name
 		FROM expenses e
 		JOIN users u ON e.added_by = u.id
-		WHERE e.project_id = $1
+		WHERE e.project_id = $1 AND e.deleted_at IS NULL
 	`
 	args := []interface{}{projectID}
 	argIndex := 2
@@ -894,7 +1353,7 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 
-	headers := []string{"Date", "Vendor", "Category", "Amount", "Paid By", "Description", "Added By"}
+	headers := []string{"Date", "Vendor", "Category", "Amount", "Amount (" + string(projectCurrency) + ")", "Paid By", "Description", "Added By"}
 	for i, header := range headers {
 		cell := fmt.Sprintf("%c1", 'A'+i)
 		f.SetCellValue(sheetName, cell, header)
@@ -904,33 +1363,35 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 	f.SetColWidth(sheetName, "A", "A", 12)
 	f.SetColWidth(sheetName, "B", "B", 20)
 	f.SetColWidth(sheetName, "C", "C", 15)
-	f.SetColWidth(sheetName, "D", "D", 12)
-	f.SetColWidth(sheetName, "E", "E", 15)
-	f.SetColWidth(sheetName, "F", "F", 30)
-	f.SetColWidth(sheetName, "G", "G", 20)
+	f.SetColWidth(sheetName, "D", "D", 14)
+	f.SetColWidth(sheetName, "E", "E", 16)
+	f.SetColWidth(sheetName, "F", "F", 15)
+	f.SetColWidth(sheetName, "G", "G", 30)
+	f.SetColWidth(sheetName, "H", "H", 20)
+
+	styles := newCurrencyStyles(f)
 
 	rowIndex := 2
-	totalAmount := 0.0
-	totalByOwner := 0.0
-	totalByContractor := 0.0
+	var totalAmount, totalByOwner, totalByContractor money.Amount
 
 	for rows.Next() {
 		var id, projectID, addedBy string
-		var amount float64
+		var amount, converted money.Amount
+		var currency money.Currency
 		var vendor, date, category, description, paidBy, addedByName string
 		var createdAt time.Time
 
-		err := rows.Scan(&id, &projectID, &amount, &vendor, &date, &category, &description,
+		err := rows.Scan(&id, &projectID, &amount, &currency, &converted, &vendor, &date, &category, &description,
 			&paidBy, &addedBy, &createdAt, &addedByName)
 		if err != nil {
 			continue
 		}
 
-		totalAmount += amount
+		totalAmount += converted
 		if paidBy == string(models.ExpensePaidByOwner) {
-			totalByOwner += amount
+			totalByOwner += converted
 		} else if paidBy == string(models.ExpensePaidByContractor) {
-			totalByContractor += amount
+			totalByContractor += converted
 		}
 
 		categoryLabel := getCategoryLabel(category)
@@ -939,15 +1400,22 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", rowIndex), date)
 		f.SetCellValue(sheetName, fmt.Sprintf("B%d", rowIndex), vendor)
 		f.SetCellValue(sheetName, fmt.Sprintf("C%d", rowIndex), categoryLabel)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", rowIndex), amount)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", rowIndex), paidByLabel)
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", rowIndex), description)
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", rowIndex), addedByName)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", rowIndex), amount.Float64())
+		f.SetCellStyle(sheetName, fmt.Sprintf("D%d", rowIndex), fmt.Sprintf("D%d", rowIndex), styles.get(currency))
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", rowIndex), converted.Float64())
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", rowIndex), paidByLabel)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", rowIndex), description)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", rowIndex), addedByName)
 
 		rowIndex++
 	}
 
+	if rowIndex > 2 {
+		f.SetCellStyle(sheetName, "E2", fmt.Sprintf("E%d", rowIndex-1), styles.get(projectCurrency))
+	}
+
 	summaryStartRow := rowIndex + 2
+	totalsFormat := projectCurrency.ExcelFormat()
 
 	summaryStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
@@ -959,21 +1427,22 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 			Color:   []string{"#E7E6E6"},
 			Pattern: 1,
 		},
+		CustomNumFmt: &totalsFormat,
 	})
 
-	f.SetCellValue(sheetName, fmt.Sprintf("C%d", summaryStartRow), "Total Spent:")
-	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow), totalAmount)
-	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", summaryStartRow), fmt.Sprintf("D%d", summaryStartRow), summaryStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow), "Total Spent:")
+	f.SetCellValue(sheetName, fmt.Sprintf("E%d", summaryStartRow), totalAmount.Float64())
+	f.SetCellStyle(sheetName, fmt.Sprintf("D%d", summaryStartRow), fmt.Sprintf("E%d", summaryStartRow), summaryStyle)
 
-	f.SetCellValue(sheetName, fmt.Sprintf("C%d", summaryStartRow+1), "Paid by Owner:")
-	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow+1), totalByOwner)
-	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", summaryStartRow+1), fmt.Sprintf("D%d", summaryStartRow+1), summaryStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow+1), "Paid by Owner:")
+	f.SetCellValue(sheetName, fmt.Sprintf("E%d", summaryStartRow+1), totalByOwner.Float64())
+	f.SetCellStyle(sheetName, fmt.Sprintf("D%d", summaryStartRow+1), fmt.Sprintf("E%d", summaryStartRow+1), summaryStyle)
 
-	f.SetCellValue(sheetName, fmt.Sprintf("C%d", summaryStartRow+2), "Paid by Contractor:")
-	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow+2), totalByContractor)
-	f.SetCellStyle(sheetName, fmt.Sprintf("C%d", summaryStartRow+2), fmt.Sprintf("D%d", summaryStartRow+2), summaryStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("D%d", summaryStartRow+2), "Paid by Contractor:")
+	f.SetCellValue(sheetName, fmt.Sprintf("E%d", summaryStartRow+2), totalByContractor.Float64())
+	f.SetCellStyle(sheetName, fmt.Sprintf("D%d", summaryStartRow+2), fmt.Sprintf("E%d", summaryStartRow+2), summaryStyle)
 
-	timestamp := time.Now().Format("2006-01-02")
+	timestamp := timeZone.Today(time.Now())
 	filename := fmt.Sprintf("expenses-%s-%s.xlsx", utils.SanitizeFilename(projectName), timestamp)
 
 	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
@@ -985,6 +1454,27 @@ func DownloadExpensesExcel(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// currencyStyles creates one spreadsheet cell style per currency, so every
+// amount shows its own symbol and decimal places.
+type currencyStyles struct {
+	f      *excelize.File
+	styles map[money.Currency]int
+}
+
+func newCurrencyStyles(f *excelize.File) *currencyStyles {
+	return &currencyStyles{f: f, styles: make(map[money.Currency]int)}
+}
+
+func (s *currencyStyles) get(c money.Currency) int {
+	if style, ok := s.styles[c]; ok {
+		return style
+	}
+	format := c.ExcelFormat()
+	style, _ := s.f.NewStyle(&excelize.Style{CustomNumFmt: &format})
+	s.styles[c] = style
+	return style
+}
+
 func getCategoryLabel(category string) string {
 	switch category {
 	case "materials":
@@ -1010,155 +1500,3 @@ func getPaidByLabel(paidBy string) string {
 		return paidBy
 	}
 }
-
-// func GetProjectExpenses(w http.ResponseWriter, r *http.Request) {
-// 	userCtx, ok := middleware.GetUserFromContext(r.Context())
-// 	if !ok {
-// 		respondWithError(w, http.StatusUnauthorized, "User not found in context")
-// 		return
-// 	}
-
-// 	projectID := mux.Vars(r)["id"]
-// 	if projectID == "" {
-// 		respondWithError(w, http.StatusBadRequest, "Project ID is required")
-// 		return
-// 	}
-
-// 	db := database.GetDB()
-
-// 	var ownerID, contractorID sql.NullString
-// 	err := db.QueryRow("SELECT owner_id, contractor_id FROM projects WHERE id = $1", projectID).Scan(&ownerID, &contractorID)
-
-// 	if err == sql.ErrNoRows {
-// 		respondWithError(w, http.StatusNotFound, "Project not found")
-// 		return
-// 	}
-
-// 	if err != nil {
-// 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
-// 		return
-// 	}
-
-// 	isOwner := ownerID.Valid && ownerID.String == userCtx.UserID
-// 	isContractor := contractorID.Valid && contractorID.String == userCtx.UserID
-
-// 	if !isOwner && !isContractor {
-// 		respondWithError(w, http.StatusForbidden, "Only project owner or assigned contractor can view expenses")
-// 		return
-// 	}
-
-// 	paidBy := r.URL.Query().Get("paid_by")
-// 	category := r.URL.Query().Get("category")
-// 	startDate := r.URL.Query().Get("start_date")
-// 	endDate := r.URL.Query().Get("end_date")
-
-// 	if paidBy == "" {
-// 		paidBy = "all"
-// 	}
-
-// 	if paidBy != "all" && paidBy != string(models.ExpensePaidByOwner) && paidBy != string(models.ExpensePaidByContractor) {
-// 		respondWithError(w, http.StatusBadRequest, "Invalid paid_by filter")
-// 		return
-// 	}
-
-// 	query := `
-// 		SELECT e.id, e.project_id, e.amount, e.vendor, e.date, e.category, e.description,
-// 		       e.paid_by, e.receipt_photo_url, e.added_by, e.created_at, u.name
-// 		FROM expenses e
-// 		JOIN users u ON e.added_by = u.id
-// 		WHERE e.project_id = $1
-// 	`
-// 	args := []interface{}{projectID}
-// 	argIndex := 2
-
-// 	if paidBy != "all" {
-// 		query += " AND e.paid_by = $" + strconv.Itoa(argIndex)
-// 		args = append(args, paidBy)
-// 		argIndex++
-// 	}
-
-// 	if category != "" {
-// 		query += " AND e.category = $" + strconv.Itoa(argIndex)
-// 		args = append(args, category)
-// 		argIndex++
-// 	}
-
-// 	if startDate != "" {
-// 		query += " AND e.date >= $" + strconv.Itoa(argIndex)
-// 		args = append(args, startDate)
-// 		argIndex++
-// 	}
-
-// 	if endDate != "" {
-// 		query += " AND e.date <= $" + strconv.Itoa(argIndex)
-// 		args = append(args, endDate)
-// 		argIndex++
-// 	}
-
-// 	query += " ORDER BY e.date DESC, e.created_at DESC"
-
-// 	rows, err := db.Query(query, args...)
-// 	if err != nil {
-// 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch expenses")
-// 		return
-// 	}
-// 	defer rows.Close()
-
-// 	type ExpenseWithUser struct {
-// 		models.Expense
-// 		AddedByName string `json:"added_by_name"`
-// 	}
-
-// 	expenses := []ExpenseWithUser{}
-// 	totalAmount := 0.0
-// 	totalByOwner := 0.0
-// 	totalByContractor := 0.0
-// 	categoryTotals := make(map[string]float64)
-
-// 	for rows.Next() {
-// 		var exp ExpenseWithUser
-// 		err := rows.Scan(
-// 			&exp.ID,
-// 			&exp.ProjectID,
-// 			&exp.Amount,
-// 			&exp.Vendor,
-// 			&exp.Date,
-// 			&exp.Category,
-// 			&exp.Description,
-// 			&exp.PaidBy,
-// 			&exp.ReceiptPhotoURL,
-// 			&exp.AddedBy,
-// 			&exp.CreatedAt,
-// 			&exp.AddedByName,
-// 		)
-// 		if err != nil {
-// 			respondWithError(w, http.StatusInternalServerError, "Failed to parse expense")
-// 			return
-// 		}
-
-// 		expenses = append(expenses, exp)
-// 		totalAmount += exp.Amount
-
-// 		if exp.PaidBy == models.ExpensePaidByOwner {
-// 			totalByOwner += exp.Amount
-// 		} else if exp.PaidBy == models.ExpensePaidByContractor {
-// 			totalByContractor += exp.Amount
-// 		}
-
-// 		categoryTotals[string(exp.Category)] += exp.Amount
-// 	}
-
-// 	summary := map[string]interface{}{
-// 		"total_expenses":        totalAmount,
-// 		"total_by_owner":        totalByOwner,
-// 		"total_by_contractor":   totalByContractor,
-// 		"breakdown_by_category": categoryTotals,
-// 	}
-
-// 	response := map[string]interface{}{
-// 		"expenses": expenses,
-// 		"summary":  summary,
-// 	}
-
-// 	respondWithJSON(w, http.StatusOK, response)
-// }
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+)
+
+const projectForemanSelect = `
+	SELECT pf.id, pf.project_id, pf.employee_id, e.user_id, e.name, e.email, e.organization_id, c.id,
+	       pf.can_post_updates, pf.can_log_expenses, pf.can_approve_timesheets, pf.can_view_crew_hours,
+	       pf.granted_by, pf.created_at, pf.updated_at
+	FROM project_foremen pf
+	JOIN employees e ON pf.employee_id = e.id AND e.deleted_at IS NULL
+	JOIN contracts c ON c.project_id = pf.project_id AND c.organization_id = e.organization_id
+	                AND c.status != 'terminated'
+`
+
+func scanProjectForeman(row rowScanner) (*models.ProjectForeman, error) {
+	var f models.ProjectForeman
+	err := row.Scan(&f.ID, &f.ProjectID, &f.EmployeeID, &f.UserID, &f.Name, &f.Email, &f.OrganizationID, &f.ContractID,
+		&f.CanPostUpdates, &f.CanLogExpenses, &f.CanApproveTimesheets, &f.CanViewCrewHours,
+		&f.GrantedBy, &f.CreatedAt, &f.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// foremanAccess returns the foreman grant an employee holds on a project.
+// Grants only count while the employee is active and their organization still
+// holds a live contract on the project; otherwise sql.ErrNoRows is returned.
+func foremanAccess(q queryRower, projectID, userID string) (*models.ProjectForeman, error) {
+	return scanProjectForeman(q.QueryRow(projectForemanSelect+`
+		WHERE pf.project_id = $1 AND e.user_id = $2 AND e.is_active = true
+	`, projectID, userID))
+}
+
+// requireForemanManager checks that the caller may grant foreman permissions on
+// the project and writes the error response when they may not.
+func requireForemanManager(w http.ResponseWriter, db *sql.DB, projectID string, userCtx middleware.UserContext) bool {
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can manage foremen")
+		return false
+	}
+
+	hasAccess, err := contractorHasProjectAccess(db, projectID, userCtx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to verify project")
+		return false
+	}
+	if !hasAccess {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return false
+	}
+
+	return true
+}
+
+func ListProjectForemen(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) {
+		respondWithError(w, http.StatusForbidden, "Only contractors can view foremen")
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	hasAccess, err := contractorHasProjectAccess(db, projectID, userCtx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to verify project")
+		return
+	}
+	if !hasAccess {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	rows, err := db.Query(projectForemanSelect+`
+		WHERE pf.project_id = $1 AND e.organization_id = $2
+		ORDER BY e.name ASC
+	`, projectID, userCtx.OrganizationID)
+	if err != nil {
+		log.Printf("ERROR ListProjectForemen: Failed to query foremen: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch foremen")
+		return
+	}
+	defer rows.Close()
+
+	foremen := []models.ProjectForeman{}
+	for rows.Next() {
+		f, err := scanProjectForeman(rows)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan foreman")
+			return
+		}
+		foremen = append(foremen, *f)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating foremen")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, foremen)
+}
+
+func SetProjectForeman(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	var req models.SetProjectForemanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.EmployeeID == "" {
+		respondWithAPIError(w, apierror.Invalid("employee_id", "employee_id is required"))
+		return
+	}
+
+	if !req.CanPostUpdates && !req.CanLogExpenses && !req.CanApproveTimesheets && !req.CanViewCrewHours {
+		respondWithError(w, http.StatusBadRequest, "At least one permission is required. Remove the foreman instead.")
+		return
+	}
+
+	db := database.GetDB()
+
+	if !requireForemanManager(w, db, projectID, userCtx) {
+		return
+	}
+
+	var organizationID string
+	var isActive bool
+	err := db.QueryRow("SELECT organization_id, is_active FROM employees WHERE id = $1 AND deleted_at IS NULL", req.EmployeeID).
+		Scan(&organizationID, &isActive)
+	if err == sql.ErrNoRows || (err == nil && !userCtx.InOrganization(organizationID)) {
+		respondWithAPIError(w, apierror.ErrEmployeeNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch employee")
+		return
+	}
+
+	if !isActive {
+		respondWithError(w, http.StatusBadRequest, "Inactive employees cannot be foremen")
+		return
+	}
+
+	var foremanID string
+	err = db.QueryRow(`
+		INSERT INTO project_foremen (project_id, employee_id, can_post_updates, can_log_expenses,
+		                             can_approve_timesheets, can_view_crew_hours, granted_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (project_id, employee_id) DO UPDATE SET
+			can_post_updates = EXCLUDED.can_post_updates,
+			can_log_expenses = EXCLUDED.can_log_expenses,
+			can_approve_timesheets = EXCLUDED.can_approve_timesheets,
+			can_view_crew_hours = EXCLUDED.can_view_crew_hours,
+			granted_by = EXCLUDED.granted_by
+		RETURNING id
+	`, projectID, req.EmployeeID, req.CanPostUpdates, req.CanLogExpenses, req.CanApproveTimesheets,
+		req.CanViewCrewHours, userCtx.UserID).Scan(&foremanID)
+	if err != nil {
+		log.Printf("ERROR SetProjectForeman: Failed to save foreman: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to save foreman")
+		return
+	}
+
+	foreman, err := scanProjectForeman(db.QueryRow(projectForemanSelect+"WHERE pf.id = $1", foremanID))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch foreman")
+		return
+	}
+
+	log.Printf("SUCCESS SetProjectForeman: Employee %s is foreman on project %s", req.EmployeeID, projectID)
+	respondWithJSON(w, http.StatusOK, foreman)
+}
+
+func RemoveProjectForeman(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	employeeID := vars["employeeId"]
+
+	db := database.GetDB()
+
+	if !requireForemanManager(w, db, projectID, userCtx) {
+		return
+	}
+
+	result, err := db.Exec(`
+		DELETE FROM project_foremen pf
+		USING employees e
+		WHERE pf.employee_id = e.id AND pf.project_id = $1 AND pf.employee_id = $2 AND e.organization_id = $3
+	`, projectID, employeeID, userCtx.OrganizationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to remove foreman")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithError(w, http.StatusNotFound, "Foreman not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func ApproveWorkLog(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	workLogID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var projectID, employeeID string
+	var organizationID sql.NullString
+	var checkOutTime, approvedAt sql.NullTime
+	err := db.QueryRow(`
+		SELECT wl.project_id, wl.employee_id, wl.check_out_time, wl.approved_at, c.organization_id
+		FROM work_logs wl
+		JOIN projects p ON p.id = wl.project_id AND p.deleted_at IS NULL
+		LEFT JOIN contracts c ON wl.contract_id = c.id
+		WHERE wl.id = $1
+	`, workLogID).Scan(&projectID, &employeeID, &checkOutTime, &approvedAt, &organizationID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrWorkLogNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch work log")
+		return
+	}
+
+	canApprove := false
+	switch userCtx.UserType {
+	case string(models.UserTypeContractor):
+		canApprove = userCtx.InOrganization(organizationID.String) && userCtx.CanManageBusiness()
+	case string(models.UserTypeEmployee):
+		foreman, err := foremanAccess(db, projectID, userCtx.UserID)
+		canApprove = err == nil && foreman.CanApproveTimesheets &&
+			foreman.OrganizationID == organizationID.String && employeeID != userCtx.UserID
+	}
+
+	if !canApprove {
+		respondWithError(w, http.StatusForbidden, "You are not allowed to approve this timesheet")
+		return
+	}
+
+	if !checkOutTime.Valid {
+		respondWithAPIError(w, apierror.ErrStillCheckedIn)
+		return
+	}
+
+	if approvedAt.Valid {
+		respondWithAPIError(w, apierror.ErrWorkLogApproved)
+		return
+	}
+
+	var wl models.WorkLog
+	err = db.QueryRow(`
+		UPDATE work_logs wl SET approved_by = $1, approved_at = NOW()
+		FROM projects p
+		WHERE wl.id = $2 AND wl.approved_at IS NULL AND p.id = wl.project_id AND p.deleted_at IS NULL
+		RETURNING wl.id, wl.employee_id, wl.project_id, wl.contract_id, wl.check_in_time, `+workLogDate+`::text,
+		          wl.check_out_time, wl.hours_worked, wl.approved_by, wl.approved_at, wl.created_at
+	`, userCtx.UserID, workLogID).Scan(&wl.ID, &wl.EmployeeID, &wl.ProjectID, &wl.ContractID, &wl.CheckInTime,
+		&wl.WorkDate, &wl.CheckOutTime, &wl.HoursWorked, &wl.ApprovedBy, &wl.ApprovedAt, &wl.CreatedAt)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrWorkLogApproved)
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR ApproveWorkLog: Failed to approve work log: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to approve work log")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, wl)
+}
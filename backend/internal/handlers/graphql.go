@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/pagination"
+)
+
+const (
+	maxGraphQLQueryLength = 16 * 1024
+	// maxGraphQLDepth bounds how deeply selections nest.
+	maxGraphQLDepth = 8
+	// maxGraphQLComplexity bounds how many values a query may ask for. Each
+	// field costs one, and the fields under a list are counted once for each
+	// record its limit allows.
+	maxGraphQLComplexity = 50000
+)
+
+// GraphQL runs a read-only GraphQL query over the same data and with the same
+// access rules as the REST endpoints. Lists under a project or contract are
+// loaded in one query per field for all parents at once.
+func GraphQL(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	var req models.GraphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	db := database.GetDB()
+
+	query, persistAs, apiErr := persistedQuery(db, req)
+	if apiErr != nil {
+		respondWithGraphQLErrors(w, graphQLError{apiErr})
+		return
+	}
+	if query == "" {
+		respondWithGraphQLErrors(w, graphQLError{apierror.Invalid("query", "query is required")})
+		return
+	}
+	if len(query) > maxGraphQLQueryLength {
+		respondWithGraphQLErrors(w, graphQLError{apierror.Invalid("query", "query must be at most "+strconv.Itoa(maxGraphQLQueryLength)+" bytes")})
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		respondWithGraphQLErrors(w, err)
+		return
+	}
+	if result := graphql.ValidateDocument(&graphQLSchema, doc, nil); !result.IsValid {
+		respondWithJSON(w, http.StatusOK, &graphql.Result{Errors: result.Errors})
+		return
+	}
+	if apiErr := checkGraphQLCost(doc, req.Variables); apiErr != nil {
+		respondWithGraphQLErrors(w, graphQLError{apiErr})
+		return
+	}
+
+	// Only queries that parse and validate are kept.
+	if persistAs != "" {
+		_, err := db.Exec(`
+			INSERT INTO graphql_persisted_queries (hash, query) VALUES ($1, $2)
+			ON CONFLICT (hash) DO NOTHING
+		`, persistAs, query)
+		if err != nil {
+			log.Printf("ERROR GraphQL: Failed to persist query: %v", err)
+		}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        graphQLSchema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(r.Context(), graphQLStateKey{}, newGraphQLState(db, userCtx)),
+	})
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// persistedQuery returns the text of the query to run. A request naming a
+// persisted query by hash without its text gets the stored text. One that
+// sends both has the text checked against the hash, which is returned to
+// store the text under once it validates.
+func persistedQuery(db *sql.DB, req models.GraphQLRequest) (string, string, *apierror.Error) {
+	persisted := req.Extensions.PersistedQuery
+	if persisted == nil {
+		return req.Query, "", nil
+	}
+	if persisted.Version != 1 {
+		return "", "", apierror.Invalid("extensions.persistedQuery.version", "Unsupported persisted query version")
+	}
+
+	hash := strings.ToLower(persisted.Sha256Hash)
+	if req.Query != "" {
+		sum := sha256.Sum256([]byte(req.Query))
+		if hex.EncodeToString(sum[:]) != hash {
+			return "", "", apierror.Invalid("extensions.persistedQuery.sha256Hash", "sha256Hash does not match the query")
+		}
+		return req.Query, hash, nil
+	}
+
+	var query string
+	err := db.QueryRow(`SELECT query FROM graphql_persisted_queries WHERE hash = $1`, hash).Scan(&query)
+	if err == sql.ErrNoRows {
+		return "", "", apierror.ErrPersistedQueryNotFound
+	}
+	if err != nil {
+		log.Printf("ERROR GraphQL: Failed to look up persisted query: %v", err)
+		return "", "", apierror.FromStatus(http.StatusInternalServerError, "Failed to look up persisted query")
+	}
+	return query, "", nil
+}
+
+// respondWithGraphQLErrors reports a query that could not be run. As with
+// errors raised while running one, the status is 200 and the errors are in
+// the body.
+func respondWithGraphQLErrors(w http.ResponseWriter, errs ...error) {
+	respondWithJSON(w, http.StatusOK, &graphql.Result{Errors: gqlerrors.FormatErrors(errs...)})
+}
+
+// checkGraphQLCost rejects queries nested too deeply or asking for too much
+// before any of them runs.
+func checkGraphQLCost(doc *ast.Document, variables map[string]interface{}) *apierror.Error {
+	c := &graphQLCost{fragments: make(map[string]*ast.FragmentDefinition), variables: variables}
+	for _, def := range doc.Definitions {
+		if f, ok := def.(*ast.FragmentDefinition); ok {
+			c.fragments[f.Name.Value] = f
+		}
+	}
+
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok || op.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		cost, depth := c.selections(op.SelectionSet, graphQLSchema.QueryType(), 0)
+		if depth > maxGraphQLDepth {
+			return apierror.New(http.StatusBadRequest, apierror.CodeQueryTooComplex,
+				"Query is nested "+strconv.Itoa(depth)+" levels deep; the limit is "+strconv.Itoa(maxGraphQLDepth))
+		}
+		if cost > maxGraphQLComplexity {
+			return apierror.New(http.StatusBadRequest, apierror.CodeQueryTooComplex,
+				"Query may return more than "+strconv.Itoa(maxGraphQLComplexity)+" values; lower the limits or select fewer fields")
+		}
+	}
+	return nil
+}
+
+type graphQLCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selections returns the cost of a selection set on parent and the depth its
+// deepest field reaches. Costs stop growing just past the limit so they
+// cannot overflow.
+func (c *graphQLCost) selections(set *ast.SelectionSet, parent *graphql.Object, depth int) (int, int) {
+	cost, deepest := 0, depth
+	if set == nil {
+		return cost, deepest
+	}
+
+	for _, sel := range set.Selections {
+		var n, d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			// Introspection is cheap and bounded by the schema.
+			if strings.HasPrefix(sel.Name.Value, "__") {
+				continue
+			}
+			def, ok := parent.Fields()[sel.Name.Value]
+			if !ok {
+				continue
+			}
+			n, d = 1, depth+1
+			child, isList := unwrapGraphQLType(def.Type)
+			if obj, ok := child.(*graphql.Object); ok {
+				childCost, childDepth := c.selections(sel.SelectionSet, obj, depth+1)
+				if isList {
+					childCost = capGraphQLCost(childCost * c.limit(sel))
+				}
+				n += childCost
+				d = childDepth
+			}
+		case *ast.InlineFragment:
+			n, d = c.selections(sel.SelectionSet, parent, depth)
+		case *ast.FragmentSpread:
+			if f := c.fragments[sel.Name.Value]; f != nil {
+				n, d = c.selections(f.SelectionSet, parent, depth)
+			}
+		}
+
+		cost = capGraphQLCost(cost + n)
+		if d > deepest {
+			deepest = d
+		}
+	}
+	return cost, deepest
+}
+
+// limit reads the limit a list field was given, literally or through a
+// variable. Limits out of range fail when the field runs, so they are only
+// counted up to the maximum.
+func (c *graphQLCost) limit(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		var n float64
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			n, _ = strconv.ParseFloat(v.Value, 64)
+		case *ast.Variable:
+			n, _ = c.variables[v.Name.Value].(float64)
+		}
+		if n >= 1 {
+			return int(min(n, pagination.MaxLimit))
+		}
+	}
+	return pagination.DefaultLimit
+}
+
+func unwrapGraphQLType(t graphql.Type) (graphql.Type, bool) {
+	isList := false
+	for {
+		switch w := t.(type) {
+		case *graphql.NonNull:
+			t = w.OfType
+		case *graphql.List:
+			isList = true
+			t = w.OfType
+		default:
+			return t, isList
+		}
+	}
+}
+
+func capGraphQLCost(cost int) int {
+	if cost > maxGraphQLComplexity {
+		return maxGraphQLComplexity + 1
+	}
+	return cost
+}
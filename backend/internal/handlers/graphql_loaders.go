@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/lib/pq"
+)
+
+// batch loads the values of many keys with one query. A resolver asks for a
+// key and gets a thunk back. The executor resolves every field at one level
+// before calling any of their thunks, so the first thunk called fetches all
+// the keys asked for so far. Execution is single-threaded, so there is no
+// locking.
+type batch[K comparable, V any] struct {
+	fetch   func(keys []K) (map[K]V, error)
+	pending []K
+	queued  map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newBatch[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *batch[K, V] {
+	return &batch[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+func (b *batch[K, V]) load(key K) func() (interface{}, error) {
+	if !b.queued[key] {
+		b.queued[key] = true
+		b.pending = append(b.pending, key)
+	}
+
+	return func() (interface{}, error) {
+		if len(b.pending) > 0 {
+			keys := b.pending
+			b.pending = nil
+			values, err := b.fetch(keys)
+			for _, k := range keys {
+				if err != nil {
+					b.errs[k] = err
+					continue
+				}
+				b.values[k] = values[k]
+			}
+		}
+		if err := b.errs[key]; err != nil {
+			return nil, err
+		}
+		return b.values[key], nil
+	}
+}
+
+// scopedKey names the records under one project, or one contract for
+// estimates. A scope narrows them to one contract or organization, the way
+// the REST endpoints narrow what contractors see.
+type scopedKey struct {
+	id    string
+	scope string
+}
+
+// listLoader batches a list field separately for each limit it is asked for,
+// so aliases of the same field with different limits each get their own
+// query.
+type listLoader[V any] struct {
+	fetch   func(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]V, error)
+	db      *sql.DB
+	batches map[int]*batch[scopedKey, []V]
+}
+
+func newListLoader[V any](db *sql.DB, fetch func(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]V, error)) *listLoader[V] {
+	return &listLoader[V]{fetch: fetch, db: db, batches: make(map[int]*batch[scopedKey, []V])}
+}
+
+func (l *listLoader[V]) load(key scopedKey, limit int) func() (interface{}, error) {
+	b, ok := l.batches[limit]
+	if !ok {
+		b = newBatch(func(keys []scopedKey) (map[scopedKey][]V, error) {
+			values, err := l.fetch(l.db, keys, limit)
+			if err != nil {
+				log.Printf("ERROR GraphQL: Failed to load records: %v", err)
+				return nil, errors.New("Failed to load records")
+			}
+			for _, k := range keys {
+				if values[k] == nil {
+					values[k] = []V{}
+				}
+			}
+			return values, nil
+		})
+		l.batches[limit] = b
+	}
+	return b.load(key)
+}
+
+// unnestKeys passes keys to a query as two arrays to unnest side by side.
+func unnestKeys(keys []scopedKey) (interface{}, interface{}) {
+	ids := make([]string, len(keys))
+	scopes := make([]string, len(keys))
+	for i, k := range keys {
+		ids[i] = k.id
+		scopes[i] = k.scope
+	}
+	return pq.Array(ids), pq.Array(scopes)
+}
+
+// graphQLUser is the public part of a user.
+type graphQLUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func fetchGraphQLUsers(db *sql.DB, ids []string) (map[string]*graphQLUser, error) {
+	rows, err := db.Query(`SELECT id, name FROM users WHERE id = ANY($1::uuid[])`, pq.Array(ids))
+	if err != nil {
+		log.Printf("ERROR GraphQL: Failed to load users: %v", err)
+		return nil, errors.New("Failed to load users")
+	}
+	defer rows.Close()
+
+	users := make(map[string]*graphQLUser)
+	for rows.Next() {
+		var u graphQLUser
+		if err := rows.Scan(&u.ID, &u.Name); err != nil {
+			return nil, errors.New("Failed to load users")
+		}
+		users[u.ID] = &u
+	}
+	return users, rows.Err()
+}
+
+// projectAccess is what the caller may see of one project, decided the same
+// way as by the REST endpoints.
+type projectAccess struct {
+	// visible is whether the project itself shows up for the caller, as in
+	// ListProjects and GetProjectDashboard.
+	visible bool
+	// owner is set for the project's owner and members.
+	owner bool
+	// contractID is the contract the caller's organization holds on the
+	// project, if any.
+	contractID string
+	foreman    *models.ProjectForeman
+}
+
+// recordScope narrows expenses and payments as GetProjectExpenses and
+// ListPaymentSummaries do: owners and members see all of them, contractors
+// those of their own contract.
+func (a projectAccess) recordScope() (string, bool) {
+	if a.owner {
+		return "", true
+	}
+	if a.contractID != "" {
+		return a.contractID, true
+	}
+	return "", false
+}
+
+// workLogScope narrows work logs as GetProjectWorkLogs does, which also lets
+// foremen who can view crew hours see those of their contract.
+func (a projectAccess) workLogScope() (string, bool) {
+	if scope, ok := a.recordScope(); ok {
+		return scope, true
+	}
+	if a.foreman != nil && a.foreman.CanViewCrewHours {
+		return a.foreman.ContractID, true
+	}
+	return "", false
+}
+
+// contractScope narrows contracts as GetContract does: owners and members see
+// every contract, others those of their organization.
+func (a projectAccess) contractScope(userCtx middleware.UserContext) (string, bool) {
+	if a.owner {
+		return "", true
+	}
+	if userCtx.OrganizationID != "" {
+		return userCtx.OrganizationID, true
+	}
+	return "", false
+}
+
+// fetchProjectAccess works out the caller's access to each of the projects.
+// Projects that do not exist or are in the trash are left out.
+func fetchProjectAccess(db *sql.DB, userCtx middleware.UserContext, ids []string) (map[string]projectAccess, error) {
+	rows, err := db.Query(`
+		SELECT p.id,
+		       p.owner_id = $2 OR EXISTS(SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $2),
+		       COALESCE((SELECT c.id::text FROM contracts c
+		                 WHERE c.project_id = p.id
+		                   AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
+		                 LIMIT 1), ''),
+		       EXISTS(SELECT 1 FROM contracts c
+		              WHERE c.project_id = p.id AND c.organization_id::text = $3 AND c.status != 'terminated'),
+		       EXISTS(SELECT 1 FROM employee_projects ep WHERE ep.project_id = p.id AND ep.employee_id = $2)
+		FROM projects p
+		WHERE p.id = ANY($1::uuid[]) AND p.deleted_at IS NULL
+	`, pq.Array(ids), userCtx.UserID, userCtx.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	access := make(map[string]projectAccess)
+	for rows.Next() {
+		var id string
+		var a projectAccess
+		var liveContract, assigned bool
+		if err := rows.Scan(&id, &a.owner, &a.contractID, &liveContract, &assigned); err != nil {
+			return nil, err
+		}
+
+		switch models.UserType(userCtx.UserType) {
+		case models.UserTypeHouseOwner:
+			a.visible = a.owner
+		case models.UserTypeContractor:
+			a.visible = liveContract
+		case models.UserTypeEmployee:
+			a.visible = assigned
+		}
+		access[id] = a
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if userCtx.UserType != string(models.UserTypeEmployee) || len(access) == 0 {
+		return access, nil
+	}
+
+	foremen, err := db.Query(projectForemanSelect+`
+		WHERE pf.project_id = ANY($1::uuid[]) AND e.user_id = $2 AND e.is_active = true
+	`, pq.Array(ids), userCtx.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer foremen.Close()
+
+	for foremen.Next() {
+		f, err := scanProjectForeman(foremen)
+		if err != nil {
+			return nil, err
+		}
+		if a, ok := access[f.ProjectID]; ok {
+			a.foreman = f
+			access[f.ProjectID] = a
+		}
+	}
+	return access, foremen.Err()
+}
+
+func fetchProjectContracts(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]models.Contract, error) {
+	ids, scopes := unnestKeys(keys)
+	rows, err := db.Query(`
+		SELECT a.id::text, a.scope, x.*
+		FROM unnest($1::uuid[], $2::text[]) AS a(id, scope)
+		CROSS JOIN LATERAL (
+			SELECT c.id, c.project_id, c.contractor_id, c.organization_id, c.owner_id, c.status,
+			       c.start_date, c.end_date, c.terms, c.created_at, c.updated_at
+			FROM contracts c
+			WHERE c.project_id = a.id AND (a.scope = '' OR c.organization_id::text = a.scope)
+			ORDER BY c.created_at DESC
+			LIMIT $3
+		) x
+	`, ids, scopes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contracts := make(map[scopedKey][]models.Contract)
+	for rows.Next() {
+		var key scopedKey
+		var c models.Contract
+		err := rows.Scan(&key.id, &key.scope,
+			&c.ID, &c.ProjectID, &c.ContractorID, &c.OrganizationID, &c.OwnerID, &c.Status,
+			&c.StartDate, &c.EndDate, &c.Terms, &c.CreatedAt, &c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		contracts[key] = append(contracts[key], c)
+	}
+	return contracts, rows.Err()
+}
+
+func fetchContractEstimates(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]models.Estimate, error) {
+	ids, scopes := unnestKeys(keys)
+	rows, err := db.Query(`
+		SELECT a.id::text, a.scope, x.*
+		FROM unnest($1::uuid[], $2::text[]) AS a(id, scope)
+		CROSS JOIN LATERAL (
+			SELECT e.id, e.contract_id, e.organization_id, e.amount, e.description, e.submitted_by, e.submitted_at, e.status,
+			       e.approved_by, e.approved_at, e.rejected_at, e.rejection_reason, e.is_active, e.created_at, e.updated_at
+			FROM estimates e
+			WHERE e.contract_id = a.id
+			ORDER BY e.submitted_at DESC
+			LIMIT $3
+		) x
+	`, ids, scopes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	estimates := make(map[scopedKey][]models.Estimate)
+	for rows.Next() {
+		var key scopedKey
+		var e models.Estimate
+		err := rows.Scan(&key.id, &key.scope,
+			&e.ID, &e.ContractID, &e.OrganizationID, &e.Amount, &e.Description, &e.SubmittedBy, &e.SubmittedAt,
+			&e.Status, &e.ApprovedBy, &e.ApprovedAt, &e.RejectedAt, &e.RejectionReason,
+			&e.IsActive, &e.CreatedAt, &e.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		estimates[key] = append(estimates[key], e)
+	}
+	return estimates, rows.Err()
+}
+
+func fetchProjectPayments(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]models.PaymentSummary, error) {
+	ids, scopes := unnestKeys(keys)
+	rows, err := db.Query(`
+		SELECT a.id::text, a.scope, x.*
+		FROM unnest($1::uuid[], $2::text[]) AS a(id, scope)
+		CROSS JOIN LATERAL (
+			SELECT ps.id, ps.project_id, ps.contract_id, ps.amount, ps.currency, ps.exchange_rate, ps.converted_amount,
+			       ps.payment_method, ps.payment_date::text, ps.screenshot_url, ps.notes, ps.added_by, ps.status,
+			       ps.confirmed_by, ps.confirmed_at, ps.disputed_at, ps.dispute_reason,
+			       ps.created_at, ps.updated_at, ps.version
+			FROM payment_summaries ps
+			WHERE ps.project_id = a.id AND ps.deleted_at IS NULL
+			  AND (a.scope = '' OR ps.contract_id::text = a.scope)
+			ORDER BY ps.payment_date DESC, ps.created_at DESC
+			LIMIT $3
+		) x
+	`, ids, scopes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := make(map[scopedKey][]models.PaymentSummary)
+	for rows.Next() {
+		var key scopedKey
+		var ps models.PaymentSummary
+		err := rows.Scan(&key.id, &key.scope,
+			&ps.ID, &ps.ProjectID, &ps.ContractID, &ps.Amount, &ps.Currency, &ps.ExchangeRate, &ps.ConvertedAmount,
+			&ps.PaymentMethod, &ps.PaymentDate, &ps.ScreenshotURL, &ps.Notes, &ps.AddedBy, &ps.Status,
+			&ps.ConfirmedBy, &ps.ConfirmedAt, &ps.DisputedAt, &ps.DisputeReason,
+			&ps.CreatedAt, &ps.UpdatedAt, &ps.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		payments[key] = append(payments[key], ps)
+	}
+	return payments, rows.Err()
+}
+
+func fetchProjectExpenses(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]models.Expense, error) {
+	ids, scopes := unnestKeys(keys)
+	rows, err := db.Query(`
+		SELECT a.id::text, a.scope, x.*
+		FROM unnest($1::uuid[], $2::text[]) AS a(id, scope)
+		CROSS JOIN LATERAL (
+			SELECT e.id, e.project_id, e.contract_id, e.amount, e.currency, e.exchange_rate, e.converted_amount,
+			       e.vendor, e.date::text, e.category, e.description, e.paid_by, e.receipt_photo_url,
+			       e.added_by, e.created_at, e.version
+			FROM expenses e
+			WHERE e.project_id = a.id AND e.deleted_at IS NULL
+			  AND (a.scope = '' OR e.contract_id::text = a.scope)
+			ORDER BY e.date DESC, e.created_at DESC
+			LIMIT $3
+		) x
+	`, ids, scopes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expenses := make(map[scopedKey][]models.Expense)
+	for rows.Next() {
+		var key scopedKey
+		var e models.Expense
+		err := rows.Scan(&key.id, &key.scope,
+			&e.ID, &e.ProjectID, &e.ContractID, &e.Amount, &e.Currency, &e.ExchangeRate, &e.ConvertedAmount,
+			&e.Vendor, &e.Date, &e.Category, &e.Description, &e.PaidBy, &e.ReceiptPhotoURL,
+			&e.AddedBy, &e.CreatedAt, &e.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		expenses[key] = append(expenses[key], e)
+	}
+	return expenses, rows.Err()
+}
+
+func fetchProjectWorkLogs(db *sql.DB, keys []scopedKey, limit int) (map[scopedKey][]models.WorkLog, error) {
+	ids, scopes := unnestKeys(keys)
+	rows, err := db.Query(`
+		SELECT a.id::text, a.scope, x.*
+		FROM unnest($1::uuid[], $2::text[]) AS a(id, scope)
+		CROSS JOIN LATERAL (
+			SELECT wl.id, wl.employee_id, wl.project_id, wl.contract_id, wl.check_in_time, `+workLogDate+`::text,
+			       wl.check_out_time, wl.check_in_photo_url, wl.check_out_photo_url,
+			       wl.check_in_latitude, wl.check_in_longitude,
+			       wl.check_out_latitude, wl.check_out_longitude,
+			       wl.hours_worked, wl.approved_by, wl.approved_at, wl.created_at
+			FROM work_logs wl
+			JOIN projects p ON wl.project_id = p.id
+			WHERE wl.project_id = a.id AND (a.scope = '' OR wl.contract_id::text = a.scope)
+			ORDER BY wl.check_in_time DESC
+			LIMIT $3
+		) x
+	`, ids, scopes, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workLogs := make(map[scopedKey][]models.WorkLog)
+	for rows.Next() {
+		var key scopedKey
+		var wl models.WorkLog
+		err := rows.Scan(&key.id, &key.scope,
+			&wl.ID, &wl.EmployeeID, &wl.ProjectID, &wl.ContractID, &wl.CheckInTime, &wl.WorkDate,
+			&wl.CheckOutTime, &wl.CheckInPhotoURL, &wl.CheckOutPhotoURL,
+			&wl.CheckInLatitude, &wl.CheckInLongitude,
+			&wl.CheckOutLatitude, &wl.CheckOutLongitude,
+			&wl.HoursWorked, &wl.ApprovedBy, &wl.ApprovedAt, &wl.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		workLogs[key] = append(workLogs[key], wl)
+	}
+	return workLogs, rows.Err()
+}
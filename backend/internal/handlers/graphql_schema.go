@@ -0,0 +1,536 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
+)
+
+// graphQLState is what one GraphQL request shares between its resolvers: who
+// is asking, what they may see of each project and the batch loaders.
+type graphQLState struct {
+	db     *sql.DB
+	user   middleware.UserContext
+	access map[string]projectAccess
+
+	users     *batch[string, *graphQLUser]
+	contracts *listLoader[models.Contract]
+	estimates *listLoader[models.Estimate]
+	payments  *listLoader[models.PaymentSummary]
+	expenses  *listLoader[models.Expense]
+	workLogs  *listLoader[models.WorkLog]
+}
+
+type graphQLStateKey struct{}
+
+func newGraphQLState(db *sql.DB, userCtx middleware.UserContext) *graphQLState {
+	return &graphQLState{
+		db:     db,
+		user:   userCtx,
+		access: make(map[string]projectAccess),
+		users: newBatch(func(ids []string) (map[string]*graphQLUser, error) {
+			return fetchGraphQLUsers(db, ids)
+		}),
+		contracts: newListLoader(db, fetchProjectContracts),
+		estimates: newListLoader(db, fetchContractEstimates),
+		payments:  newListLoader(db, fetchProjectPayments),
+		expenses:  newListLoader(db, fetchProjectExpenses),
+		workLogs:  newListLoader(db, fetchProjectWorkLogs),
+	}
+}
+
+func graphQLStateFrom(ctx context.Context) *graphQLState {
+	return ctx.Value(graphQLStateKey{}).(*graphQLState)
+}
+
+// loadAccess works out the caller's access to the projects not looked at
+// yet in this request.
+func (s *graphQLState) loadAccess(ids []string) error {
+	var missing []string
+	for _, id := range ids {
+		if _, ok := s.access[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	access, err := fetchProjectAccess(s.db, s.user, missing)
+	if err != nil {
+		return err
+	}
+	for id, a := range access {
+		s.access[id] = a
+	}
+	return nil
+}
+
+// requireScope fails fields an API token was not granted, the way the
+// middleware fails the REST endpoints that return them.
+func (s *graphQLState) requireScope(scope models.APITokenScope) error {
+	if s.user.HasScope(scope) {
+		return nil
+	}
+	return graphQLError{apierror.FromStatus(http.StatusForbidden, "API token is missing the "+string(scope)+" scope")}
+}
+
+// requireSession fails fields whose REST endpoints API tokens cannot reach.
+func (s *graphQLState) requireSession() error {
+	if s.user.TokenID == "" {
+		return nil
+	}
+	return graphQLError{apierror.FromStatus(http.StatusForbidden, "This field cannot be accessed with an API token")}
+}
+
+// graphQLError reports an API error as a GraphQL error whose extensions carry
+// the code the REST endpoints use.
+type graphQLError struct {
+	err *apierror.Error
+}
+
+func (e graphQLError) Error() string {
+	return e.err.Message
+}
+
+func (e graphQLError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{"code": e.err.Code}
+	if len(e.err.Details) > 0 {
+		ext["details"] = e.err.Details
+	}
+	return ext
+}
+
+// limitArg reads the limit argument of a list field.
+func limitArg(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > pagination.MaxLimit {
+		return 0, graphQLError{apierror.Invalid("limit", "limit must be between 1 and "+strconv.Itoa(pagination.MaxLimit))}
+	}
+	return limit, nil
+}
+
+var limitArgs = graphql.FieldConfigArgument{
+	"limit": &graphql.ArgumentConfig{
+		Type:         graphql.Int,
+		DefaultValue: pagination.DefaultLimit,
+		Description:  "How many records to return, newest first.",
+	},
+}
+
+// decimalScalar carries money amounts and exchange rates as JSON numbers
+// with their exact digits, as the REST endpoints do.
+var decimalScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "An exact decimal number, such as a money amount or exchange rate.",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case money.Amount:
+			return json.Number(v.String())
+		case money.Rate:
+			return json.Number(v)
+		}
+		return nil
+	},
+})
+
+// userField resolves a user by the id that idOf reads off the parent.
+func userField(idOf func(source interface{}) string) *graphql.Field {
+	return &graphql.Field{
+		Type: userType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return graphQLStateFrom(p.Context).users.load(idOf(p.Source)), nil
+		},
+	}
+}
+
+var userType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "User",
+	Fields: graphql.Fields{
+		"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+var estimateType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Estimate",
+	Fields: graphql.Fields{
+		"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"contract_id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"organization_id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"amount":           &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"description":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"submitted_by":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"submitted_at":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"status":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"approved_by":      &graphql.Field{Type: graphql.ID},
+		"approved_at":      &graphql.Field{Type: graphql.DateTime},
+		"rejected_at":      &graphql.Field{Type: graphql.DateTime},
+		"rejection_reason": &graphql.Field{Type: graphql.String},
+		"is_active":        &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"created_at":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"updated_at":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"submitted_by_user": userField(func(source interface{}) string {
+			return source.(models.Estimate).SubmittedBy
+		}),
+	},
+})
+
+var contractType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Contract",
+	Fields: graphql.Fields{
+		"id":              &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"project_id":      &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"contractor_id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"organization_id": &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"owner_id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"status":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"start_date":      &graphql.Field{Type: graphql.DateTime},
+		"end_date":        &graphql.Field{Type: graphql.DateTime},
+		"terms":           &graphql.Field{Type: graphql.String},
+		"created_at":      &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"updated_at":      &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"contractor": userField(func(source interface{}) string {
+			return source.(models.Contract).ContractorID
+		}),
+		"estimates": &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(estimateType)),
+			Args: limitArgs,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				limit, err := limitArg(p)
+				if err != nil {
+					return nil, err
+				}
+				// Whoever can see a contract can see its estimates.
+				contract := p.Source.(models.Contract)
+				return graphQLStateFrom(p.Context).estimates.load(scopedKey{id: contract.ID}, limit), nil
+			},
+		},
+	},
+})
+
+var paymentType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Payment",
+	Fields: graphql.Fields{
+		"id":               &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"project_id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"contract_id":      &graphql.Field{Type: graphql.ID},
+		"amount":           &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"currency":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"exchange_rate":    &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"converted_amount": &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"payment_method":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"payment_date":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"screenshot_url":   &graphql.Field{Type: graphql.String},
+		"notes":            &graphql.Field{Type: graphql.String},
+		"added_by":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"status":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"confirmed_by":     &graphql.Field{Type: graphql.ID},
+		"confirmed_at":     &graphql.Field{Type: graphql.DateTime},
+		"disputed_at":      &graphql.Field{Type: graphql.DateTime},
+		"dispute_reason":   &graphql.Field{Type: graphql.String},
+		"created_at":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"updated_at":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"version":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"added_by_user": userField(func(source interface{}) string {
+			return source.(models.PaymentSummary).AddedBy
+		}),
+	},
+})
+
+var expenseType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Expense",
+	Fields: graphql.Fields{
+		"id":                &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"project_id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"contract_id":       &graphql.Field{Type: graphql.ID},
+		"amount":            &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"currency":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"exchange_rate":     &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"converted_amount":  &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"vendor":            &graphql.Field{Type: graphql.String},
+		"date":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"category":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description":       &graphql.Field{Type: graphql.String},
+		"paid_by":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"receipt_photo_url": &graphql.Field{Type: graphql.String},
+		"added_by":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"created_at":        &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"version":           &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"added_by_user": userField(func(source interface{}) string {
+			return source.(models.Expense).AddedBy
+		}),
+	},
+})
+
+var workLogType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "WorkLog",
+	Fields: graphql.Fields{
+		"id":                  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"employee_id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"project_id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"contract_id":         &graphql.Field{Type: graphql.ID},
+		"check_in_time":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"work_date":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"check_out_time":      &graphql.Field{Type: graphql.DateTime},
+		"check_in_photo_url":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"check_out_photo_url": &graphql.Field{Type: graphql.String},
+		"check_in_latitude":   &graphql.Field{Type: graphql.Float},
+		"check_in_longitude":  &graphql.Field{Type: graphql.Float},
+		"check_out_latitude":  &graphql.Field{Type: graphql.Float},
+		"check_out_longitude": &graphql.Field{Type: graphql.Float},
+		"hours_worked":        &graphql.Field{Type: graphql.Float},
+		"approved_by":         &graphql.Field{Type: graphql.ID},
+		"approved_at":         &graphql.Field{Type: graphql.DateTime},
+		"created_at":          &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"employee": userField(func(source interface{}) string {
+			return source.(models.WorkLog).EmployeeID
+		}),
+	},
+})
+
+// projectRecords resolves a list under a project. scopeOf decides whether
+// the caller may see the list and how it is narrowed for them.
+func projectRecords[V any](
+	scope models.APITokenScope,
+	loader func(s *graphQLState) *listLoader[V],
+	scopeOf func(s *graphQLState, a projectAccess) (string, bool),
+) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		s := graphQLStateFrom(p.Context)
+		if scope != "" {
+			if err := s.requireScope(scope); err != nil {
+				return nil, err
+			}
+		} else if err := s.requireSession(); err != nil {
+			return nil, err
+		}
+
+		limit, err := limitArg(p)
+		if err != nil {
+			return nil, err
+		}
+
+		project := p.Source.(*models.Project)
+		recordScope, ok := scopeOf(s, s.access[project.ID])
+		if !ok {
+			return nil, graphQLError{apierror.ErrAccessDenied}
+		}
+		return loader(s).load(scopedKey{id: project.ID, scope: recordScope}, limit), nil
+	}
+}
+
+var projectType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Project",
+	Fields: graphql.Fields{
+		"id":             &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"owner_id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"title":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"estimated_cost": &graphql.Field{Type: graphql.NewNonNull(decimalScalar)},
+		"currency":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"time_zone":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"address":        &graphql.Field{Type: graphql.String},
+		"status":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"created_at":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"updated_at":     &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		"version":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"owner": userField(func(source interface{}) string {
+			return source.(*models.Project).OwnerID
+		}),
+		"contracts": &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(contractType)),
+			Args: limitArgs,
+			Resolve: projectRecords("",
+				func(s *graphQLState) *listLoader[models.Contract] { return s.contracts },
+				func(s *graphQLState, a projectAccess) (string, bool) { return a.contractScope(s.user) },
+			),
+		},
+		"payments": &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(paymentType)),
+			Args: limitArgs,
+			Resolve: projectRecords(models.ScopePaymentsRead,
+				func(s *graphQLState) *listLoader[models.PaymentSummary] { return s.payments },
+				func(s *graphQLState, a projectAccess) (string, bool) { return a.recordScope() },
+			),
+		},
+		"expenses": &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(expenseType)),
+			Args: limitArgs,
+			Resolve: projectRecords(models.ScopeExpensesRead,
+				func(s *graphQLState) *listLoader[models.Expense] { return s.expenses },
+				func(s *graphQLState, a projectAccess) (string, bool) { return a.recordScope() },
+			),
+		},
+		"work_logs": &graphql.Field{
+			Type: graphql.NewList(graphql.NewNonNull(workLogType)),
+			Args: limitArgs,
+			Resolve: projectRecords(models.ScopeWorkLogsRead,
+				func(s *graphQLState) *listLoader[models.WorkLog] { return s.workLogs },
+				func(s *graphQLState, a projectAccess) (string, bool) { return a.workLogScope() },
+			),
+		},
+	},
+})
+
+const graphQLProjectColumns = `p.id, p.owner_id, p.title, p.description,
+	p.estimated_cost, p.currency, p.time_zone, p.address, p.status, p.created_at, p.updated_at, p.version`
+
+func scanGraphQLProject(row rowScanner) (*models.Project, error) {
+	var p models.Project
+	err := row.Scan(&p.ID, &p.OwnerID, &p.Title, &p.Description,
+		&p.EstimatedCost, &p.Currency, &p.TimeZone, &p.Address, &p.Status, &p.CreatedAt, &p.UpdatedAt, &p.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// resolveProjects lists the projects the caller can see, as ListProjects
+// does.
+func resolveProjects(p graphql.ResolveParams) (interface{}, error) {
+	s := graphQLStateFrom(p.Context)
+	if err := s.requireScope(models.ScopeProjectsRead); err != nil {
+		return nil, err
+	}
+	limit, err := limitArg(p)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &pagination.Query{}
+	q.Where("p.deleted_at IS NULL")
+	switch models.UserType(s.user.UserType) {
+	case models.UserTypeHouseOwner:
+		userArg := q.Arg(s.user.UserID)
+		q.Where("(p.owner_id = " + userArg + " OR EXISTS(SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = " + userArg + "))")
+	case models.UserTypeContractor:
+		q.Where("EXISTS(SELECT 1 FROM contracts c WHERE c.project_id = p.id AND c.organization_id = " + q.Arg(s.user.OrganizationID) + " AND c.status != 'terminated')")
+	case models.UserTypeEmployee:
+		q.Where("EXISTS(SELECT 1 FROM employee_projects ep WHERE ep.project_id = p.id AND ep.employee_id = " + q.Arg(s.user.UserID) + ")")
+	default:
+		return nil, graphQLError{apierror.Invalid("user_type", "Invalid user type")}
+	}
+	if status, _ := p.Args["status"].(string); status != "" {
+		q.Where("p.status = " + q.Arg(status))
+	}
+
+	rows, err := s.db.Query(`SELECT `+graphQLProjectColumns+` FROM projects p`+q.WhereSQL()+
+		` ORDER BY p.created_at DESC LIMIT `+strconv.Itoa(limit), q.Args()...)
+	if err != nil {
+		log.Printf("ERROR GraphQL: Failed to list projects: %v", err)
+		return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch projects")}
+	}
+	defer rows.Close()
+
+	projects := []*models.Project{}
+	ids := []string{}
+	for rows.Next() {
+		project, err := scanGraphQLProject(rows)
+		if err != nil {
+			log.Printf("ERROR GraphQL: Failed to scan project: %v", err)
+			return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch projects")}
+		}
+		projects = append(projects, project)
+		ids = append(ids, project.ID)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("ERROR GraphQL: Failed to list projects: %v", err)
+		return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch projects")}
+	}
+
+	if err := s.loadAccess(ids); err != nil {
+		log.Printf("ERROR GraphQL: Failed to check project access: %v", err)
+		return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch projects")}
+	}
+	return projects, nil
+}
+
+// resolveProject returns one project the caller can see, as GetProject
+// does.
+func resolveProject(p graphql.ResolveParams) (interface{}, error) {
+	s := graphQLStateFrom(p.Context)
+	if err := s.requireScope(models.ScopeProjectsRead); err != nil {
+		return nil, err
+	}
+
+	id, _ := p.Args["id"].(string)
+	if err := s.loadAccess([]string{id}); err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return nil, graphQLError{apiErr}
+		}
+		log.Printf("ERROR GraphQL: Failed to check project access: %v", err)
+		return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch project")}
+	}
+
+	access, ok := s.access[id]
+	if !ok {
+		return nil, graphQLError{apierror.ErrProjectNotFound}
+	}
+	if !access.visible {
+		return nil, graphQLError{apierror.ErrAccessDenied}
+	}
+
+	project, err := scanGraphQLProject(s.db.QueryRow(`SELECT `+graphQLProjectColumns+` FROM projects p WHERE p.id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, graphQLError{apierror.ErrProjectNotFound}
+	}
+	if err != nil {
+		log.Printf("ERROR GraphQL: Failed to fetch project %s: %v", id, err)
+		return nil, graphQLError{apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch project")}
+	}
+	return project, nil
+}
+
+var queryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Query",
+	Fields: graphql.Fields{
+		"me": &graphql.Field{
+			Type:        graphql.NewNonNull(userType),
+			Description: "The user making the request.",
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				s := graphQLStateFrom(p.Context)
+				return s.users.load(s.user.UserID), nil
+			},
+		},
+		"projects": &graphql.Field{
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(projectType))),
+			Description: "The projects the user can see, newest first.",
+			Args: graphql.FieldConfigArgument{
+				"limit": limitArgs["limit"],
+				"status": &graphql.ArgumentConfig{
+					Type:        graphql.String,
+					Description: "Only projects with this status.",
+				},
+			},
+			Resolve: resolveProjects,
+		},
+		"project": &graphql.Field{
+			Type: projectType,
+			Args: graphql.FieldConfigArgument{
+				"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+			},
+			Resolve: resolveProject,
+		},
+	},
+})
+
+var graphQLSchema = mustGraphQLSchema()
+
+func mustGraphQLSchema() graphql.Schema {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+	if err != nil {
+		panic("graphql: invalid schema: " + err.Error())
+	}
+	return schema
+}
@@ -0,0 +1,495 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+)
+
+const invitationExpiry = 7 * 24 * time.Hour
+
+func InviteContractor(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	db := database.GetDB()
+
+	var ownerID, projectTitle string
+	err := db.QueryRow("SELECT owner_id, title FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID, &projectTitle)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can invite contractors")
+		return
+	}
+
+	var req models.AssignContractorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.ContractorEmail))
+	if !emailRegex.MatchString(email) {
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
+		return
+	}
+
+	var existingUserID, existingUserType string
+	err = db.QueryRow("SELECT id, user_type FROM users WHERE LOWER(email) = $1", email).
+		Scan(&existingUserID, &existingUserType)
+	if err != nil && err != sql.ErrNoRows {
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+
+	if err == nil {
+		if existingUserType != string(models.UserTypeContractor) {
+			respondWithError(w, http.StatusBadRequest, "This email belongs to an account that is not a contractor")
+			return
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+			return
+		}
+		defer tx.Rollback()
+
+		assigned, err := assignContractorToProject(tx, projectID, existingUserID, ownerID)
+		if err != nil {
+			log.Printf("ERROR InviteContractor: Failed to assign existing contractor: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to assign contractor")
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+			return
+		}
+
+		message := "Contractor is already registered and has been assigned to the project"
+		if !assigned {
+			message = "Contractor is already assigned to this project"
+		}
+
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"message":       message,
+			"contractor_id": existingUserID,
+			"assigned":      assigned,
+		})
+		return
+	}
+
+	var pendingExists bool
+	err = db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM contractor_invitations
+			WHERE project_id = $1 AND LOWER(email) = $2 AND status = $3
+		)
+	`, projectID, email, models.InvitationStatusPending).Scan(&pendingExists)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check existing invitations")
+		return
+	}
+
+	if pendingExists {
+		respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeInvitationPending, "An invitation is already pending for this email. Resend it instead."))
+		return
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate invitation token")
+		return
+	}
+
+	query := `
+		INSERT INTO contractor_invitations (project_id, invited_by, email, token_hash, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, project_id, invited_by, email, status, expires_at, accepted_by, accepted_at, created_at, updated_at
+	`
+
+	var invitation models.ContractorInvitation
+	err = db.QueryRow(query, projectID, userCtx.UserID, email, utils.HashLoginCode(token), models.InvitationStatusPending, time.Now().UTC().Add(invitationExpiry)).
+		Scan(&invitation.ID, &invitation.ProjectID, &invitation.InvitedBy, &invitation.Email, &invitation.Status,
+			&invitation.ExpiresAt, &invitation.AcceptedBy, &invitation.AcceptedAt, &invitation.CreatedAt, &invitation.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR InviteContractor: Failed to create invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	ownerInfo, err := getUserInfo(db, userCtx.UserID)
+	if err == nil {
+		if err := utils.SendContractorInvitationEmail(email, ownerInfo.Name, projectTitle, token); err != nil {
+			log.Printf("Failed to send contractor invitation email: %v", err)
+		}
+	}
+
+	respondWithJSON(w, http.StatusCreated, invitation)
+}
+
+func ListProjectInvitations(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	db := database.GetDB()
+
+	var ownerID string
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	query := `
+		SELECT id, project_id, invited_by, email, status, expires_at, accepted_by, accepted_at, created_at, updated_at
+		FROM contractor_invitations
+		WHERE project_id = $1 AND status = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.Query(query, projectID, models.InvitationStatusPending)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+	defer rows.Close()
+
+	type InvitationResponse struct {
+		models.ContractorInvitation
+		Expired bool `json:"expired"`
+	}
+
+	invitations := []InvitationResponse{}
+	now := time.Now()
+	for rows.Next() {
+		var inv InvitationResponse
+		err := rows.Scan(&inv.ID, &inv.ProjectID, &inv.InvitedBy, &inv.Email, &inv.Status,
+			&inv.ExpiresAt, &inv.AcceptedBy, &inv.AcceptedAt, &inv.CreatedAt, &inv.UpdatedAt)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan invitation")
+			return
+		}
+		inv.Expired = now.After(inv.ExpiresAt)
+		invitations = append(invitations, inv)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating invitations")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, invitations)
+}
+
+func ResendContractorInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	invitationID := vars["id"]
+
+	db := database.GetDB()
+
+	var ownerID, projectTitle string
+	var status models.InvitationStatus
+	err := db.QueryRow(`
+		SELECT p.owner_id, p.title, ci.status
+		FROM contractor_invitations ci
+		JOIN projects p ON ci.project_id = p.id
+		WHERE ci.id = $1 AND p.deleted_at IS NULL
+	`, invitationID).Scan(&ownerID, &projectTitle, &status)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can resend invitations")
+		return
+	}
+
+	if status != models.InvitationStatusPending {
+		respondWithError(w, http.StatusBadRequest, "Only pending invitations can be resent")
+		return
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate invitation token")
+		return
+	}
+
+	query := `
+		UPDATE contractor_invitations
+		SET token_hash = $1, expires_at = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING id, project_id, invited_by, email, status, expires_at, accepted_by, accepted_at, created_at, updated_at
+	`
+
+	var invitation models.ContractorInvitation
+	err = db.QueryRow(query, utils.HashLoginCode(token), time.Now().UTC().Add(invitationExpiry), invitationID).
+		Scan(&invitation.ID, &invitation.ProjectID, &invitation.InvitedBy, &invitation.Email, &invitation.Status,
+			&invitation.ExpiresAt, &invitation.AcceptedBy, &invitation.AcceptedAt, &invitation.CreatedAt, &invitation.UpdatedAt)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to refresh invitation")
+		return
+	}
+
+	ownerInfo, err := getUserInfo(db, userCtx.UserID)
+	if err == nil {
+		if err := utils.SendContractorInvitationEmail(invitation.Email, ownerInfo.Name, projectTitle, token); err != nil {
+			log.Printf("Failed to resend contractor invitation email: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to send invitation email")
+			return
+		}
+	}
+
+	respondWithJSON(w, http.StatusOK, invitation)
+}
+
+func RevokeContractorInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	invitationID := vars["id"]
+
+	db := database.GetDB()
+
+	var ownerID string
+	var status models.InvitationStatus
+	err := db.QueryRow(`
+		SELECT p.owner_id, ci.status
+		FROM contractor_invitations ci
+		JOIN projects p ON ci.project_id = p.id
+		WHERE ci.id = $1 AND p.deleted_at IS NULL
+	`, invitationID).Scan(&ownerID, &status)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can revoke invitations")
+		return
+	}
+
+	if status != models.InvitationStatusPending {
+		respondWithError(w, http.StatusBadRequest, "Only pending invitations can be revoked")
+		return
+	}
+
+	_, err = db.Exec(`
+		UPDATE contractor_invitations
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2
+	`, models.InvitationStatusRevoked, invitationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to revoke invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func GetInvitationByToken(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var email, projectTitle, invitedByName string
+	var status models.InvitationStatus
+	var expiresAt time.Time
+	err := db.QueryRow(`
+		SELECT ci.email, ci.status, ci.expires_at, p.title, u.name
+		FROM contractor_invitations ci
+		JOIN projects p ON ci.project_id = p.id
+		JOIN users u ON ci.invited_by = u.id
+		WHERE ci.token_hash = $1 AND p.deleted_at IS NULL
+	`, utils.HashLoginCode(token)).Scan(&email, &status, &expiresAt, &projectTitle, &invitedByName)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if status != models.InvitationStatusPending || time.Now().After(expiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusGone, apierror.CodeInvitationExpired, "This invitation is no longer valid"))
+		return
+	}
+
+	var accountExists bool
+	db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = $1)", email).Scan(&accountExists)
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"email":           email,
+		"project_title":   projectTitle,
+		"invited_by_name": invitedByName,
+		"expires_at":      expiresAt,
+		"account_exists":  accountExists,
+	})
+}
+
+func AcceptContractorInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) {
+		respondWithError(w, http.StatusForbidden, "Only contractors can accept project invitations")
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	invitation, status, message := findPendingInvitation(db, req.Token, userCtx.Email)
+	if invitation == nil {
+		respondWithError(w, status, message)
+		return
+	}
+
+	if err := acceptContractorInvitation(db, invitation, userCtx.UserID); err != nil {
+		log.Printf("ERROR AcceptContractorInvitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message":    "Invitation accepted successfully",
+		"project_id": invitation.ProjectID,
+	})
+}
+
+func findPendingInvitation(db *sql.DB, token, email string) (*models.ContractorInvitation, int, string) {
+	var invitation models.ContractorInvitation
+	err := db.QueryRow(`
+		SELECT id, project_id, invited_by, email, status, expires_at
+		FROM contractor_invitations
+		WHERE token_hash = $1
+	`, utils.HashLoginCode(token)).Scan(&invitation.ID, &invitation.ProjectID, &invitation.InvitedBy, &invitation.Email,
+		&invitation.Status, &invitation.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, http.StatusBadRequest, "Invalid or expired invitation token"
+	}
+	if err != nil {
+		return nil, http.StatusInternalServerError, "Failed to fetch invitation"
+	}
+
+	if invitation.Status != models.InvitationStatusPending || time.Now().After(invitation.ExpiresAt) {
+		return nil, http.StatusBadRequest, "Invalid or expired invitation token"
+	}
+
+	if !strings.EqualFold(invitation.Email, strings.TrimSpace(email)) {
+		return nil, http.StatusForbidden, "This invitation was sent to a different email address"
+	}
+
+	return &invitation, http.StatusOK, ""
+}
+
+func acceptContractorInvitation(db *sql.DB, invitation *models.ContractorInvitation, contractorID string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var ownerID string
+	if err := tx.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", invitation.ProjectID).Scan(&ownerID); err != nil {
+		return err
+	}
+
+	if _, err := assignContractorToProject(tx, invitation.ProjectID, contractorID, ownerID); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE contractor_invitations
+		SET status = $1, accepted_by = $2, accepted_at = NOW(), updated_at = NOW()
+		WHERE id = $3 AND status = $4
+	`, models.InvitationStatusAccepted, contractorID, invitation.ID, models.InvitationStatusPending)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/utils"
+)
+
+const emailedToken = "emailed-invitation-token"
+
+// Invitations and ownership transfers are stored with only a hash of their
+// token, so every lookup must hash the token from the link before matching it.
+func TestInvitationsAreLookedUpByTokenHash(t *testing.T) {
+	asUser := func(userType string) func(*http.Request) *http.Request {
+		return func(r *http.Request) *http.Request {
+			return r.WithContext(context.WithValue(r.Context(), middleware.UserContextKey, middleware.UserContext{
+				UserID: testUserID, UserType: userType, Email: "sam@example.com",
+			}))
+		}
+	}
+	anonymous := func(r *http.Request) *http.Request { return r }
+	body := func() *strings.Reader { return strings.NewReader(`{"token":"` + emailedToken + `"}`) }
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		request *http.Request
+		as      func(*http.Request) *http.Request
+		query   string
+		inTx    bool
+		status  int
+	}{
+		{"contractor lookup", GetInvitationByToken,
+			httptest.NewRequest("GET", "/api/invitations/lookup?token="+emailedToken, nil), anonymous,
+			`FROM contractor_invitations ci.*WHERE ci\.token_hash = \$1`, false, http.StatusNotFound},
+		{"contractor accept", AcceptContractorInvitation,
+			httptest.NewRequest("POST", "/api/invitations/accept", body()), asUser("contractor"),
+			`FROM contractor_invitations\s+WHERE token_hash = \$1`, false, http.StatusBadRequest},
+		{"employee lookup", GetEmployeeInvitationByToken,
+			httptest.NewRequest("GET", "/api/employee-invitations/lookup?token="+emailedToken, nil), anonymous,
+			`FROM employee_invitations\s+WHERE token_hash = \$1`, false, http.StatusNotFound},
+		{"employee accept", AcceptEmployeeInvitation,
+			httptest.NewRequest("POST", "/api/employee-invitations/accept", body()), anonymous,
+			`FROM employee_invitations\s+WHERE token_hash = \$1`, false, http.StatusBadRequest},
+		{"project member lookup", GetProjectMemberInvitationByToken,
+			httptest.NewRequest("GET", "/api/project-invitations/lookup?token="+emailedToken, nil), anonymous,
+			`FROM project_member_invitations i.*WHERE i\.token_hash = \$1`, false, http.StatusNotFound},
+		{"project member accept", AcceptProjectMemberInvitation,
+			httptest.NewRequest("POST", "/api/project-invitations/accept", body()), asUser("house_owner"),
+			`FROM project_member_invitations\s+WHERE token_hash = \$1`, true, http.StatusBadRequest},
+		{"organization accept", AcceptOrganizationInvitation,
+			httptest.NewRequest("POST", "/api/organization/invitations/accept", body()), asUser("contractor"),
+			`FROM organization_invitations\s+WHERE token_hash = \$1`, true, http.StatusBadRequest},
+		{"ownership transfer lookup", GetOwnershipTransferByToken,
+			httptest.NewRequest("GET", "/api/ownership-transfers/lookup?token="+emailedToken, nil), anonymous,
+			`FROM project_ownership_transfers t.*WHERE t\.token_hash = \$1`, false, http.StatusNotFound},
+		{"ownership transfer accept", AcceptOwnershipTransfer,
+			httptest.NewRequest("POST", "/api/ownership-transfers/accept", body()), asUser("house_owner"),
+			`FROM project_ownership_transfers\s+WHERE token_hash = \$1`, true, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockDB(t)
+			if tt.inTx {
+				mock.ExpectBegin()
+			}
+			mock.ExpectQuery(tt.query).WithArgs(utils.HashLoginCode(emailedToken)).WillReturnRows(sqlmock.NewRows(nil))
+			if tt.inTx {
+				mock.ExpectRollback()
+			}
+
+			rec := httptest.NewRecorder()
+			tt.handler(rec, tt.as(tt.request))
+			if rec.Code != tt.status {
+				t.Errorf("status %d, want %d: %s", rec.Code, tt.status, rec.Body)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,707 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const organizationInvitationExpiry = 72 * time.Hour
+
+type queryRower interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func createOrganization(tx *sql.Tx, ownerID, name string) (string, error) {
+	var organizationID string
+	err := tx.QueryRow("INSERT INTO organizations (name) VALUES ($1) RETURNING id", name).Scan(&organizationID)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+	`, organizationID, ownerID, models.OrganizationRoleOwner)
+	if err != nil {
+		return "", err
+	}
+
+	return organizationID, nil
+}
+
+func organizationIDForUser(q queryRower, userID string) (string, error) {
+	var organizationID string
+	err := q.QueryRow("SELECT organization_id FROM organization_members WHERE user_id = $1", userID).Scan(&organizationID)
+	return organizationID, err
+}
+
+// contractorHasProjectAccess reports whether the user's organization holds a
+// live contract on the project, and the project is not in the trash.
+func contractorHasProjectAccess(db *sql.DB, projectID string, userCtx middleware.UserContext) (bool, error) {
+	if userCtx.OrganizationID == "" {
+		return false, nil
+	}
+
+	var hasAccess bool
+	err := db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM contracts c
+			JOIN projects p ON p.id = c.project_id
+			WHERE c.project_id = $1 AND c.organization_id = $2 AND c.status != 'terminated'
+			  AND p.deleted_at IS NULL
+		)
+	`, projectID, userCtx.OrganizationID).Scan(&hasAccess)
+	return hasAccess, err
+}
+
+func GetOrganization(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.OrganizationID == "" {
+		respondWithError(w, http.StatusNotFound, "You are not a member of an organization")
+		return
+	}
+
+	db := database.GetDB()
+
+	var org models.OrganizationWithMembership
+	err := db.QueryRow(`
+		SELECT id, name, created_at, updated_at FROM organizations WHERE id = $1
+	`, userCtx.OrganizationID).Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR GetOrganization: Failed to query organization: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch organization")
+		return
+	}
+	org.Role = models.OrganizationRole(userCtx.OrganizationRole)
+
+	rows, err := db.Query(`
+		SELECT m.id, m.organization_id, m.user_id, u.name, u.email, m.role, m.created_at, m.updated_at
+		FROM organization_members m
+		JOIN users u ON m.user_id = u.id
+		WHERE m.organization_id = $1
+		ORDER BY m.created_at ASC
+	`, userCtx.OrganizationID)
+	if err != nil {
+		log.Printf("ERROR GetOrganization: Failed to query members: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+	defer rows.Close()
+
+	org.Members = []models.OrganizationMember{}
+	for rows.Next() {
+		var m models.OrganizationMember
+		if err := rows.Scan(&m.ID, &m.OrganizationID, &m.UserID, &m.Name, &m.Email, &m.Role, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan member")
+			return
+		}
+		org.Members = append(org.Members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating members")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, org)
+}
+
+func UpdateOrganization(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can update the organization")
+		return
+	}
+
+	var req models.UpdateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var org models.Organization
+	err := db.QueryRow(`
+		UPDATE organizations SET name = $1 WHERE id = $2
+		RETURNING id, name, created_at, updated_at
+	`, req.Name, userCtx.OrganizationID).Scan(&org.ID, &org.Name, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR UpdateOrganization: Failed to update organization: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to update organization")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, org)
+}
+
+func AddOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can add members")
+		return
+	}
+
+	var req models.AddOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if !emailRegex.MatchString(req.Email) {
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
+		return
+	}
+
+	if !models.ValidOrganizationRoles[req.Role] {
+		respondWithAPIError(w, apierror.Invalid("role", "Invalid role"))
+		return
+	}
+
+	if req.Role == models.OrganizationRoleOwner && !userCtx.HasOrganizationRole(models.OrganizationRoleOwner) {
+		respondWithError(w, http.StatusForbidden, "Only owners can add other owners")
+		return
+	}
+
+	db := database.GetDB()
+
+	var orgName, inviterName string
+	err := db.QueryRow(`
+		SELECT o.name, u.name FROM organizations o, users u WHERE o.id = $1 AND u.id = $2
+	`, userCtx.OrganizationID, userCtx.UserID).Scan(&orgName, &inviterName)
+	if err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to load organization: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to load organization")
+		return
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var userID, userType, name string
+	err = tx.QueryRow("SELECT id, user_type, name FROM users WHERE LOWER(email) = LOWER($1)", req.Email).
+		Scan(&userID, &userType, &name)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("ERROR AddOrganizationMember: Failed to look up user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+
+	if err == nil {
+		if userType != string(models.UserTypeContractor) {
+			respondWithError(w, http.StatusConflict, "Only contractor accounts can join an organization")
+			return
+		}
+
+		// An existing account is only moved once its owner accepts.
+		inviteOrganizationMember(w, tx, userCtx, req, name, orgName, inviterName)
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondWithAPIError(w, apierror.Invalid("name", "Name is required for new members"))
+		return
+	}
+	name = req.Name
+
+	placeholderPassword, err := utils.GenerateRandomPassword()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate password")
+		return
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(placeholderPassword), bcrypt.DefaultCost)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to process password")
+		return
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO users (email, password_hash, name, phone, user_type, email_verified)
+		VALUES ($1, $2, $3, $4, $5, false)
+		RETURNING id
+	`, req.Email, string(hashedPassword), req.Name, req.Phone, models.UserTypeContractor).Scan(&userID)
+	if err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to create user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create user account")
+		return
+	}
+
+	resetToken, err := utils.GenerateResetToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO password_resets (user_id, reset_token, expires_at)
+		VALUES ($1, $2, $3)
+	`, userID, resetToken, time.Now().UTC().Add(organizationInvitationExpiry))
+	if err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to create reset token: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	var member models.OrganizationMember
+	err = tx.QueryRow(`
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+		RETURNING id, organization_id, user_id, role, created_at, updated_at
+	`, userCtx.OrganizationID, userID, req.Role).
+		Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Role, &member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to add member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to add member")
+		return
+	}
+	member.Name = name
+	member.Email = req.Email
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if err := utils.SendOrganizationInvitationEmail(req.Email, name, orgName, inviterName, resetToken); err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to send invitation email: %v", err)
+	}
+
+	log.Printf("SUCCESS AddOrganizationMember: Added %s to organization %s as %s", userID, userCtx.OrganizationID, req.Role)
+	respondWithJSON(w, http.StatusCreated, member)
+}
+
+func inviteOrganizationMember(w http.ResponseWriter, tx *sql.Tx, userCtx middleware.UserContext, req models.AddOrganizationMemberRequest, name, orgName, inviterName string) {
+	var alreadyMember bool
+	err := tx.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM organization_members m JOIN users u ON u.id = m.user_id
+			WHERE m.organization_id = $1 AND LOWER(u.email) = LOWER($2)
+		)
+	`, userCtx.OrganizationID, req.Email).Scan(&alreadyMember)
+	if err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to check membership: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+	if alreadyMember {
+		respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeAlreadyMember, "This person is already a member of your organization"))
+		return
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate invitation token")
+		return
+	}
+
+	invitation, err := scanOrganizationInvitation(tx.QueryRow(`
+		INSERT INTO organization_invitations (organization_id, invited_by, email, role, token_hash, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+organizationInvitationColumns,
+		userCtx.OrganizationID, userCtx.UserID, strings.ToLower(req.Email), req.Role, utils.HashLoginCode(token),
+		models.InvitationStatusPending, time.Now().UTC().Add(organizationInvitationExpiry)))
+	if err != nil {
+		if apierror.IsUniqueViolation(err, "idx_organization_invitations_pending") {
+			respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeInvitationPending, "An invitation is already pending for this email"))
+			return
+		}
+		log.Printf("ERROR AddOrganizationMember: Failed to create invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if err := utils.SendOrganizationJoinInvitationEmail(req.Email, name, orgName, inviterName, token); err != nil {
+		log.Printf("ERROR AddOrganizationMember: Failed to send invitation email: %v", err)
+	}
+
+	log.Printf("SUCCESS AddOrganizationMember: Invited %s to organization %s as %s", invitation.Email, userCtx.OrganizationID, req.Role)
+	respondWithJSON(w, http.StatusAccepted, invitation)
+}
+
+const organizationInvitationColumns = `id, organization_id, invited_by, email, role, status, expires_at,
+	accepted_by, accepted_at, created_at, updated_at`
+
+func scanOrganizationInvitation(row rowScanner) (*models.OrganizationInvitation, error) {
+	var inv models.OrganizationInvitation
+	err := row.Scan(&inv.ID, &inv.OrganizationID, &inv.InvitedBy, &inv.Email, &inv.Role, &inv.Status, &inv.ExpiresAt,
+		&inv.AcceptedBy, &inv.AcceptedAt, &inv.CreatedAt, &inv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// AcceptOrganizationInvitation moves the caller into the organization that
+// invited them, releasing their personal organization as AddOrganizationMember
+// used to do without asking.
+func AcceptOrganizationInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) {
+		respondWithError(w, http.StatusForbidden, "Only contractor accounts can join an organization")
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	invitation, err := scanOrganizationInvitation(tx.QueryRow(`
+		SELECT `+organizationInvitationColumns+`
+		FROM organization_invitations
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, utils.HashLoginCode(req.Token)))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if invitation.Status != models.InvitationStatusPending || time.Now().After(invitation.ExpiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+
+	if !strings.EqualFold(invitation.Email, strings.TrimSpace(userCtx.Email)) {
+		respondWithError(w, http.StatusForbidden, "This invitation was sent to a different email address")
+		return
+	}
+
+	status, message, err := releaseSoloOrganization(tx, userCtx.UserID, invitation.OrganizationID)
+	if err != nil {
+		log.Printf("ERROR AcceptOrganizationInvitation: Failed to release existing organization: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+	if status != 0 {
+		respondWithError(w, status, message)
+		return
+	}
+
+	var member models.OrganizationMember
+	err = tx.QueryRow(`
+		INSERT INTO organization_members (organization_id, user_id, role)
+		VALUES ($1, $2, $3)
+		RETURNING id, organization_id, user_id, (SELECT name FROM users WHERE id = $2), role, created_at, updated_at
+	`, invitation.OrganizationID, userCtx.UserID, invitation.Role).
+		Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Name, &member.Role, &member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR AcceptOrganizationInvitation: Failed to add member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+	member.Email = userCtx.Email
+
+	_, err = tx.Exec(`
+		UPDATE organization_invitations
+		SET status = $1, accepted_by = $2, accepted_at = NOW()
+		WHERE id = $3
+	`, models.InvitationStatusAccepted, userCtx.UserID, invitation.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	log.Printf("SUCCESS AcceptOrganizationInvitation: User %s joined organization %s as %s", userCtx.UserID, invitation.OrganizationID, invitation.Role)
+	respondWithJSON(w, http.StatusOK, member)
+}
+
+// releaseSoloOrganization lets an existing contractor join another
+// organization only when the one they currently belong to is a personal,
+// unused organization, which is then deleted. A non-zero status means the move
+// was refused.
+func releaseSoloOrganization(tx *sql.Tx, userID, targetOrganizationID string) (int, string, error) {
+	currentOrgID, err := organizationIDForUser(tx, userID)
+	if err == sql.ErrNoRows {
+		return 0, "", nil
+	}
+	if err != nil {
+		return 0, "", err
+	}
+
+	if currentOrgID == targetOrganizationID {
+		return http.StatusConflict, "This person is already a member of your organization", nil
+	}
+
+	var otherMembers int
+	var hasBusiness bool
+	err = tx.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM organization_members WHERE organization_id = $1 AND user_id != $2),
+			EXISTS(SELECT 1 FROM contracts WHERE organization_id = $1)
+			OR EXISTS(SELECT 1 FROM employees WHERE organization_id = $1)
+	`, currentOrgID, userID).Scan(&otherMembers, &hasBusiness)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if otherMembers > 0 || hasBusiness {
+		return http.StatusConflict, "This person already belongs to an organization with its own projects or staff", nil
+	}
+
+	if _, err := tx.Exec("DELETE FROM organizations WHERE id = $1", currentOrgID); err != nil {
+		return 0, "", err
+	}
+
+	return 0, "", nil
+}
+
+func UpdateOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can change roles")
+		return
+	}
+
+	memberID := mux.Vars(r)["id"]
+
+	var req models.UpdateOrganizationMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if !models.ValidOrganizationRoles[req.Role] {
+		respondWithAPIError(w, apierror.Invalid("role", "Invalid role"))
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	currentRole, status, message := lockOrganizationMember(tx, memberID, userCtx)
+	if status != 0 {
+		respondWithError(w, status, message)
+		return
+	}
+
+	if req.Role == models.OrganizationRoleOwner && !userCtx.HasOrganizationRole(models.OrganizationRoleOwner) {
+		respondWithError(w, http.StatusForbidden, "Only owners can grant the owner role")
+		return
+	}
+
+	if currentRole == models.OrganizationRoleOwner && req.Role != models.OrganizationRoleOwner {
+		if status, message := ensureAnotherOwner(tx, userCtx.OrganizationID, memberID); status != 0 {
+			respondWithError(w, status, message)
+			return
+		}
+	}
+
+	var member models.OrganizationMember
+	err = tx.QueryRow(`
+		UPDATE organization_members m SET role = $1
+		FROM users u
+		WHERE m.id = $2 AND u.id = m.user_id
+		RETURNING m.id, m.organization_id, m.user_id, u.name, u.email, m.role, m.created_at, m.updated_at
+	`, req.Role, memberID).Scan(&member.ID, &member.OrganizationID, &member.UserID, &member.Name, &member.Email,
+		&member.Role, &member.CreatedAt, &member.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR UpdateOrganizationMember: Failed to update member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, member)
+}
+
+func RemoveOrganizationMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	memberID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	var memberUserID, memberName string
+	err = tx.QueryRow(`
+		SELECT m.user_id, u.name FROM organization_members m JOIN users u ON u.id = m.user_id
+		WHERE m.id = $1 AND m.organization_id = $2
+	`, memberID, userCtx.OrganizationID).Scan(&memberUserID, &memberName)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrMemberNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch member")
+		return
+	}
+
+	leaving := memberUserID == userCtx.UserID
+	if !leaving && !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can remove members")
+		return
+	}
+
+	currentRole, status, message := lockOrganizationMember(tx, memberID, userCtx)
+	if status != 0 && !leaving {
+		respondWithError(w, status, message)
+		return
+	}
+
+	if currentRole == models.OrganizationRoleOwner {
+		if status, message := ensureAnotherOwner(tx, userCtx.OrganizationID, memberID); status != 0 {
+			respondWithError(w, status, message)
+			return
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM organization_members WHERE id = $1", memberID); err != nil {
+		log.Printf("ERROR RemoveOrganizationMember: Failed to remove member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	// Every contractor account keeps an organization of its own so it can
+	// still run projects after leaving a company.
+	if _, err := createOrganization(tx, memberUserID, memberName); err != nil {
+		log.Printf("ERROR RemoveOrganizationMember: Failed to create personal organization: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lockOrganizationMember loads a member of the caller's organization for
+// update and checks that the caller may modify them: admins cannot modify
+// owners.
+func lockOrganizationMember(tx *sql.Tx, memberID string, userCtx middleware.UserContext) (models.OrganizationRole, int, string) {
+	var role models.OrganizationRole
+	err := tx.QueryRow(`
+		SELECT role FROM organization_members
+		WHERE id = $1 AND organization_id = $2
+		FOR UPDATE
+	`, memberID, userCtx.OrganizationID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", http.StatusNotFound, "Member not found"
+	}
+	if err != nil {
+		return "", http.StatusInternalServerError, "Failed to fetch member"
+	}
+
+	if role == models.OrganizationRoleOwner && !userCtx.HasOrganizationRole(models.OrganizationRoleOwner) {
+		return role, http.StatusForbidden, "Only owners can modify other owners"
+	}
+
+	return role, 0, ""
+}
+
+func ensureAnotherOwner(tx *sql.Tx, organizationID, memberID string) (int, string) {
+	var otherOwners int
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FROM organization_members
+		WHERE organization_id = $1 AND role = $2 AND id != $3
+	`, organizationID, models.OrganizationRoleOwner, memberID).Scan(&otherOwners)
+	if err != nil {
+		return http.StatusInternalServerError, "Failed to check owners"
+	}
+
+	if otherOwners == 0 {
+		return http.StatusBadRequest, "An organization must keep at least one owner"
+	}
+
+	return 0, ""
+}
@@ -0,0 +1,403 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+)
+
+const ownershipTransferColumns = `id, project_id, from_user_id, to_email, to_user_id, status, expires_at,
+	completed_at, created_at, updated_at`
+
+func scanOwnershipTransfer(row rowScanner) (*models.ProjectOwnershipTransfer, error) {
+	var t models.ProjectOwnershipTransfer
+	err := row.Scan(&t.ID, &t.ProjectID, &t.FromUserID, &t.ToEmail, &t.ToUserID, &t.Status, &t.ExpiresAt,
+		&t.CompletedAt, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func InitiateOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	var req models.InitiateOwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !emailRegex.MatchString(email) {
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
+		return
+	}
+
+	if strings.EqualFold(email, userCtx.Email) {
+		respondWithError(w, http.StatusBadRequest, "You already own this project")
+		return
+	}
+
+	db := database.GetDB()
+
+	var ownerID, projectTitle string
+	err := db.QueryRow("SELECT owner_id, title FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID, &projectTitle)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can transfer ownership")
+		return
+	}
+
+	var existingUserID, existingUserType string
+	err = db.QueryRow("SELECT id, user_type FROM users WHERE LOWER(email) = $1", email).
+		Scan(&existingUserID, &existingUserType)
+	if err != nil && err != sql.ErrNoRows {
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+
+	if err == nil && existingUserType != string(models.UserTypeHouseOwner) {
+		respondWithError(w, http.StatusBadRequest, "This email belongs to an account that is not a house owner")
+		return
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate transfer token")
+		return
+	}
+
+	transfer, err := scanOwnershipTransfer(db.QueryRow(`
+		INSERT INTO project_ownership_transfers (project_id, from_user_id, to_email, token_hash, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+ownershipTransferColumns,
+		projectID, userCtx.UserID, email, utils.HashLoginCode(token), models.OwnershipTransferStatusPending,
+		time.Now().UTC().Add(invitationExpiry)))
+	if err != nil {
+		if apierror.IsUniqueViolation(err, "idx_project_ownership_transfers_pending") {
+			respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeTransferPending, "A transfer is already pending for this project. Cancel it first."))
+			return
+		}
+		log.Printf("ERROR InitiateOwnershipTransfer: Failed to create transfer: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create transfer")
+		return
+	}
+
+	ownerInfo, err := getUserInfo(db, userCtx.UserID)
+	if err == nil {
+		if err := utils.SendOwnershipTransferRequestEmail(email, ownerInfo.Name, projectTitle, token); err != nil {
+			log.Printf("Failed to send ownership transfer email: %v", err)
+		}
+	}
+
+	log.Printf("SUCCESS InitiateOwnershipTransfer: Project %s offered to %s", projectID, email)
+	respondWithJSON(w, http.StatusCreated, transfer)
+}
+
+func GetPendingOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	transfer, err := scanOwnershipTransfer(db.QueryRow(`
+		SELECT `+ownershipTransferColumns+`
+		FROM project_ownership_transfers
+		WHERE project_id = $1 AND status = $3
+		  AND EXISTS(SELECT 1 FROM projects WHERE id = $1 AND deleted_at IS NULL AND owner_id = $2)
+	`, projectID, userCtx.UserID, models.OwnershipTransferStatusPending))
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusNotFound, "No pending transfer")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR GetPendingOwnershipTransfer: Failed to query transfer: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch transfer")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, transfer)
+}
+
+func CancelOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	result, err := db.Exec(`
+		UPDATE project_ownership_transfers t
+		SET status = $1, completed_at = NOW()
+		FROM projects p
+		WHERE t.project_id = p.id AND t.project_id = $2 AND p.owner_id = $3 AND t.status = $4
+	`, models.OwnershipTransferStatusCancelled, projectID, userCtx.UserID, models.OwnershipTransferStatusPending)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to cancel transfer")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithError(w, http.StatusNotFound, "No pending transfer")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func GetOwnershipTransferByToken(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Transfer token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var email, projectTitle, ownerName string
+	var status models.OwnershipTransferStatus
+	var expiresAt time.Time
+	err := db.QueryRow(`
+		SELECT t.to_email, t.status, t.expires_at, p.title, u.name
+		FROM project_ownership_transfers t
+		JOIN projects p ON t.project_id = p.id
+		JOIN users u ON t.from_user_id = u.id
+		WHERE t.token_hash = $1 AND p.deleted_at IS NULL
+	`, utils.HashLoginCode(token)).Scan(&email, &status, &expiresAt, &projectTitle, &ownerName)
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusNotFound, "Transfer not found")
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch transfer")
+		return
+	}
+
+	if status != models.OwnershipTransferStatusPending || time.Now().After(expiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusGone, apierror.CodeTransferExpired, "This transfer is no longer valid"))
+		return
+	}
+
+	var accountExists bool
+	db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = $1)", email).Scan(&accountExists)
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"email":          email,
+		"project_title":  projectTitle,
+		"owner_name":     ownerName,
+		"expires_at":     expiresAt,
+		"account_exists": accountExists,
+	})
+}
+
+func AcceptOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	respondToOwnershipTransfer(w, r, true)
+}
+
+func DeclineOwnershipTransfer(w http.ResponseWriter, r *http.Request) {
+	respondToOwnershipTransfer(w, r, false)
+}
+
+// respondToOwnershipTransfer moves the project and every contract on it to the
+// recipient in one transaction. The transfer row doubles as the audit record.
+func respondToOwnershipTransfer(w http.ResponseWriter, r *http.Request, accept bool) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if accept && userCtx.UserType != string(models.UserTypeHouseOwner) {
+		respondWithError(w, http.StatusForbidden, "Only house owner accounts can take over projects")
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Transfer token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	transfer, err := scanOwnershipTransfer(tx.QueryRow(`
+		SELECT `+ownershipTransferColumns+`
+		FROM project_ownership_transfers
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, utils.HashLoginCode(req.Token)))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired transfer token"))
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch transfer")
+		return
+	}
+
+	if transfer.Status != models.OwnershipTransferStatusPending || time.Now().After(transfer.ExpiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired transfer token"))
+		return
+	}
+
+	if !strings.EqualFold(transfer.ToEmail, strings.TrimSpace(userCtx.Email)) {
+		respondWithError(w, http.StatusForbidden, "This transfer was sent to a different email address")
+		return
+	}
+
+	if !accept {
+		_, err = tx.Exec(`
+			UPDATE project_ownership_transfers SET status = $1, to_user_id = $2, completed_at = NOW() WHERE id = $3
+		`, models.OwnershipTransferStatusDeclined, userCtx.UserID, transfer.ID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to decline transfer")
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+			return
+		}
+		respondWithJSON(w, http.StatusOK, map[string]string{"message": "Transfer declined"})
+		return
+	}
+
+	var projectTitle string
+	err = tx.QueryRow(`
+		UPDATE projects SET owner_id = $1 WHERE id = $2 AND owner_id = $3 AND deleted_at IS NULL RETURNING title
+	`, userCtx.UserID, transfer.ProjectID, transfer.FromUserID).Scan(&projectTitle)
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusConflict, "The project owner has changed since this transfer was sent")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR AcceptOwnershipTransfer: Failed to update project: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to transfer project")
+		return
+	}
+
+	if _, err := tx.Exec("UPDATE contracts SET owner_id = $1 WHERE project_id = $2", userCtx.UserID, transfer.ProjectID); err != nil {
+		log.Printf("ERROR AcceptOwnershipTransfer: Failed to update contracts: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to transfer contracts")
+		return
+	}
+
+	// The new owner no longer needs a co-owner or viewer membership.
+	_, err = tx.Exec("DELETE FROM project_members WHERE project_id = $1 AND user_id = $2", transfer.ProjectID, userCtx.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to transfer project")
+		return
+	}
+
+	_, err = tx.Exec(`
+		UPDATE project_ownership_transfers SET status = $1, to_user_id = $2, completed_at = NOW() WHERE id = $3
+	`, models.OwnershipTransferStatusAccepted, userCtx.UserID, transfer.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to complete transfer")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	log.Printf("SUCCESS AcceptOwnershipTransfer: Project %s moved from %s to %s", transfer.ProjectID, transfer.FromUserID, userCtx.UserID)
+
+	notifyOwnershipTransferred(db, transfer, projectTitle, userCtx.UserID)
+
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message":    "Ownership transferred successfully",
+		"project_id": transfer.ProjectID,
+	})
+}
+
+func notifyOwnershipTransferred(db *sql.DB, transfer *models.ProjectOwnershipTransfer, projectTitle, newOwnerID string) {
+	var previousOwnerEmail, previousOwnerName string
+	err := db.QueryRow("SELECT email, name FROM users WHERE id = $1", transfer.FromUserID).
+		Scan(&previousOwnerEmail, &previousOwnerName)
+	if err != nil {
+		log.Printf("Failed to load previous owner for transfer %s: %v", transfer.ID, err)
+		return
+	}
+	newOwner, err := getUserInfo(db, newOwnerID)
+	if err != nil {
+		log.Printf("Failed to load new owner for transfer %s: %v", transfer.ID, err)
+		return
+	}
+
+	if err := utils.SendOwnershipTransferredNotification(previousOwnerEmail, previousOwnerName, projectTitle,
+		previousOwnerName, newOwner.Name); err != nil {
+		log.Printf("Failed to send ownership transferred notification to previous owner: %v", err)
+	}
+
+	rows, err := db.Query(`
+		SELECT DISTINCT u.email, u.name
+		FROM contracts c
+		JOIN organization_members om ON om.organization_id = c.organization_id
+		JOIN users u ON om.user_id = u.id
+		WHERE c.project_id = $1 AND c.status != 'terminated'
+		  AND om.role IN ('owner', 'admin', 'office_manager')
+	`, transfer.ProjectID)
+	if err != nil {
+		log.Printf("Failed to load contractors for transfer %s: %v", transfer.ID, err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var email, name string
+		if err := rows.Scan(&email, &name); err != nil {
+			continue
+		}
+		if err := utils.SendOwnershipTransferredNotification(email, name, projectTitle, previousOwnerName, newOwner.Name); err != nil {
+			log.Printf("Failed to send ownership transferred notification to contractor: %v", err)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/sms"
+	"github.com/juazsh/managrr/internal/utils"
+)
+
+const (
+	magicLinkExpiry      = 15 * time.Minute
+	smsCodeExpiry        = 10 * time.Minute
+	loginCodeWindow      = time.Hour
+	maxLoginCodesPerUser = 5
+	maxLoginCodeAttempts = 5
+)
+
+var nonDigitRegex = regexp.MustCompile(`[^0-9]`)
+
+const passwordlessRequestedMessage = "If an account matches, a login link or code has been sent."
+
+func RequestPasswordlessLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.PasswordlessLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	db := database.GetDB()
+
+	var userID, email, name string
+	var phone *string
+	var err error
+
+	switch req.Channel {
+	case models.LoginCodeChannelEmail:
+		if !emailRegex.MatchString(req.Email) {
+			respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
+			return
+		}
+		err = db.QueryRow(`
+			SELECT id, email, name, phone FROM users
+			WHERE LOWER(email) = LOWER($1) AND user_type = $2 AND disabled_at IS NULL
+		`, req.Email, models.UserTypeEmployee).Scan(&userID, &email, &name, &phone)
+
+	case models.LoginCodeChannelSMS:
+		if len(nonDigitRegex.ReplaceAllString(req.Phone, "")) < 7 {
+			respondWithAPIError(w, apierror.Invalid("phone", "Invalid phone number"))
+			return
+		}
+		userID, email, name, phone, err = findEmployeeByPhone(db, req.Phone)
+
+	default:
+		respondWithAPIError(w, apierror.Invalid("channel", "Channel must be email or sms"))
+		return
+	}
+
+	if err == sql.ErrNoRows {
+		respondWithJSON(w, http.StatusAccepted, map[string]string{"message": passwordlessRequestedMessage})
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR RequestPasswordlessLogin: Failed to look up user: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to process request")
+		return
+	}
+
+	var recentCodes int
+	err = db.QueryRow(`
+		SELECT COUNT(*) FROM login_codes WHERE user_id = $1 AND created_at > $2
+	`, userID, time.Now().UTC().Add(-loginCodeWindow)).Scan(&recentCodes)
+	if err != nil {
+		log.Printf("ERROR RequestPasswordlessLogin: Failed to count recent codes: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to process request")
+		return
+	}
+
+	// A limited request gets the same answer as one for an unknown address,
+	// so the response never tells whether an account exists.
+	if recentCodes >= maxLoginCodesPerUser {
+		log.Printf("ERROR RequestPasswordlessLogin: Rate limit reached for user %s", userID)
+		respondWithJSON(w, http.StatusAccepted, map[string]string{"message": passwordlessRequestedMessage})
+		return
+	}
+
+	// Only the newest code for a channel is ever valid.
+	_, err = db.Exec(`
+		UPDATE login_codes SET expires_at = NOW()
+		WHERE user_id = $1 AND channel = $2 AND used_at IS NULL AND expires_at > NOW()
+	`, userID, req.Channel)
+	if err != nil {
+		log.Printf("ERROR RequestPasswordlessLogin: Failed to expire previous codes: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to process request")
+		return
+	}
+
+	var secret string
+	var expiry time.Duration
+	if req.Channel == models.LoginCodeChannelEmail {
+		secret, err = utils.GenerateVerificationToken()
+		expiry = magicLinkExpiry
+	} else {
+		secret, err = utils.GenerateLoginCode()
+		expiry = smsCodeExpiry
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate login code")
+		return
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO login_codes (user_id, channel, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, userID, req.Channel, utils.HashLoginCode(secret), time.Now().UTC().Add(expiry))
+	if err != nil {
+		log.Printf("ERROR RequestPasswordlessLogin: Failed to store login code: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create login code")
+		return
+	}
+
+	if req.Channel == models.LoginCodeChannelEmail {
+		err = utils.SendMagicLinkEmail(email, name, secret)
+	} else {
+		message := fmt.Sprintf("Your Managrr login code is %s. It expires in %d minutes.", secret, int(smsCodeExpiry.Minutes()))
+		err = sms.GetProvider().Send(*phone, message)
+	}
+	if err != nil {
+		log.Printf("ERROR RequestPasswordlessLogin: Failed to deliver %s login code to user %s: %v", req.Channel, userID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to send login code")
+		return
+	}
+
+	log.Printf("SUCCESS RequestPasswordlessLogin: Sent %s login code to user %s", req.Channel, userID)
+	respondWithJSON(w, http.StatusAccepted, map[string]string{"message": passwordlessRequestedMessage})
+}
+
+func VerifyPasswordlessLogin(w http.ResponseWriter, r *http.Request) {
+	var req models.PasswordlessVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	db := database.GetDB()
+
+	var userID string
+	if req.Token != "" {
+		err := db.QueryRow(`
+			UPDATE login_codes SET used_at = NOW()
+			WHERE code_hash = $1 AND channel = $2 AND used_at IS NULL AND expires_at > NOW()
+			RETURNING user_id
+		`, utils.HashLoginCode(req.Token), models.LoginCodeChannelEmail).Scan(&userID)
+		if err == sql.ErrNoRows {
+			respondWithAPIError(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired login link"))
+			return
+		}
+		if err != nil {
+			log.Printf("ERROR VerifyPasswordlessLogin: Failed to consume login link: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify login link")
+			return
+		}
+
+		// Following the link proves ownership of the inbox.
+		if _, err := db.Exec("UPDATE users SET email_verified = true WHERE id = $1", userID); err != nil {
+			log.Printf("ERROR VerifyPasswordlessLogin: Failed to mark email verified: %v", err)
+		}
+	} else {
+		if req.Phone == "" || len(req.Code) != 6 {
+			respondWithError(w, http.StatusBadRequest, "Phone and 6-digit code are required")
+			return
+		}
+
+		id, _, _, _, err := findEmployeeByPhone(db, req.Phone)
+		if err == sql.ErrNoRows {
+			respondWithAPIError(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired code"))
+			return
+		}
+		if err != nil {
+			log.Printf("ERROR VerifyPasswordlessLogin: Failed to look up user: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify code")
+			return
+		}
+
+		ok, err := consumeSMSLoginCode(db, id, req.Code)
+		if err != nil {
+			log.Printf("ERROR VerifyPasswordlessLogin: Failed to verify code: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify code")
+			return
+		}
+		if !ok {
+			respondWithAPIError(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired code"))
+			return
+		}
+		userID = id
+	}
+
+	var user models.User
+	err := db.QueryRow(`
+		SELECT id, email, name, phone, user_type, email_verified, time_zone, disabled_at, created_at, updated_at
+		FROM users
+		WHERE id = $1
+	`, userID).Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.TimeZone, &user.DisabledAt, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to query user")
+		return
+	}
+
+	if user.DisabledAt != nil {
+		respondWithAPIError(w, apierror.ErrAccountDisabled)
+		return
+	}
+
+	token, err := utils.GenerateToken(user.ID, user.Email, string(user.UserType))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	log.Printf("SUCCESS VerifyPasswordlessLogin: User %s logged in without a password", user.ID)
+	respondWithJSON(w, http.StatusOK, models.AuthResponse{
+		Token: token,
+		User:  user,
+	})
+}
+
+// findEmployeeByPhone matches on digits only so "+1 (555) 010-2000" and
+// "15550102000" are the same number. Ambiguous matches are treated as no match.
+func findEmployeeByPhone(db *sql.DB, phone string) (string, string, string, *string, error) {
+	rows, err := db.Query(`
+		SELECT id, email, name, phone FROM users
+		WHERE user_type = $1 AND phone IS NOT NULL AND disabled_at IS NULL
+		  AND regexp_replace(phone, '[^0-9]', '', 'g') = $2
+		LIMIT 2
+	`, models.UserTypeEmployee, nonDigitRegex.ReplaceAllString(phone, ""))
+	if err != nil {
+		return "", "", "", nil, err
+	}
+	defer rows.Close()
+
+	var id, email, name string
+	var storedPhone *string
+	matches := 0
+	for rows.Next() {
+		if err := rows.Scan(&id, &email, &name, &storedPhone); err != nil {
+			return "", "", "", nil, err
+		}
+		matches++
+	}
+	if err := rows.Err(); err != nil {
+		return "", "", "", nil, err
+	}
+
+	if matches != 1 {
+		return "", "", "", nil, sql.ErrNoRows
+	}
+
+	return id, email, name, storedPhone, nil
+}
+
+func consumeSMSLoginCode(db *sql.DB, userID, code string) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var codeID, codeHash string
+	var attempts int
+	err = tx.QueryRow(`
+		SELECT id, code_hash, attempts FROM login_codes
+		WHERE user_id = $1 AND channel = $2 AND used_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`, userID, models.LoginCodeChannelSMS).Scan(&codeID, &codeHash, &attempts)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(codeHash), []byte(utils.HashLoginCode(code))) != 1 {
+		attempts++
+		query := "UPDATE login_codes SET attempts = $2 WHERE id = $1"
+		if attempts >= maxLoginCodeAttempts {
+			query = "UPDATE login_codes SET attempts = $2, expires_at = NOW() WHERE id = $1"
+		}
+		if _, err := tx.Exec(query, codeID, attempts); err != nil {
+			return false, err
+		}
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.Exec("UPDATE login_codes SET used_at = NOW() WHERE id = $1", codeID); err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
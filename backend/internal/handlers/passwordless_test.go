@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// A rate-limited account and an unknown address get the same answer, so the
+// endpoint cannot be used to find out which addresses have accounts.
+func TestPasswordlessRequestDoesNotRevealRateLimitedAccounts(t *testing.T) {
+	request := func(setup func(sqlmock.Sqlmock)) *httptest.ResponseRecorder {
+		mock := newMockDB(t)
+		setup(mock)
+		rec := httptest.NewRecorder()
+		RequestPasswordlessLogin(rec, httptest.NewRequest("POST", "/api/auth/passwordless/request",
+			strings.NewReader(`{"channel":"email","email":"sam@example.com"}`)))
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Fatal(err)
+		}
+		return rec
+	}
+
+	unknown := request(func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`FROM users`).WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "phone"}))
+	})
+	limited := request(func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`FROM users`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "phone"}).AddRow(testUserID, "sam@example.com", "Sam", nil))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM login_codes`).WithArgs(testUserID, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(maxLoginCodesPerUser))
+	})
+
+	if unknown.Code != http.StatusAccepted || limited.Code != unknown.Code || limited.Body.String() != unknown.Body.String() {
+		t.Fatalf("unknown: %d %s; rate limited: %d %s", unknown.Code, unknown.Body, limited.Code, limited.Body)
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 	"github.com/juazsh/managrr/internal/utils"
 	"github.com/xuri/excelize/v2"
@@ -23,7 +27,7 @@ import (
 func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -33,25 +37,25 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	projectID := r.FormValue("project_id")
 	if projectID == "" {
-		respondWithError(w, http.StatusBadRequest, "project_id is required")
+		respondWithAPIError(w, apierror.Invalid("project_id", "project_id is required"))
 		return
 	}
 
 	amount := r.FormValue("amount")
 	if amount == "" {
-		respondWithError(w, http.StatusBadRequest, "amount is required")
+		respondWithAPIError(w, apierror.Invalid("amount", "amount is required"))
 		return
 	}
 
 	paymentMethod := r.FormValue("payment_method")
 	if paymentMethod == "" {
-		respondWithError(w, http.StatusBadRequest, "payment_method is required")
+		respondWithAPIError(w, apierror.Invalid("payment_method", "payment_method is required"))
 		return
 	}
 
@@ -62,34 +66,38 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 		paymentMethod != string(models.PaymentMethodCashApp) &&
 		paymentMethod != string(models.PaymentMethodVenmo) &&
 		paymentMethod != string(models.PaymentMethodOther) {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment_method")
+		respondWithAPIError(w, apierror.Invalid("payment_method", "Invalid payment_method"))
 		return
 	}
 
 	paymentDate := r.FormValue("payment_date")
 	if paymentDate == "" {
-		respondWithError(w, http.StatusBadRequest, "payment_date is required")
+		respondWithAPIError(w, apierror.Invalid("payment_date", "payment_date is required"))
+		return
+	}
+	paymentDate, err := calendar.ParseDate(paymentDate)
+	if err != nil {
+		respondWithAPIError(w, apierror.Invalid("payment_date", "payment_date "+err.Error()))
 		return
 	}
 
-	var amountFloat float64
-	if _, err := strconv.ParseFloat(amount, 64); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid amount format")
+	parsedAmount, apiErr := parseAmount("amount", amount)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	amountFloat, _ = strconv.ParseFloat(amount, 64)
 
-	if amountFloat <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Amount must be positive")
+	currency, apiErr := parseCurrencyField("currency", r.FormValue("currency"), "")
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
 	db := database.GetDB()
 
-	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -97,14 +105,14 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if ownerID != userCtx.UserID {
+	if !access.CanConfirmPayments {
 		respondWithError(w, http.StatusForbidden, "Only the project owner can add payment summaries")
 		return
 	}
 
 	contractID := r.FormValue("contract_id")
 	if contractID == "" {
-		respondWithError(w, http.StatusBadRequest, "contract_id is required")
+		respondWithAPIError(w, apierror.ErrContractRequired)
 		return
 	}
 
@@ -112,12 +120,18 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	err = db.QueryRow(`
 		SELECT EXISTS(
 			SELECT 1 FROM contracts
-			WHERE id = $1 AND project_id = $2 AND owner_id = $3
+			WHERE id = $1 AND project_id = $2
 		)
-	`, contractID, projectID, userCtx.UserID).Scan(&exists)
+	`, contractID, projectID).Scan(&exists)
 
 	if err != nil || !exists {
-		respondWithError(w, http.StatusBadRequest, "Invalid contract_id for this project")
+		respondWithAPIError(w, apierror.ErrInvalidContract)
+		return
+	}
+
+	converted, apiErr := convertToProject(db, projectID, currency, parsedAmount, paymentDate)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
@@ -128,7 +142,7 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 
 		ext := strings.ToLower(filepath.Ext(header.Filename))
 		if !allowedExtensions[ext] {
-			respondWithError(w, http.StatusBadRequest, "Invalid file type. Allowed: jpg, jpeg, png")
+			respondWithAPIError(w, apierror.ErrUnsupportedImage.WithMessage("Invalid file type. Allowed: jpg, jpeg, png"))
 			return
 		}
 
@@ -144,9 +158,9 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	notes := r.FormValue("notes")
 
 	query := `
-		INSERT INTO payment_summaries (project_id, contract_id, amount, payment_method, payment_date, screenshot_url, notes, added_by)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, project_id, contract_id, amount, payment_method, payment_date, screenshot_url, notes, added_by, status, created_at, updated_at
+		INSERT INTO payment_summaries (project_id, contract_id, amount, currency, exchange_rate, converted_amount, payment_method, payment_date, screenshot_url, notes, added_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, project_id, contract_id, amount, currency, exchange_rate, converted_amount, payment_method, payment_date::text, screenshot_url, notes, added_by, status, created_at, updated_at
 	`
 
 	var payment models.PaymentSummary
@@ -154,7 +168,10 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 		query,
 		projectID,
 		contractID,
-		amountFloat,
+		parsedAmount,
+		converted.Currency,
+		converted.Rate,
+		converted.Converted,
 		paymentMethod,
 		paymentDate,
 		screenshotURL,
@@ -165,6 +182,9 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 		&payment.ProjectID,
 		&payment.ContractID,
 		&payment.Amount,
+		&payment.Currency,
+		&payment.ExchangeRate,
+		&payment.ConvertedAmount,
 		&payment.PaymentMethod,
 		&payment.PaymentDate,
 		&payment.ScreenshotURL,
@@ -188,7 +208,8 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 				participants.ContractorName.String,
 				participants.OwnerName,
 				participants.ProjectTitle,
-				amountFloat,
+				parsedAmount,
+				converted.Currency,
 				paymentMethod,
 				paymentDate,
 			)
@@ -201,10 +222,25 @@ func AddPaymentSummary(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, payment)
 }
 
+var paymentListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"payment_date": {Column: "ps.payment_date", Type: "date"},
+		"amount":       {Column: "ps.amount", Type: "numeric"},
+		"created_at":   {Column: "ps.created_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-payment_date",
+	IDColumn:    "ps.id",
+	DateColumn:  "ps.payment_date",
+	Filters: map[string]string{
+		"status":         "ps.status",
+		"payment_method": "ps.payment_method",
+	},
+}
+
 func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -215,9 +251,9 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -225,7 +261,7 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -233,7 +269,8 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -243,34 +280,45 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
-	query := `
-		SELECT ps.id, ps.project_id, ps.amount, ps.payment_method, ps.payment_date,
-		       ps.screenshot_url, ps.notes, ps.added_by, ps.status,
-		       ps.confirmed_by, ps.confirmed_at, ps.disputed_at, ps.dispute_reason,
-		       ps.created_at, ps.updated_at, u.name as added_by_name
+	params, err := pagination.Parse(r, paymentListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
+
+	from := `
 		FROM payment_summaries ps
 		JOIN users u ON ps.added_by = u.id
-		WHERE ps.project_id = $1
 	`
-
-	args := []interface{}{projectID}
-	argIndex := 2
+	q := &pagination.Query{}
+	q.Where("ps.project_id = " + q.Arg(projectID))
+	q.Where("ps.deleted_at IS NULL")
 
 	if isContractor {
-		query += " AND ps.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractorContractID)
-		argIndex++
+		q.Where("ps.contract_id = " + q.Arg(contractorContractID))
 	} else if contractFilter != "" {
-		query += " AND ps.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractFilter)
-		argIndex++
+		q.Where("ps.contract_id = " + q.Arg(contractFilter))
 	}
 
-	query += " ORDER BY ps.payment_date DESC, ps.created_at DESC"
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		log.Printf("ERROR ListPaymentSummaries: Failed to count payment summaries: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch payment summaries")
+		return
+	}
+
+	query, args := params.Select(`
+		ps.id, ps.project_id, ps.amount, ps.currency, ps.exchange_rate, ps.converted_amount,
+		ps.payment_method, ps.payment_date::text, ps.screenshot_url, ps.notes, ps.added_by, ps.status,
+		ps.confirmed_by, ps.confirmed_at, ps.disputed_at, ps.dispute_reason,
+		ps.created_at, ps.updated_at, ps.version, u.name as added_by_name,
+		`+params.CursorColumn(), from, q)
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -280,14 +328,19 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var payments []map[string]interface{}
+	var cursors []string
 	for rows.Next() {
 		var payment models.PaymentSummary
 		var addedByName string
+		var cursor string
 
 		err := rows.Scan(
 			&payment.ID,
 			&payment.ProjectID,
 			&payment.Amount,
+			&payment.Currency,
+			&payment.ExchangeRate,
+			&payment.ConvertedAmount,
 			&payment.PaymentMethod,
 			&payment.PaymentDate,
 			&payment.ScreenshotURL,
@@ -300,7 +353,9 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 			&payment.DisputeReason,
 			&payment.CreatedAt,
 			&payment.UpdatedAt,
+			&payment.Version,
 			&addedByName,
+			&cursor,
 		)
 
 		if err != nil {
@@ -309,38 +364,44 @@ func ListPaymentSummaries(w http.ResponseWriter, r *http.Request) {
 		}
 
 		paymentData := map[string]interface{}{
-			"id":             payment.ID,
-			"project_id":     payment.ProjectID,
-			"amount":         payment.Amount,
-			"payment_method": payment.PaymentMethod,
-			"payment_date":   payment.PaymentDate,
-			"screenshot_url": payment.ScreenshotURL,
-			"notes":          payment.Notes,
-			"added_by":       payment.AddedBy,
-			"added_by_name":  addedByName,
-			"status":         payment.Status,
-			"confirmed_by":   payment.ConfirmedBy,
-			"confirmed_at":   payment.ConfirmedAt,
-			"disputed_at":    payment.DisputedAt,
-			"dispute_reason": payment.DisputeReason,
-			"created_at":     payment.CreatedAt,
-			"updated_at":     payment.UpdatedAt,
+			"id":               payment.ID,
+			"project_id":       payment.ProjectID,
+			"amount":           payment.Amount,
+			"currency":         payment.Currency,
+			"exchange_rate":    payment.ExchangeRate,
+			"converted_amount": payment.ConvertedAmount,
+			"payment_method":   payment.PaymentMethod,
+			"payment_date":     payment.PaymentDate,
+			"screenshot_url":   payment.ScreenshotURL,
+			"notes":            payment.Notes,
+			"added_by":         payment.AddedBy,
+			"added_by_name":    addedByName,
+			"status":           payment.Status,
+			"confirmed_by":     payment.ConfirmedBy,
+			"confirmed_at":     payment.ConfirmedAt,
+			"disputed_at":      payment.DisputedAt,
+			"dispute_reason":   payment.DisputeReason,
+			"created_at":       payment.CreatedAt,
+			"updated_at":       payment.UpdatedAt,
+			"version":          payment.Version,
 		}
 
 		payments = append(payments, paymentData)
+		cursors = append(cursors, cursor)
 	}
 
-	if payments == nil {
-		payments = []map[string]interface{}{}
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating payment summaries")
+		return
 	}
 
-	respondWithJSON(w, http.StatusOK, payments)
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, payments, cursors, total))
 }
 
 func ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -352,45 +413,95 @@ func ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	paymentID := vars["id"]
 
+	if apiErr := confirmPayment(database.GetDB(), userCtx, paymentID); apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment confirmed successfully"})
+}
+
+// BulkConfirmPayments confirms several payments, each checked and confirmed
+// on its own as by ConfirmPayment. The response reports how each went.
+func BulkConfirmPayments(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) {
+		respondWithError(w, http.StatusForbidden, "Only contractors can confirm payments")
+		return
+	}
+
+	var req models.BulkConfirmPaymentsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+	if apiErr := validateBulkIDs("payment_ids", req.PaymentIDs); apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
 	db := database.GetDB()
 
+	results := newBulkResults(len(req.PaymentIDs))
+	for i, paymentID := range req.PaymentIDs {
+		if apiErr := confirmPayment(db, userCtx, paymentID); apiErr != nil {
+			results.fail(i, paymentID, apiErr)
+			continue
+		}
+		results.succeed(i, paymentID, http.StatusOK)
+	}
+
+	results.respond(w)
+}
+
+// confirmPayment marks a pending payment as received by a contractor working
+// on its project and lets the owner know.
+func confirmPayment(db *sql.DB, userCtx middleware.UserContext, paymentID string) *apierror.Error {
+	if !userCtx.CanManageBusiness() {
+		return apierror.ErrAccessDenied
+	}
+
 	var payment models.PaymentSummary
 	err := db.QueryRow(`
-		SELECT ps.id, ps.project_id, ps.status, ps.amount, ps.payment_date
+		SELECT ps.id, ps.project_id, ps.status, ps.amount, ps.currency, ps.payment_date::text
 		FROM payment_summaries ps
-		WHERE ps.id = $1
-	`, paymentID).Scan(&payment.ID, &payment.ProjectID, &payment.Status, &payment.Amount, &payment.PaymentDate)
+		WHERE ps.id = $1 AND ps.deleted_at IS NULL
+	`, paymentID).Scan(&payment.ID, &payment.ProjectID, &payment.Status, &payment.Amount, &payment.Currency, &payment.PaymentDate)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Payment summary not found")
-		return
+		return apierror.ErrPaymentNotFound
 	}
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to fetch payment summary")
-		return
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return apiErr
+		}
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch payment summary")
 	}
 
 	var hasAccess bool
 	err = db.QueryRow(`
     SELECT EXISTS(
-        SELECT 1 FROM project_contractors 
-        WHERE project_id = $1 AND contractor_id = $2
+        SELECT 1 FROM contracts
+        WHERE project_id = $1 AND status != 'terminated'
+          AND organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
     )
 `, payment.ProjectID, userCtx.UserID).Scan(&hasAccess)
 
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to verify project contractor")
-		return
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to verify project contractor")
 	}
 
 	if !hasAccess {
-		respondWithError(w, http.StatusForbidden, "Only assigned contractors can confirm payments")
-		return
+		return apierror.FromStatus(http.StatusForbidden, "Only assigned contractors can confirm payments")
 	}
 
 	if payment.Status != models.PaymentStatusPending {
-		respondWithError(w, http.StatusBadRequest, "Payment is not in pending status")
-		return
+		return apierror.ErrPaymentNotPending
 	}
 
 	_, err = db.Exec(`
@@ -400,8 +511,7 @@ func ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 	`, models.PaymentStatusConfirmed, userCtx.UserID, time.Now(), paymentID)
 
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to confirm payment")
-		return
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to confirm payment")
 	}
 
 	participants, err := getProjectParticipants(db, payment.ProjectID)
@@ -414,6 +524,7 @@ func ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 				userInfo.Name,
 				participants.ProjectTitle,
 				payment.Amount,
+				payment.Currency,
 				payment.PaymentDate,
 			)
 			if err != nil {
@@ -422,13 +533,13 @@ func ConfirmPayment(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment confirmed successfully"})
+	return nil
 }
 
 func DisputePayment(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -437,6 +548,11 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !userCtx.CanManageBusiness() {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
 	vars := mux.Vars(r)
 	paymentID := vars["id"]
 
@@ -445,12 +561,12 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if req.Reason == "" {
-		respondWithError(w, http.StatusBadRequest, "reason is required")
+		respondWithAPIError(w, apierror.Invalid("reason", "reason is required"))
 		return
 	}
 
@@ -458,13 +574,13 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 
 	var payment models.PaymentSummary
 	err := db.QueryRow(`
-		SELECT ps.id, ps.project_id, ps.status, ps.amount, ps.payment_date
+		SELECT ps.id, ps.project_id, ps.status, ps.amount, ps.currency, ps.payment_date::text
 		FROM payment_summaries ps
-		WHERE ps.id = $1
-	`, paymentID).Scan(&payment.ID, &payment.ProjectID, &payment.Status, &payment.Amount, &payment.PaymentDate)
+		WHERE ps.id = $1 AND ps.deleted_at IS NULL
+	`, paymentID).Scan(&payment.ID, &payment.ProjectID, &payment.Status, &payment.Amount, &payment.Currency, &payment.PaymentDate)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Payment summary not found")
+		respondWithAPIError(w, apierror.ErrPaymentNotFound)
 		return
 	}
 	if err != nil {
@@ -475,8 +591,9 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 	var hasAccess bool
 	err = db.QueryRow(`
     SELECT EXISTS(
-        SELECT 1 FROM project_contractors 
-        WHERE project_id = $1 AND contractor_id = $2
+        SELECT 1 FROM contracts
+        WHERE project_id = $1 AND status != 'terminated'
+          AND organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
     )
 `, payment.ProjectID, userCtx.UserID).Scan(&hasAccess)
 
@@ -491,7 +608,7 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if payment.Status != models.PaymentStatusPending {
-		respondWithError(w, http.StatusBadRequest, "Payment is not in pending status")
+		respondWithAPIError(w, apierror.ErrPaymentNotPending)
 		return
 	}
 
@@ -516,6 +633,7 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 				userInfo.Name,
 				participants.ProjectTitle,
 				payment.Amount,
+				payment.Currency,
 				payment.PaymentDate,
 				req.Reason,
 			)
@@ -531,7 +649,7 @@ func DisputePayment(w http.ResponseWriter, r *http.Request) {
 func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -542,12 +660,12 @@ func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 
 	var existing models.PaymentSummary
 	err := db.QueryRow(`
-		SELECT id, project_id, added_by, status, screenshot_url
-		FROM payment_summaries WHERE id = $1
-	`, paymentID).Scan(&existing.ID, &existing.ProjectID, &existing.AddedBy, &existing.Status, &existing.ScreenshotURL)
+		SELECT id, project_id, added_by, status, screenshot_url, currency, version
+		FROM payment_summaries WHERE id = $1 AND deleted_at IS NULL
+	`, paymentID).Scan(&existing.ID, &existing.ProjectID, &existing.AddedBy, &existing.Status, &existing.ScreenshotURL, &existing.Currency, &existing.Version)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Payment summary not found")
+		respondWithAPIError(w, apierror.ErrPaymentNotFound)
 		return
 	}
 	if err != nil {
@@ -560,25 +678,30 @@ func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if versionMismatch(r, existing.Version) {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
+
 	if existing.Status != models.PaymentStatusPending {
-		respondWithError(w, http.StatusBadRequest, "Cannot update payment that is not pending")
+		respondWithAPIError(w, apierror.ErrPaymentNotPending.WithMessage("Cannot update payment that is not pending"))
 		return
 	}
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	amount := r.FormValue("amount")
 	if amount == "" {
-		respondWithError(w, http.StatusBadRequest, "amount is required")
+		respondWithAPIError(w, apierror.Invalid("amount", "amount is required"))
 		return
 	}
 
 	paymentMethod := r.FormValue("payment_method")
 	if paymentMethod == "" {
-		respondWithError(w, http.StatusBadRequest, "payment_method is required")
+		respondWithAPIError(w, apierror.Invalid("payment_method", "payment_method is required"))
 		return
 	}
 
@@ -589,25 +712,36 @@ func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 		paymentMethod != string(models.PaymentMethodCashApp) &&
 		paymentMethod != string(models.PaymentMethodVenmo) &&
 		paymentMethod != string(models.PaymentMethodOther) {
-		respondWithError(w, http.StatusBadRequest, "Invalid payment_method")
+		respondWithAPIError(w, apierror.Invalid("payment_method", "Invalid payment_method"))
 		return
 	}
 
 	paymentDate := r.FormValue("payment_date")
 	if paymentDate == "" {
-		respondWithError(w, http.StatusBadRequest, "payment_date is required")
+		respondWithAPIError(w, apierror.Invalid("payment_date", "payment_date is required"))
+		return
+	}
+	paymentDate, err = calendar.ParseDate(paymentDate)
+	if err != nil {
+		respondWithAPIError(w, apierror.Invalid("payment_date", "payment_date "+err.Error()))
+		return
+	}
+
+	parsedAmount, apiErr := parseAmount("amount", amount)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
-	var amountFloat float64
-	if _, err := strconv.ParseFloat(amount, 64); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid amount format")
+	currency, apiErr := parseCurrencyField("currency", r.FormValue("currency"), existing.Currency)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
-	amountFloat, _ = strconv.ParseFloat(amount, 64)
 
-	if amountFloat <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Amount must be positive")
+	converted, apiErr := convertToProject(db, existing.ProjectID, currency, parsedAmount, paymentDate)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
@@ -618,7 +752,7 @@ func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 
 		ext := strings.ToLower(filepath.Ext(header.Filename))
 		if !allowedExtensions[ext] {
-			respondWithError(w, http.StatusBadRequest, "Invalid file type. Allowed: jpg, jpeg, png")
+			respondWithAPIError(w, apierror.ErrUnsupportedImage.WithMessage("Invalid file type. Allowed: jpg, jpeg, png"))
 			return
 		}
 
@@ -633,25 +767,34 @@ func UpdatePaymentSummary(w http.ResponseWriter, r *http.Request) {
 
 	notes := r.FormValue("notes")
 
-	_, err = db.Exec(`
+	var version int
+	err = db.QueryRow(`
 		UPDATE payment_summaries 
 		SET amount = $1, payment_method = $2, payment_date = $3, 
-		    screenshot_url = $4, notes = $5
-		WHERE id = $6
-	`, amountFloat, paymentMethod, paymentDate, screenshotURL, nilIfEmpty(notes), paymentID)
+		    screenshot_url = $4, notes = $5,
+		    currency = $8, exchange_rate = $9, converted_amount = $10
+		WHERE id = $6 AND deleted_at IS NULL AND ($7::int IS NULL OR version = $7)
+		RETURNING version
+	`, parsedAmount, paymentMethod, paymentDate, screenshotURL, nilIfEmpty(notes), paymentID, ifMatchVersion(r),
+		converted.Currency, converted.Rate, converted.Converted).Scan(&version)
 
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to update payment summary")
+		respondWithDBError(w, err, "Failed to update payment summary")
 		return
 	}
 
+	setVersionETag(w, version)
 	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment summary updated successfully"})
 }
 
 func DeletePaymentSummary(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -663,11 +806,11 @@ func DeletePaymentSummary(w http.ResponseWriter, r *http.Request) {
 	var payment models.PaymentSummary
 	err := db.QueryRow(`
 		SELECT id, project_id, added_by, status
-		FROM payment_summaries WHERE id = $1
+		FROM payment_summaries WHERE id = $1 AND deleted_at IS NULL
 	`, paymentID).Scan(&payment.ID, &payment.ProjectID, &payment.AddedBy, &payment.Status)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Payment summary not found")
+		respondWithAPIError(w, apierror.ErrPaymentNotFound)
 		return
 	}
 	if err != nil {
@@ -676,7 +819,11 @@ func DeletePaymentSummary(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var ownerID sql.NullString
-	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", payment.ProjectID).Scan(&ownerID)
+	err = db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", payment.ProjectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrPaymentNotFound)
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to verify project ownership")
 		return
@@ -695,7 +842,10 @@ func DeletePaymentSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = db.Exec("DELETE FROM payment_summaries WHERE id = $1", paymentID)
+	_, err = db.Exec(`
+		UPDATE payment_summaries SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, paymentID, userCtx.UserID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to delete payment summary")
 		return
@@ -707,7 +857,7 @@ func DeletePaymentSummary(w http.ResponseWriter, r *http.Request) {
 func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -718,11 +868,13 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 
 	var ownerID string
 	var projectName string
-	err := db.QueryRow("SELECT owner_id, title FROM projects WHERE id = $1", projectID).
-		Scan(&ownerID, &projectName)
+	var projectCurrency money.Currency
+	var timeZone calendar.Zone
+	err := db.QueryRow("SELECT owner_id, title, currency, time_zone FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).
+		Scan(&ownerID, &projectName, &projectCurrency, &timeZone)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -730,7 +882,7 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -738,7 +890,8 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -748,14 +901,14 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
 	contractFilter := r.URL.Query().Get("contract_id")
 
 	query := `
-		SELECT ps.id, ps.amount, ps.payment_method, ps.payment_date,
+		SELECT ps.id, ps.amount, ps.currency, ps.converted_amount, ps.payment_method, ps.payment_date::text,
 		       ps.notes, ps.added_by, ps.status,
 		       ps.confirmed_by, ps.confirmed_at, ps.created_at,
 		       u.name as added_by_name,
@@ -763,7 +916,7 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		FROM payment_summaries ps
 		JOIN users u ON ps.added_by = u.id
 		LEFT JOIN users u2 ON ps.confirmed_by = u2.id
-		WHERE ps.project_id = $1
+		WHERE ps.project_id = $1 AND ps.deleted_at IS NULL
 	`
 
 	args := []interface{}{projectID}
@@ -816,7 +969,7 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 
-	headers := []string{"Payment Date", "Amount", "Payment Method", "Status", "Added By", "Confirmed By", "Confirmed Date", "Notes"}
+	headers := []string{"Payment Date", "Amount", "Amount (" + string(projectCurrency) + ")", "Payment Method", "Status", "Added By", "Confirmed By", "Confirmed Date", "Notes"}
 	for i, header := range headers {
 		cell := fmt.Sprintf("%c1", 'A'+i)
 		f.SetCellValue(sheetName, cell, header)
@@ -824,35 +977,38 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 	}
 
 	f.SetColWidth(sheetName, "A", "A", 14)
-	f.SetColWidth(sheetName, "B", "B", 12)
-	f.SetColWidth(sheetName, "C", "C", 18)
-	f.SetColWidth(sheetName, "D", "D", 12)
-	f.SetColWidth(sheetName, "E", "E", 20)
+	f.SetColWidth(sheetName, "B", "B", 14)
+	f.SetColWidth(sheetName, "C", "C", 16)
+	f.SetColWidth(sheetName, "D", "D", 18)
+	f.SetColWidth(sheetName, "E", "E", 12)
 	f.SetColWidth(sheetName, "F", "F", 20)
-	f.SetColWidth(sheetName, "G", "G", 14)
-	f.SetColWidth(sheetName, "H", "H", 30)
+	f.SetColWidth(sheetName, "G", "G", 20)
+	f.SetColWidth(sheetName, "H", "H", 14)
+	f.SetColWidth(sheetName, "I", "I", 30)
+
+	styles := newCurrencyStyles(f)
 
 	rowIndex := 2
-	totalConfirmed := 0.0
-	totalPending := 0.0
+	var totalConfirmed, totalPending money.Amount
 
 	for rows.Next() {
 		var id, addedBy, status, addedByName string
-		var amount float64
+		var amount, converted money.Amount
+		var currency money.Currency
 		var paymentMethod, paymentDate string
 		var notes, confirmedBy, confirmedByName sql.NullString
 		var confirmedAt, createdAt sql.NullTime
 
-		err := rows.Scan(&id, &amount, &paymentMethod, &paymentDate, &notes, &addedBy,
+		err := rows.Scan(&id, &amount, &currency, &converted, &paymentMethod, &paymentDate, &notes, &addedBy,
 			&status, &confirmedBy, &confirmedAt, &createdAt, &addedByName, &confirmedByName)
 		if err != nil {
 			continue
 		}
 
 		if status == string(models.PaymentStatusConfirmed) {
-			totalConfirmed += amount
+			totalConfirmed += converted
 		} else if status == string(models.PaymentStatusPending) {
-			totalPending += amount
+			totalPending += converted
 		}
 
 		statusLabel := getStatusLabel(status)
@@ -862,7 +1018,7 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		}
 		confirmedDateValue := ""
 		if confirmedAt.Valid {
-			confirmedDateValue = confirmedAt.Time.Format("2006-01-02")
+			confirmedDateValue = timeZone.Today(confirmedAt.Time)
 		}
 		notesValue := ""
 		if notes.Valid {
@@ -870,18 +1026,25 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 		}
 
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", rowIndex), paymentDate)
-		f.SetCellValue(sheetName, fmt.Sprintf("B%d", rowIndex), amount)
-		f.SetCellValue(sheetName, fmt.Sprintf("C%d", rowIndex), paymentMethod)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", rowIndex), statusLabel)
-		f.SetCellValue(sheetName, fmt.Sprintf("E%d", rowIndex), addedByName)
-		f.SetCellValue(sheetName, fmt.Sprintf("F%d", rowIndex), confirmedByValue)
-		f.SetCellValue(sheetName, fmt.Sprintf("G%d", rowIndex), confirmedDateValue)
-		f.SetCellValue(sheetName, fmt.Sprintf("H%d", rowIndex), notesValue)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", rowIndex), amount.Float64())
+		f.SetCellStyle(sheetName, fmt.Sprintf("B%d", rowIndex), fmt.Sprintf("B%d", rowIndex), styles.get(currency))
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", rowIndex), converted.Float64())
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", rowIndex), paymentMethod)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", rowIndex), statusLabel)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", rowIndex), addedByName)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", rowIndex), confirmedByValue)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", rowIndex), confirmedDateValue)
+		f.SetCellValue(sheetName, fmt.Sprintf("I%d", rowIndex), notesValue)
 
 		rowIndex++
 	}
 
+	if rowIndex > 2 {
+		f.SetCellStyle(sheetName, "C2", fmt.Sprintf("C%d", rowIndex-1), styles.get(projectCurrency))
+	}
+
 	summaryStartRow := rowIndex + 2
+	totalsFormat := projectCurrency.ExcelFormat()
 
 	summaryStyle, _ := f.NewStyle(&excelize.Style{
 		Font: &excelize.Font{
@@ -893,17 +1056,18 @@ func DownloadPaymentSummaryExcel(w http.ResponseWriter, r *http.Request) {
 			Color:   []string{"#E7E6E6"},
 			Pattern: 1,
 		},
+		CustomNumFmt: &totalsFormat,
 	})
 
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryStartRow), "Total Confirmed:")
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryStartRow), totalConfirmed)
-	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryStartRow), fmt.Sprintf("B%d", summaryStartRow), summaryStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryStartRow), "Total Confirmed:")
+	f.SetCellValue(sheetName, fmt.Sprintf("C%d", summaryStartRow), totalConfirmed.Float64())
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryStartRow), fmt.Sprintf("C%d", summaryStartRow), summaryStyle)
 
-	f.SetCellValue(sheetName, fmt.Sprintf("A%d", summaryStartRow+1), "Total Pending:")
-	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryStartRow+1), totalPending)
-	f.SetCellStyle(sheetName, fmt.Sprintf("A%d", summaryStartRow+1), fmt.Sprintf("B%d", summaryStartRow+1), summaryStyle)
+	f.SetCellValue(sheetName, fmt.Sprintf("B%d", summaryStartRow+1), "Total Pending:")
+	f.SetCellValue(sheetName, fmt.Sprintf("C%d", summaryStartRow+1), totalPending.Float64())
+	f.SetCellStyle(sheetName, fmt.Sprintf("B%d", summaryStartRow+1), fmt.Sprintf("C%d", summaryStartRow+1), summaryStyle)
 
-	timestamp := time.Now().Format("2006-01-02")
+	timestamp := timeZone.Today(time.Now())
 	filename := fmt.Sprintf("payment-summary-%s-%s.xlsx", utils.SanitizeFilename(projectName), timestamp)
 
 	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
@@ -6,15 +6,18 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 )
 
@@ -24,7 +27,7 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
 		log.Println("ERROR: User not found in context")
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 	log.Printf("User authenticated: ID=%s, Type=%s", userCtx.UserID, userCtx.UserType)
@@ -46,6 +49,8 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 	title := r.FormValue("title")
 	description := r.FormValue("description")
 	estimatedCostStr := r.FormValue("estimated_cost")
+	currencyStr := r.FormValue("currency")
+	timeZoneStr := r.FormValue("time_zone")
 	address := r.FormValue("address")
 	status := r.FormValue("status")
 
@@ -54,7 +59,7 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 
 	if title == "" {
 		log.Println("ERROR: Title is required but empty")
-		respondWithError(w, http.StatusBadRequest, "Title is required")
+		respondWithAPIError(w, apierror.Invalid("title", "Title is required"))
 		return
 	}
 
@@ -63,25 +68,52 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Status not provided, defaulting to: %s", status)
 	}
 
-	var estimatedCost float64
+	var estimatedCost money.Amount
 	if estimatedCostStr != "" {
-		estimatedCost, err = strconv.ParseFloat(estimatedCostStr, 64)
+		estimatedCost, err = money.Parse(estimatedCostStr)
 		if err != nil {
 			log.Printf("ERROR: Invalid estimated_cost value: %s, error: %v", estimatedCostStr, err)
-			respondWithError(w, http.StatusBadRequest, "Invalid estimated cost")
+			respondWithAPIError(w, apierror.Invalid("estimated_cost", "estimated_cost "+err.Error()))
 			return
 		}
-		log.Printf("Estimated cost parsed: %.2f", estimatedCost)
+		log.Printf("Estimated cost parsed: %s", estimatedCost)
+	}
+
+	currency := money.DefaultCurrency
+	if currencyStr != "" {
+		currency, err = money.ParseCurrency(currencyStr)
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("currency", "currency "+err.Error()))
+			return
+		}
+	}
+	if err := currency.Check(estimatedCost); err != nil {
+		respondWithAPIError(w, apierror.Invalid("estimated_cost", "estimated_cost "+err.Error()))
+		return
 	}
 
 	db := database.GetDB()
+
+	// Projects without a zone of their own are bucketed in the owner's.
+	var timeZone calendar.Zone
+	if timeZoneStr != "" {
+		timeZone, err = calendar.ParseZone(timeZoneStr)
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("time_zone", "time_zone "+err.Error()))
+			return
+		}
+	} else if timeZone, err = userTimeZone(db, userCtx.UserID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to create project")
+		return
+	}
+
 	projectID := uuid.New().String()
 	log.Printf("Generated new project ID: %s", projectID)
 
 	query := `
-		INSERT INTO projects (id, owner_id, title, description, estimated_cost, address, status)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING id, owner_id, title, description, estimated_cost, address, status, created_at, updated_at
+		INSERT INTO projects (id, owner_id, title, description, estimated_cost, currency, time_zone, address, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, owner_id, title, description, estimated_cost, currency, time_zone, address, status, created_at, updated_at
 	`
 	log.Println("Executing INSERT query...")
 
@@ -93,6 +125,8 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 		title,
 		description,
 		estimatedCost,
+		currency,
+		timeZone,
 		address,
 		status,
 	).Scan(
@@ -101,6 +135,8 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 		&project.Title,
 		&project.Description,
 		&project.EstimatedCost,
+		&project.Currency,
+		&project.TimeZone,
 		&project.Address,
 		&project.Status,
 		&project.CreatedAt,
@@ -171,17 +207,34 @@ func CreateProject(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+var projectListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"created_at":     {Column: "p.created_at", Type: "timestamptz"},
+		"title":          {Column: "p.title", Type: "text"},
+		"estimated_cost": {Column: "COALESCE(p.estimated_cost, 0)", Type: "numeric"},
+	},
+	DefaultSort: "-created_at",
+	IDColumn:    "p.id",
+	DateColumn:  "p.created_at",
+	Filters: map[string]string{
+		"status": "p.status",
+	},
+}
+
 func ListProjects(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	db := database.GetDB()
+	params, err := pagination.Parse(r, projectListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
 
-	var rows *sql.Rows
-	var err error
+	db := database.GetDB()
 
 	type ProjectWithContractor struct {
 		models.Project
@@ -189,84 +242,87 @@ func ListProjects(w http.ResponseWriter, r *http.Request) {
 		ContractorCount int     `json:"contractor_count"`
 	}
 
-	var projects []ProjectWithContractor
+	q := &pagination.Query{}
+	q.Where("p.deleted_at IS NULL")
+	from := "FROM projects p"
+	contractorName := "NULL"
 
 	switch userCtx.UserType {
 	case string(models.UserTypeHouseOwner):
-		query := `
-			SELECT p.id, p.owner_id, p.title, p.description, 
-			       p.estimated_cost, p.address, p.status, p.created_at, p.updated_at,
-			       (SELECT u.name FROM project_contractors pc 
-			        JOIN users u ON pc.contractor_id = u.id 
-			        WHERE pc.project_id = p.id 
-			        ORDER BY pc.assigned_at ASC LIMIT 1) as contractor_name,
-			       (SELECT COUNT(*) FROM project_contractors pc 
-			        WHERE pc.project_id = p.id) as contractor_count
-			FROM projects p
-			WHERE p.owner_id = $1
-			ORDER BY p.created_at DESC
-		`
-		rows, err = db.Query(query, userCtx.UserID)
+		userArg := q.Arg(userCtx.UserID)
+		q.Where("(p.owner_id = " + userArg + " OR EXISTS(SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = " + userArg + "))")
+		contractorName = `(SELECT u.name FROM contracts ac
+		        JOIN users u ON ac.contractor_id = u.id
+		        WHERE ac.project_id = p.id AND ac.status != 'terminated'
+		        ORDER BY ac.created_at ASC LIMIT 1)`
 
 	case string(models.UserTypeContractor):
-		query := `
-			SELECT p.id, p.owner_id, p.title, p.description, 
-			       p.estimated_cost, p.address, p.status, p.created_at, p.updated_at,
-			       NULL as contractor_name,
-			       (SELECT COUNT(*) FROM project_contractors pc 
-			        WHERE pc.project_id = p.id) as contractor_count
-			FROM projects p
-			JOIN project_contractors pc ON p.id = pc.project_id
-			WHERE pc.contractor_id = $1
-			ORDER BY p.created_at DESC
-		`
-		rows, err = db.Query(query, userCtx.UserID)
+		from += " JOIN contracts c ON p.id = c.project_id"
+		q.Where("c.organization_id = " + q.Arg(userCtx.OrganizationID))
+		q.Where("c.status != 'terminated'")
 
 	case string(models.UserTypeEmployee):
-		query := `
-			SELECT p.id, p.owner_id, p.title, p.description, 
-			       p.estimated_cost, p.address, p.status, p.created_at, p.updated_at,
-			       NULL as contractor_name,
-			       (SELECT COUNT(*) FROM project_contractors pc 
-			        WHERE pc.project_id = p.id) as contractor_count
-			FROM projects p
-			JOIN employee_projects ep ON p.id = ep.project_id
-			WHERE ep.employee_id = $1
-			ORDER BY p.created_at DESC
-		`
-		rows, err = db.Query(query, userCtx.UserID)
+		from += " JOIN employee_projects ep ON p.id = ep.project_id"
+		q.Where("ep.employee_id = " + q.Arg(userCtx.UserID))
 
 	default:
-		respondWithError(w, http.StatusBadRequest, "Invalid user type")
+		respondWithAPIError(w, apierror.Invalid("user_type", "Invalid user type"))
 		return
 	}
 
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
 	if err != nil {
+		log.Printf("ERROR ListProjects: Failed to count projects: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch projects")
+		return
+	}
+
+	query, args := params.Select(`
+		p.id, p.owner_id, p.title, p.description,
+		p.estimated_cost, p.currency, p.time_zone, p.address, p.status, p.created_at, p.updated_at, p.version,
+		`+contractorName+` as contractor_name,
+		(SELECT COUNT(*) FROM contracts ac
+		 WHERE ac.project_id = p.id AND ac.status != 'terminated') as contractor_count,
+		`+params.CursorColumn(), from, q)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR ListProjects: Failed to fetch projects: %v", err)
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch projects")
 		return
 	}
 	defer rows.Close()
 
+	var projects []ProjectWithContractor
+	var cursors []string
 	for rows.Next() {
 		var project ProjectWithContractor
+		var cursor string
 		err := rows.Scan(
 			&project.ID,
 			&project.OwnerID,
 			&project.Title,
 			&project.Description,
 			&project.EstimatedCost,
+			&project.Currency,
+			&project.TimeZone,
 			&project.Address,
 			&project.Status,
 			&project.CreatedAt,
 			&project.UpdatedAt,
+			&project.Version,
 			&project.ContractorName,
 			&project.ContractorCount,
+			&cursor,
 		)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Failed to scan project")
 			return
 		}
 		projects = append(projects, project)
+		cursors = append(cursors, cursor)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -274,17 +330,13 @@ func ListProjects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if projects == nil {
-		projects = []ProjectWithContractor{}
-	}
-
-	respondWithJSON(w, http.StatusOK, projects)
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, projects, cursors, total))
 }
 
 func GetProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -295,11 +347,11 @@ func GetProject(w http.ResponseWriter, r *http.Request) {
 
 	query := `
 		SELECT p.id, p.owner_id, p.title, p.description, 
-		       p.estimated_cost, p.address, p.status, p.created_at, p.updated_at,
+		       p.estimated_cost, p.currency, p.time_zone, p.address, p.status, p.created_at, p.updated_at, p.version,
 		       o.name as owner_name, o.email as owner_email
 		FROM projects p
 		LEFT JOIN users o ON p.owner_id = o.id
-		WHERE p.id = $1
+		WHERE p.id = $1 AND p.deleted_at IS NULL
 	`
 
 	type ContractorInfo struct {
@@ -321,16 +373,19 @@ func GetProject(w http.ResponseWriter, r *http.Request) {
 		&project.Title,
 		&project.Description,
 		&project.EstimatedCost,
+		&project.Currency,
+		&project.TimeZone,
 		&project.Address,
 		&project.Status,
 		&project.CreatedAt,
 		&project.UpdatedAt,
+		&project.Version,
 		&project.OwnerName,
 		&project.OwnerEmail,
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
@@ -343,17 +398,12 @@ func GetProject(w http.ResponseWriter, r *http.Request) {
 
 	switch userCtx.UserType {
 	case string(models.UserTypeHouseOwner):
-		hasAccess = project.OwnerID == userCtx.UserID
+		access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+		hasAccess = err == nil && access.CanView()
 
 	case string(models.UserTypeContractor):
-		var isInProjectContractors bool
-		err = db.QueryRow(`
-			SELECT EXISTS(
-				SELECT 1 FROM project_contractors 
-				WHERE project_id = $1 AND contractor_id = $2
-			)
-		`, projectID, userCtx.UserID).Scan(&isInProjectContractors)
-		if err == nil && isInProjectContractors {
+		isMember, err := contractorHasProjectAccess(db, projectID, userCtx)
+		if err == nil && isMember {
 			hasAccess = true
 		}
 
@@ -375,11 +425,11 @@ func GetProject(w http.ResponseWriter, r *http.Request) {
 	}
 
 	contractorsQuery := `
-		SELECT pc.contractor_id, u.name, u.email
-		FROM project_contractors pc
-		JOIN users u ON pc.contractor_id = u.id
-		WHERE pc.project_id = $1
-		ORDER BY pc.assigned_at DESC
+		SELECT c.contractor_id, u.name, u.email
+		FROM contracts c
+		JOIN users u ON c.contractor_id = u.id
+		WHERE c.project_id = $1 AND c.status != 'terminated'
+		ORDER BY c.created_at DESC
 	`
 
 	rows, err := db.Query(contractorsQuery, projectID)
@@ -415,13 +465,14 @@ func GetProject(w http.ResponseWriter, r *http.Request) {
 
 	project.Contractors = contractors
 
+	setVersionETag(w, project.Version)
 	respondWithJSON(w, http.StatusOK, project)
 }
 
 func UpdateProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -431,10 +482,12 @@ func UpdateProject(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	var version int
+	var currentCurrency money.Currency
+	err := db.QueryRow("SELECT owner_id, version, currency FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID, &version, &currentCurrency)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
@@ -448,27 +501,74 @@ func UpdateProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if versionMismatch(r, version) {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
+
 	var req models.UpdateProjectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if req.Title != nil && *req.Title == "" {
-		respondWithError(w, http.StatusBadRequest, "Title cannot be empty")
+		respondWithAPIError(w, apierror.Invalid("title", "Title cannot be empty"))
 		return
 	}
 
 	if req.Description != nil && *req.Description == "" {
-		respondWithError(w, http.StatusBadRequest, "Description cannot be empty")
+		respondWithAPIError(w, apierror.Invalid("description", "Description cannot be empty"))
 		return
 	}
 
 	if req.EstimatedCost != nil && *req.EstimatedCost <= 0 {
-		respondWithError(w, http.StatusBadRequest, "Estimated cost must be a positive number")
+		respondWithAPIError(w, apierror.Invalid("estimated_cost", "Estimated cost must be a positive number"))
 		return
 	}
 
+	currency := currentCurrency
+	if req.Currency != nil {
+		currency, err = money.ParseCurrency(string(*req.Currency))
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("currency", "currency "+err.Error()))
+			return
+		}
+		*req.Currency = currency
+	}
+	if req.TimeZone != nil {
+		timeZone, err := calendar.ParseZone(string(*req.TimeZone))
+		if err != nil {
+			respondWithAPIError(w, apierror.Invalid("time_zone", "time_zone "+err.Error()))
+			return
+		}
+		*req.TimeZone = timeZone
+	}
+	if req.EstimatedCost != nil {
+		if err := currency.Check(*req.EstimatedCost); err != nil {
+			respondWithAPIError(w, apierror.Invalid("estimated_cost", "estimated_cost "+err.Error()))
+			return
+		}
+	}
+
+	// Stored expenses and payments were converted into the current currency,
+	// so it can only change while there are none, trashed ones included.
+	if currency != currentCurrency {
+		var recorded bool
+		err = db.QueryRow(`
+			SELECT EXISTS(SELECT 1 FROM expenses WHERE project_id = $1)
+			    OR EXISTS(SELECT 1 FROM payment_summaries WHERE project_id = $1)
+		`, projectID).Scan(&recorded)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+			return
+		}
+		if recorded {
+			respondWithAPIError(w, apierror.ErrCurrencyLocked)
+			return
+		}
+	}
+
 	query := `
 		UPDATE projects 
 		SET title = COALESCE($1, title),
@@ -476,9 +576,11 @@ func UpdateProject(w http.ResponseWriter, r *http.Request) {
 		    estimated_cost = COALESCE($3, estimated_cost),
 		    address = COALESCE($4, address),
 		    status = COALESCE($5, status),
+		    currency = COALESCE($8, currency),
+		    time_zone = COALESCE($9, time_zone),
 		    updated_at = NOW()
-		WHERE id = $6
-		RETURNING id, owner_id, title, description, estimated_cost, address, status, created_at, updated_at
+		WHERE id = $6 AND deleted_at IS NULL AND ($7::int IS NULL OR version = $7)
+		RETURNING id, owner_id, title, description, estimated_cost, currency, time_zone, address, status, created_at, updated_at, version
 	`
 
 	var project models.Project
@@ -490,30 +592,42 @@ func UpdateProject(w http.ResponseWriter, r *http.Request) {
 		req.Address,
 		req.Status,
 		projectID,
+		ifMatchVersion(r),
+		req.Currency,
+		req.TimeZone,
 	).Scan(
 		&project.ID,
 		&project.OwnerID,
 		&project.Title,
 		&project.Description,
 		&project.EstimatedCost,
+		&project.Currency,
+		&project.TimeZone,
 		&project.Address,
 		&project.Status,
 		&project.CreatedAt,
 		&project.UpdatedAt,
+		&project.Version,
 	)
 
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrVersionConflict)
+		return
+	}
+
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to update project")
+		respondWithDBError(w, err, "Failed to update project")
 		return
 	}
 
+	setVersionETag(w, project.Version)
 	respondWithJSON(w, http.StatusOK, project)
 }
 
 func DeleteProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -523,10 +637,10 @@ func DeleteProject(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
@@ -540,7 +654,10 @@ func DeleteProject(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = db.Exec("DELETE FROM projects WHERE id = $1", projectID)
+	_, err = db.Exec(`
+		UPDATE projects SET deleted_at = NOW(), deleted_by = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, projectID, userCtx.UserID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to delete project")
 		return
@@ -552,7 +669,7 @@ func DeleteProject(w http.ResponseWriter, r *http.Request) {
 func AssignContractor(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -562,9 +679,9 @@ func AssignContractor(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -581,12 +698,12 @@ func AssignContractor(w http.ResponseWriter, r *http.Request) {
 		ContractorIDs []string `json:"contractor_ids"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
 		return
 	}
 
 	if len(req.ContractorIDs) == 0 {
-		respondWithError(w, http.StatusBadRequest, "contractor_ids is required and cannot be empty")
+		respondWithAPIError(w, apierror.Invalid("contractor_ids", "contractor_ids is required and cannot be empty"))
 		return
 	}
 
@@ -621,33 +738,14 @@ func AssignContractor(w http.ResponseWriter, r *http.Request) {
 			continue
 		}
 
-		pcQuery := `
-			INSERT INTO project_contractors (project_id, contractor_id)
-			VALUES ($1, $2)
-			ON CONFLICT (project_id, contractor_id) DO NOTHING
-		`
-		result, err := tx.Exec(pcQuery, projectID, contractorID)
+		assigned, err := assignContractorToProject(tx, projectID, contractorID, ownerID)
 		if err != nil {
 			tx.Rollback()
 			failedContractors = append(failedContractors, contractorID)
 			continue
 		}
 
-		rowsAffected, _ := result.RowsAffected()
-		if rowsAffected > 0 {
-			contractID := uuid.New().String()
-			contractQuery := `
-				INSERT INTO contracts (id, project_id, contractor_id, owner_id, status, start_date)
-				VALUES ($1, $2, $3, $4, $5, $6)
-				ON CONFLICT (project_id, contractor_id) DO NOTHING
-			`
-			_, err = tx.Exec(contractQuery, contractID, projectID, contractorID, ownerID, "active", time.Now())
-			if err != nil {
-				tx.Rollback()
-				failedContractors = append(failedContractors, contractorID)
-				continue
-			}
-
+		if assigned {
 			if err = tx.Commit(); err != nil {
 				failedContractors = append(failedContractors, contractorID)
 				continue
@@ -676,10 +774,34 @@ func AssignContractor(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, response)
 }
 
+func assignContractorToProject(tx *sql.Tx, projectID, contractorID, ownerID string) (bool, error) {
+	contractID := uuid.New().String()
+	// Contracts are held by the contractor's organization, so a second staff
+	// member of the same company joins the existing contract, and a company
+	// that was removed earlier gets its terminated contract reactivated. No
+	// row is written when the company already holds a live contract.
+	contractQuery := `
+		INSERT INTO contracts (id, project_id, contractor_id, organization_id, owner_id, status, start_date)
+		SELECT $1, $2, $3, m.organization_id, $4, $5, $6
+		FROM organization_members m
+		WHERE m.user_id = $3
+		ON CONFLICT (project_id, organization_id) DO UPDATE
+		SET status = EXCLUDED.status, contractor_id = EXCLUDED.contractor_id, start_date = EXCLUDED.start_date, end_date = NULL
+		WHERE contracts.status = 'terminated'
+	`
+	result, err := tx.Exec(contractQuery, contractID, projectID, contractorID, ownerID, "active", time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
 func RemoveContractor(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -690,9 +812,9 @@ func RemoveContractor(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -712,8 +834,15 @@ func RemoveContractor(w http.ResponseWriter, r *http.Request) {
 	}
 	defer tx.Rollback()
 
-	query := `DELETE FROM project_contractors WHERE project_id = $1 AND contractor_id = $2`
-	result, err := tx.Exec(query, projectID, contractorID)
+	// The contract belongs to the contractor's organization, so removing one
+	// of its members removes the whole company from the project.
+	query := `
+		UPDATE contracts
+		SET status = $1, end_date = $2, updated_at = $3
+		WHERE project_id = $4 AND status != 'terminated'
+		  AND (contractor_id = $5 OR organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $5))
+	`
+	result, err := tx.Exec(query, "terminated", time.Now(), time.Now(), projectID, contractorID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to remove contractor")
 		return
@@ -730,17 +859,6 @@ func RemoveContractor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	contractQuery := `
-		UPDATE contracts
-		SET status = $1, end_date = $2, updated_at = $3
-		WHERE project_id = $4 AND contractor_id = $5
-	`
-	_, err = tx.Exec(contractQuery, "terminated", time.Now(), time.Now(), projectID, contractorID)
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to terminate contract")
-		return
-	}
-
 	if err = tx.Commit(); err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
 		return
@@ -752,7 +870,7 @@ func RemoveContractor(w http.ResponseWriter, r *http.Request) {
 func ListProjectContractors(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -761,10 +879,9 @@ func ListProjectContractors(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 
-	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -773,21 +890,15 @@ func ListProjectContractors(w http.ResponseWriter, r *http.Request) {
 	}
 
 	hasAccess := false
-	if ownerID == userCtx.UserID {
+	if access.CanView() {
 		hasAccess = true
 	} else if userCtx.UserType == string(models.UserTypeContractor) {
-		var isContractor bool
-		db.QueryRow(`
-			SELECT EXISTS(
-				SELECT 1 FROM contracts
-				WHERE project_id = $1 AND contractor_id = $2
-			)
-		`, projectID, userCtx.UserID).Scan(&isContractor)
-		hasAccess = isContractor
+		isMember, _ := contractorHasProjectAccess(db, projectID, userCtx)
+		hasAccess = isMember
 	}
 
 	if !hasAccess {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -795,7 +906,7 @@ func ListProjectContractors(w http.ResponseWriter, r *http.Request) {
 		SELECT c.id, c.contractor_id, u.name, u.email, c.created_at
 		FROM contracts c
 		JOIN users u ON c.contractor_id = u.id
-		WHERE c.project_id = $1
+		WHERE c.project_id = $1 AND c.status != 'terminated'
 		ORDER BY c.created_at DESC
 	`
 
@@ -839,3 +950,10 @@ func ListProjectContractors(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, contractors)
 }
+
+// projectTimeZone returns the zone a project's days and weeks are counted in.
+func projectTimeZone(q queryRower, projectID string) (calendar.Zone, error) {
+	var zone calendar.Zone
+	err := q.QueryRow("SELECT time_zone FROM projects WHERE id = $1", projectID).Scan(&zone)
+	return zone, err
+}
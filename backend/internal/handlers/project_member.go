@@ -0,0 +1,646 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+)
+
+const projectMemberInvitationColumns = `id, project_id, invited_by, email, role, can_approve_estimates, can_confirm_payments,
+	status, expires_at, accepted_by, accepted_at, created_at, updated_at`
+
+func scanProjectMemberInvitation(row rowScanner) (*models.ProjectMemberInvitation, error) {
+	var inv models.ProjectMemberInvitation
+	err := row.Scan(&inv.ID, &inv.ProjectID, &inv.InvitedBy, &inv.Email, &inv.Role, &inv.CanApproveEstimates,
+		&inv.CanConfirmPayments, &inv.Status, &inv.ExpiresAt, &inv.AcceptedBy, &inv.AcceptedAt, &inv.CreatedAt, &inv.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// ownerAccess describes what a house owner can do on a project, either as its
+// primary owner or through a co-owner or viewer membership.
+type ownerAccess struct {
+	IsPrimary           bool
+	IsMember            bool
+	CanApproveEstimates bool
+	CanConfirmPayments  bool
+}
+
+func (a ownerAccess) CanView() bool {
+	return a.IsPrimary || a.IsMember
+}
+
+// projectOwnerAccess returns sql.ErrNoRows when the project does not exist.
+func projectOwnerAccess(q queryRower, projectID, userID string) (ownerAccess, error) {
+	var access ownerAccess
+	err := q.QueryRow(`
+		SELECT p.owner_id = $2,
+		       pm.id IS NOT NULL,
+		       COALESCE(pm.role = 'co_owner' AND pm.can_approve_estimates, false),
+		       COALESCE(pm.role = 'co_owner' AND pm.can_confirm_payments, false)
+		FROM projects p
+		LEFT JOIN project_members pm ON pm.project_id = p.id AND pm.user_id = $2
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`, projectID, userID).Scan(&access.IsPrimary, &access.IsMember, &access.CanApproveEstimates, &access.CanConfirmPayments)
+	if err != nil {
+		return access, err
+	}
+
+	if access.IsPrimary {
+		access.CanApproveEstimates = true
+		access.CanConfirmPayments = true
+	}
+	return access, nil
+}
+
+// isProjectMember reports whether the user can see the project as a co-owner
+// or viewer.
+func isProjectMember(q queryRower, projectID, userID string) bool {
+	var isMember bool
+	q.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM project_members pm
+			JOIN projects p ON p.id = pm.project_id
+			WHERE pm.project_id = $1 AND pm.user_id = $2 AND p.deleted_at IS NULL
+		)
+	`, projectID, userID).Scan(&isMember)
+	return isMember
+}
+
+func projectMemberRoleLabel(role models.ProjectMemberRole) string {
+	if role == models.ProjectMemberRoleCoOwner {
+		return "co-owner"
+	}
+	return "viewer"
+}
+
+func InviteProjectMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	var req models.InviteProjectMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if !emailRegex.MatchString(email) {
+		respondWithAPIError(w, apierror.Invalid("email", "Invalid email format"))
+		return
+	}
+
+	if req.Role != models.ProjectMemberRoleCoOwner && req.Role != models.ProjectMemberRoleViewer {
+		respondWithAPIError(w, apierror.Invalid("role", "Role must be co_owner or viewer"))
+		return
+	}
+
+	if req.Role == models.ProjectMemberRoleViewer && (req.CanApproveEstimates || req.CanConfirmPayments) {
+		respondWithError(w, http.StatusBadRequest, "Viewers cannot approve estimates or confirm payments")
+		return
+	}
+
+	db := database.GetDB()
+
+	var ownerID, projectTitle, ownerEmail string
+	err := db.QueryRow(`
+		SELECT p.owner_id, p.title, u.email FROM projects p JOIN users u ON p.owner_id = u.id WHERE p.id = $1 AND p.deleted_at IS NULL
+	`, projectID).Scan(&ownerID, &projectTitle, &ownerEmail)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can invite members")
+		return
+	}
+
+	if strings.EqualFold(ownerEmail, email) {
+		respondWithError(w, http.StatusBadRequest, "You already own this project")
+		return
+	}
+
+	var existingUserType string
+	var alreadyMember bool
+	err = db.QueryRow(`
+		SELECT u.user_type, EXISTS(SELECT 1 FROM project_members WHERE project_id = $2 AND user_id = u.id)
+		FROM users u WHERE LOWER(u.email) = $1
+	`, email, projectID).Scan(&existingUserType, &alreadyMember)
+	if err != nil && err != sql.ErrNoRows {
+		respondWithError(w, http.StatusInternalServerError, "Failed to look up user")
+		return
+	}
+
+	if err == nil {
+		if existingUserType != string(models.UserTypeHouseOwner) {
+			respondWithError(w, http.StatusBadRequest, "This email belongs to an account that is not a house owner")
+			return
+		}
+		if alreadyMember {
+			respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeAlreadyMember, "This person is already a member of the project"))
+			return
+		}
+	}
+
+	token, err := utils.GenerateInvitationToken()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate invitation token")
+		return
+	}
+
+	invitation, err := scanProjectMemberInvitation(db.QueryRow(`
+		INSERT INTO project_member_invitations (project_id, invited_by, email, role, can_approve_estimates,
+		                                        can_confirm_payments, token_hash, status, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING `+projectMemberInvitationColumns,
+		projectID, userCtx.UserID, email, req.Role, req.CanApproveEstimates, req.CanConfirmPayments, utils.HashLoginCode(token),
+		models.InvitationStatusPending, time.Now().UTC().Add(invitationExpiry)))
+	if err != nil {
+		if apierror.IsUniqueViolation(err, "idx_project_member_invitations_pending") {
+			respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeInvitationPending, "An invitation is already pending for this email"))
+			return
+		}
+		log.Printf("ERROR InviteProjectMember: Failed to create invitation: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to create invitation")
+		return
+	}
+
+	ownerInfo, err := getUserInfo(db, userCtx.UserID)
+	if err == nil {
+		if err := utils.SendProjectMemberInvitationEmail(email, ownerInfo.Name, projectTitle, projectMemberRoleLabel(req.Role), token); err != nil {
+			log.Printf("Failed to send project member invitation email: %v", err)
+		}
+	}
+
+	respondWithJSON(w, http.StatusCreated, invitation)
+}
+
+func ListProjectMembers(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if !access.CanView() {
+		if userCtx.UserType != string(models.UserTypeContractor) {
+			respondWithAPIError(w, apierror.ErrAccessDenied)
+			return
+		}
+		isMember, err := contractorHasProjectAccess(db, projectID, userCtx)
+		if err != nil || !isMember {
+			respondWithAPIError(w, apierror.ErrAccessDenied)
+			return
+		}
+	}
+
+	rows, err := db.Query(`
+		SELECT pm.id, pm.project_id, pm.user_id, u.name, u.email, pm.role, pm.can_approve_estimates,
+		       pm.can_confirm_payments, pm.added_by, pm.created_at, pm.updated_at
+		FROM project_members pm
+		JOIN users u ON pm.user_id = u.id
+		WHERE pm.project_id = $1
+		ORDER BY pm.created_at ASC
+	`, projectID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch members")
+		return
+	}
+	defer rows.Close()
+
+	members := []models.ProjectMember{}
+	for rows.Next() {
+		var m models.ProjectMember
+		err := rows.Scan(&m.ID, &m.ProjectID, &m.UserID, &m.Name, &m.Email, &m.Role, &m.CanApproveEstimates,
+			&m.CanConfirmPayments, &m.AddedBy, &m.CreatedAt, &m.UpdatedAt)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan member")
+			return
+		}
+		members = append(members, m)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating members")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, members)
+}
+
+func UpdateProjectMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	memberID := vars["memberId"]
+
+	var req models.UpdateProjectMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	db := database.GetDB()
+
+	access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if !access.IsPrimary {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can change member rights")
+		return
+	}
+
+	var m models.ProjectMember
+	err = db.QueryRow(`
+		SELECT role, can_approve_estimates, can_confirm_payments
+		FROM project_members WHERE id = $1 AND project_id = $2
+	`, memberID, projectID).Scan(&m.Role, &m.CanApproveEstimates, &m.CanConfirmPayments)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrMemberNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch member")
+		return
+	}
+
+	if req.Role != nil {
+		if *req.Role != models.ProjectMemberRoleCoOwner && *req.Role != models.ProjectMemberRoleViewer {
+			respondWithAPIError(w, apierror.Invalid("role", "Role must be co_owner or viewer"))
+			return
+		}
+		m.Role = *req.Role
+	}
+	if req.CanApproveEstimates != nil {
+		m.CanApproveEstimates = *req.CanApproveEstimates
+	}
+	if req.CanConfirmPayments != nil {
+		m.CanConfirmPayments = *req.CanConfirmPayments
+	}
+
+	if m.Role == models.ProjectMemberRoleViewer {
+		m.CanApproveEstimates = false
+		m.CanConfirmPayments = false
+	}
+
+	err = db.QueryRow(`
+		UPDATE project_members pm
+		SET role = $1, can_approve_estimates = $2, can_confirm_payments = $3
+		FROM users u
+		WHERE pm.id = $4 AND u.id = pm.user_id
+		RETURNING pm.id, pm.project_id, pm.user_id, u.name, u.email, pm.role, pm.can_approve_estimates,
+		          pm.can_confirm_payments, pm.added_by, pm.created_at, pm.updated_at
+	`, m.Role, m.CanApproveEstimates, m.CanConfirmPayments, memberID).Scan(&m.ID, &m.ProjectID, &m.UserID, &m.Name,
+		&m.Email, &m.Role, &m.CanApproveEstimates, &m.CanConfirmPayments, &m.AddedBy, &m.CreatedAt, &m.UpdatedAt)
+	if err != nil {
+		log.Printf("ERROR UpdateProjectMember: Failed to update member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to update member")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, m)
+}
+
+func RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	memberID := vars["memberId"]
+
+	db := database.GetDB()
+
+	var ownerID, memberUserID string
+	err := db.QueryRow(`
+		SELECT p.owner_id, pm.user_id
+		FROM project_members pm
+		JOIN projects p ON pm.project_id = p.id
+		WHERE pm.id = $1 AND pm.project_id = $2 AND p.deleted_at IS NULL
+	`, memberID, projectID).Scan(&ownerID, &memberUserID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrMemberNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch member")
+		return
+	}
+
+	// Members may always leave a project on their own.
+	if ownerID != userCtx.UserID && memberUserID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can remove members")
+		return
+	}
+
+	if _, err := db.Exec("DELETE FROM project_members WHERE id = $1", memberID); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func ListProjectMemberInvitations(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var ownerID string
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	rows, err := db.Query(`
+		SELECT `+projectMemberInvitationColumns+`
+		FROM project_member_invitations
+		WHERE project_id = $1 AND status = $2
+		ORDER BY created_at DESC
+	`, projectID, models.InvitationStatusPending)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitations")
+		return
+	}
+	defer rows.Close()
+
+	type InvitationResponse struct {
+		models.ProjectMemberInvitation
+		Expired bool `json:"expired"`
+	}
+
+	invitations := []InvitationResponse{}
+	now := time.Now()
+	for rows.Next() {
+		inv, err := scanProjectMemberInvitation(rows)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan invitation")
+			return
+		}
+		invitations = append(invitations, InvitationResponse{*inv, now.After(inv.ExpiresAt)})
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating invitations")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, invitations)
+}
+
+func RevokeProjectMemberInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	invitationID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var ownerID string
+	var status models.InvitationStatus
+	err := db.QueryRow(`
+		SELECT p.owner_id, i.status
+		FROM project_member_invitations i
+		JOIN projects p ON i.project_id = p.id
+		WHERE i.id = $1 AND p.deleted_at IS NULL
+	`, invitationID).Scan(&ownerID, &status)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can revoke invitations")
+		return
+	}
+
+	if status != models.InvitationStatusPending {
+		respondWithError(w, http.StatusBadRequest, "Only pending invitations can be revoked")
+		return
+	}
+
+	_, err = db.Exec(`
+		UPDATE project_member_invitations SET status = $1 WHERE id = $2
+	`, models.InvitationStatusRevoked, invitationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to revoke invitation")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func GetProjectMemberInvitationByToken(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	var email, projectTitle, invitedByName string
+	var role models.ProjectMemberRole
+	var status models.InvitationStatus
+	var expiresAt time.Time
+	err := db.QueryRow(`
+		SELECT i.email, i.role, i.status, i.expires_at, p.title, u.name
+		FROM project_member_invitations i
+		JOIN projects p ON i.project_id = p.id
+		JOIN users u ON i.invited_by = u.id
+		WHERE i.token_hash = $1 AND p.deleted_at IS NULL
+	`, utils.HashLoginCode(token)).Scan(&email, &role, &status, &expiresAt, &projectTitle, &invitedByName)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrInvitationNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if status != models.InvitationStatusPending || time.Now().After(expiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusGone, apierror.CodeInvitationExpired, "This invitation is no longer valid"))
+		return
+	}
+
+	var accountExists bool
+	db.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE LOWER(email) = $1)", email).Scan(&accountExists)
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"email":           email,
+		"role":            role,
+		"project_title":   projectTitle,
+		"invited_by_name": invitedByName,
+		"expires_at":      expiresAt,
+		"account_exists":  accountExists,
+	})
+}
+
+func AcceptProjectMemberInvitation(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeHouseOwner) {
+		respondWithError(w, http.StatusForbidden, "Only house owner accounts can join projects as members")
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Invitation token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	defer tx.Rollback()
+
+	invitation, err := scanProjectMemberInvitation(tx.QueryRow(`
+		SELECT `+projectMemberInvitationColumns+`
+		FROM project_member_invitations
+		WHERE token_hash = $1
+		FOR UPDATE
+	`, utils.HashLoginCode(req.Token)))
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch invitation")
+		return
+	}
+
+	if invitation.Status != models.InvitationStatusPending || time.Now().After(invitation.ExpiresAt) {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidToken, "Invalid or expired invitation token"))
+		return
+	}
+
+	if !strings.EqualFold(invitation.Email, strings.TrimSpace(userCtx.Email)) {
+		respondWithError(w, http.StatusForbidden, "This invitation was sent to a different email address")
+		return
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO project_members (project_id, user_id, role, can_approve_estimates, can_confirm_payments, added_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (project_id, user_id) DO UPDATE SET
+			role = EXCLUDED.role,
+			can_approve_estimates = EXCLUDED.can_approve_estimates,
+			can_confirm_payments = EXCLUDED.can_confirm_payments
+	`, invitation.ProjectID, userCtx.UserID, invitation.Role, invitation.CanApproveEstimates,
+		invitation.CanConfirmPayments, invitation.InvitedBy)
+	if err != nil {
+		log.Printf("ERROR AcceptProjectMemberInvitation: Failed to add member: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	_, err = tx.Exec(`
+		UPDATE project_member_invitations
+		SET status = $1, accepted_by = $2, accepted_at = NOW()
+		WHERE id = $3
+	`, models.InvitationStatusAccepted, userCtx.UserID, invitation.ID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to accept invitation")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	log.Printf("SUCCESS AcceptProjectMemberInvitation: User %s joined project %s as %s", userCtx.UserID, invitation.ProjectID, invitation.Role)
+	respondWithJSON(w, http.StatusOK, map[string]string{
+		"message":    "Invitation accepted successfully",
+		"project_id": invitation.ProjectID,
+	})
+}
@@ -5,14 +5,15 @@ import (
 	"log"
 	"net/http"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 	"github.com/juazsh/managrr/internal/utils"
 )
@@ -32,7 +33,7 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -40,9 +41,9 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 	projectID := vars["id"]
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -62,10 +63,11 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 		SELECT c.id
 		FROM contracts c
 		WHERE c.project_id = $1
-		  AND (c.contractor_id = $2
-		       OR c.contractor_id IN (
-		           SELECT contractor_id FROM employees WHERE user_id = $2
-		       ))
+		  AND c.organization_id IN (
+		           SELECT organization_id FROM organization_members WHERE user_id = $2
+		           UNION
+		           SELECT organization_id FROM employees WHERE user_id = $2 AND is_active = true AND deleted_at IS NULL
+		       )
 		LIMIT 1
 	`
 	err = db.QueryRow(contractQuery, projectID, userCtx.UserID).Scan(&contractID)
@@ -75,6 +77,14 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userCtx.UserType == string(models.UserTypeEmployee) {
+		foreman, err := foremanAccess(db, projectID, userCtx.UserID)
+		if err != nil || !foreman.CanPostUpdates {
+			respondWithError(w, http.StatusForbidden, "Only foremen with permission can post updates")
+			return
+		}
+	}
+
 	if err := r.ParseMultipartForm(maxUpdatePhotoSize * maxPhotosPerUpdate); err != nil {
 		respondWithError(w, http.StatusBadRequest, "Request too large")
 		return
@@ -82,18 +92,18 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 
 	updateType := r.FormValue("update_type")
 	if updateType == "" {
-		respondWithError(w, http.StatusBadRequest, "update_type is required")
+		respondWithAPIError(w, apierror.Invalid("update_type", "update_type is required"))
 		return
 	}
 
 	if updateType != string(models.UpdateTypeDailySummary) && updateType != string(models.UpdateTypeWeeklyPlan) {
-		respondWithError(w, http.StatusBadRequest, "Invalid update_type. Must be 'daily_summary' or 'weekly_plan'")
+		respondWithAPIError(w, apierror.Invalid("update_type", "Invalid update_type. Must be 'daily_summary' or 'weekly_plan'"))
 		return
 	}
 
 	content := r.FormValue("content")
 	if content == "" {
-		respondWithError(w, http.StatusBadRequest, "content is required")
+		respondWithAPIError(w, apierror.Invalid("content", "content is required"))
 		return
 	}
 
@@ -128,13 +138,13 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 		defer file.Close()
 
 		if fileHeader.Size > maxUpdatePhotoSize {
-			respondWithError(w, http.StatusBadRequest, "Photo size exceeds 5MB limit")
+			respondWithAPIError(w, apierror.ErrFileTooLarge.WithMessage("Photo size exceeds 5MB limit"))
 			return
 		}
 
 		ext := strings.ToLower(filepath.Ext(fileHeader.Filename))
 		if !allowedPhotoExtensions[ext] {
-			respondWithError(w, http.StatusBadRequest, "Invalid photo type. Allowed: jpg, jpeg, png")
+			respondWithAPIError(w, apierror.ErrUnsupportedImage.WithMessage("Invalid photo type. Allowed: jpg, jpeg, png"))
 			return
 		}
 
@@ -187,6 +197,18 @@ func CreateProjectUpdate(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+var projectUpdateListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "pu.created_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-created_at",
+	IDColumn:    "pu.id",
+	DateColumn:  "pu.created_at",
+	Filters: map[string]string{
+		"type": "pu.update_type",
+	},
+}
+
 func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 	userCtx := r.Context().Value(middleware.UserContextKey).(*middleware.UserContext)
@@ -195,10 +217,10 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 	projectID := vars["id"]
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -206,7 +228,7 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -214,7 +236,8 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -224,44 +247,48 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
 	contractFilter := r.URL.Query().Get("contract_id")
 
-	query := `
-		SELECT pu.id, pu.project_id, pu.update_type, pu.content, pu.created_by, pu.created_at, u.name
+	updateTypeFilter := r.URL.Query().Get("type")
+	if updateTypeFilter != "" && updateTypeFilter != string(models.UpdateTypeDailySummary) && updateTypeFilter != string(models.UpdateTypeWeeklyPlan) {
+		respondWithAPIError(w, apierror.Invalid("type", "Invalid type filter"))
+		return
+	}
+
+	params, err := pagination.Parse(r, projectUpdateListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
+
+	from := `
 		FROM project_updates pu
 		JOIN users u ON pu.created_by = u.id
-		WHERE pu.project_id = $1
 	`
-
-	args := []interface{}{projectID}
-	argIndex := 2
+	q := &pagination.Query{}
+	q.Where("pu.project_id = " + q.Arg(projectID))
 
 	if isContractor {
-		query += " AND pu.contract_id = $2"
-		args = append(args, contractorContractID)
-		argIndex = 3
+		q.Where("pu.contract_id = " + q.Arg(contractorContractID))
 	} else if contractFilter != "" {
-		query += " AND pu.contract_id = $2"
-		args = append(args, contractFilter)
-		argIndex = 3
+		q.Where("pu.contract_id = " + q.Arg(contractFilter))
 	}
 
-	updateTypeFilter := r.URL.Query().Get("type")
-	if updateTypeFilter != "" {
-		if updateTypeFilter != string(models.UpdateTypeDailySummary) && updateTypeFilter != string(models.UpdateTypeWeeklyPlan) {
-			respondWithError(w, http.StatusBadRequest, "Invalid type filter")
-			return
-		}
-		query += " AND pu.update_type = $" + strconv.Itoa(argIndex)
-		args = append(args, updateTypeFilter)
-		argIndex++
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch updates")
+		return
 	}
 
-	query += " ORDER BY pu.created_at DESC"
+	query, args := params.Select(`
+		pu.id, pu.project_id, pu.update_type, pu.content, pu.created_by, pu.created_at, u.name,
+		`+params.CursorColumn(), from, q)
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -280,12 +307,14 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 		CreatedAt  string                      `json:"created_at"`
 	}
 
-	updates := []UpdateResponse{}
+	var updates []UpdateResponse
+	var cursors []string
 
 	for rows.Next() {
 		var update UpdateResponse
 		var createdByID, createdByName string
 		var createdAt string
+		var cursor string
 
 		err := rows.Scan(
 			&update.ID,
@@ -295,6 +324,7 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 			&createdByID,
 			&createdAt,
 			&createdByName,
+			&cursor,
 		)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Failed to scan update")
@@ -342,6 +372,7 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 		}
 
 		updates = append(updates, update)
+		cursors = append(cursors, cursor)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -349,9 +380,5 @@ func GetProjectUpdates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if updates == nil {
-		updates = []UpdateResponse{}
-	}
-
-	respondWithJSON(w, http.StatusOK, updates)
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, updates, cursors, total))
 }
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"database/sql"
+	"html"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// ts_headline marks matches with these control characters so the text can be
+// HTML-escaped before the real <mark> tags go in.
+const (
+	searchMatchStart = "\x01"
+	searchMatchStop  = "\x02"
+)
+
+// searchSources holds one SELECT per searchable record type. Each joins the
+// caller's scope, which limits owners to their projects and contractors to
+// their own contracts' records, and exposes the same columns so they can be
+// combined with UNION ALL.
+var searchSources = map[models.SearchResultType]string{
+	models.SearchResultExpense: `
+		SELECT 'expense' AS type, e.id, e.project_id, e.contract_id,
+		       CONCAT_WS(' — ', e.vendor, e.description) AS body,
+		       ts_rank(e.search_vector, query.q) AS rank, e.created_at
+		FROM expenses e
+		JOIN scope s ON e.project_id = s.project_id AND (s.contract_id IS NULL OR e.contract_id = s.contract_id)
+		CROSS JOIN query
+		WHERE e.search_vector @@ query.q AND e.deleted_at IS NULL`,
+	models.SearchResultUpdate: `
+		SELECT 'update' AS type, pu.id, pu.project_id, pu.contract_id,
+		       pu.content AS body,
+		       ts_rank(pu.search_vector, query.q) AS rank, pu.created_at
+		FROM project_updates pu
+		JOIN scope s ON pu.project_id = s.project_id AND (s.contract_id IS NULL OR pu.contract_id = s.contract_id)
+		CROSS JOIN query
+		WHERE pu.search_vector @@ query.q`,
+	models.SearchResultPhoto: `
+		SELECT 'photo' AS type, ph.id, ph.project_id, ph.contract_id,
+		       ph.caption AS body,
+		       ts_rank(ph.search_vector, query.q) AS rank, ph.created_at
+		FROM project_photos ph
+		JOIN scope s ON ph.project_id = s.project_id AND (s.contract_id IS NULL OR ph.contract_id = s.contract_id)
+		CROSS JOIN query
+		WHERE ph.search_vector @@ query.q`,
+	models.SearchResultPayment: `
+		SELECT 'payment' AS type, ps.id, ps.project_id, ps.contract_id,
+		       ps.notes AS body,
+		       ts_rank(ps.search_vector, query.q) AS rank, ps.created_at
+		FROM payment_summaries ps
+		JOIN scope s ON ps.project_id = s.project_id AND (s.contract_id IS NULL OR ps.contract_id = s.contract_id)
+		CROSS JOIN query
+		WHERE ps.search_vector @@ query.q AND ps.deleted_at IS NULL`,
+	models.SearchResultEstimate: `
+		SELECT 'estimate' AS type, es.id, c.project_id, es.contract_id,
+		       es.description AS body,
+		       ts_rank(es.search_vector, query.q) AS rank, es.created_at
+		FROM estimates es
+		JOIN contracts c ON es.contract_id = c.id
+		JOIN scope s ON c.project_id = s.project_id AND (s.contract_id IS NULL OR es.contract_id = s.contract_id)
+		CROSS JOIN query
+		WHERE es.search_vector @@ query.q`,
+	models.SearchResultContract: `
+		SELECT 'contract' AS type, c.id, c.project_id, c.id AS contract_id,
+		       c.terms AS body,
+		       ts_rank(c.search_vector, query.q) AS rank, c.created_at
+		FROM contracts c
+		JOIN scope s ON c.project_id = s.project_id AND (s.contract_id IS NULL OR c.id = s.contract_id)
+		CROSS JOIN query
+		WHERE c.search_vector @@ query.q`,
+}
+
+var searchSourceOrder = []models.SearchResultType{
+	models.SearchResultExpense,
+	models.SearchResultUpdate,
+	models.SearchResultPhoto,
+	models.SearchResultPayment,
+	models.SearchResultEstimate,
+	models.SearchResultContract,
+}
+
+// SearchProject searches the records of a single project.
+func SearchProject(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var hasAccess bool
+	switch userCtx.UserType {
+	case string(models.UserTypeHouseOwner):
+		access, err := projectOwnerAccess(db, projectID, userCtx.UserID)
+		if err == sql.ErrNoRows {
+			respondWithAPIError(w, apierror.ErrProjectNotFound)
+			return
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify project")
+			return
+		}
+		hasAccess = access.CanView()
+
+	case string(models.UserTypeContractor):
+		var err error
+		hasAccess, err = contractorHasProjectAccess(db, projectID, userCtx)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify project")
+			return
+		}
+	}
+
+	if !hasAccess {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	runSearch(w, r, db, userCtx, projectID)
+}
+
+// SearchAll searches every project the caller can see.
+func SearchAll(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	runSearch(w, r, database.GetDB(), userCtx, "")
+}
+
+func runSearch(w http.ResponseWriter, r *http.Request, db *sql.DB, userCtx middleware.UserContext, projectID string) {
+	term := strings.TrimSpace(r.URL.Query().Get("q"))
+	if len(term) < 2 {
+		respondWithAPIError(w, apierror.Invalid("q", "q must be at least 2 characters"))
+		return
+	}
+
+	limit := defaultSearchLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			respondWithAPIError(w, apierror.Invalid("limit", "limit must be a positive integer"))
+			return
+		}
+		if parsed > maxSearchLimit {
+			parsed = maxSearchLimit
+		}
+		limit = parsed
+	}
+
+	types := searchSourceOrder
+	if raw := r.URL.Query().Get("types"); raw != "" {
+		types = nil
+		for _, t := range strings.Split(raw, ",") {
+			resultType := models.SearchResultType(strings.TrimSpace(t))
+			if _, ok := searchSources[resultType]; !ok {
+				respondWithError(w, http.StatusBadRequest, "Invalid type: "+string(resultType))
+				return
+			}
+			types = append(types, resultType)
+		}
+	}
+
+	// The scope lists the projects the caller may search. A NULL contract_id
+	// means every record on the project is visible; otherwise only records
+	// belonging to that contract are.
+	args := []interface{}{term}
+	var scope string
+	switch userCtx.UserType {
+	case string(models.UserTypeHouseOwner):
+		args = append(args, userCtx.UserID)
+		scope = `
+			SELECT p.id AS project_id, NULL::uuid AS contract_id
+			FROM projects p
+			WHERE p.owner_id = $2
+			   OR EXISTS(SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $2)`
+
+	case string(models.UserTypeContractor):
+		if userCtx.OrganizationID == "" {
+			respondWithJSON(w, http.StatusOK, []models.SearchResult{})
+			return
+		}
+		args = append(args, userCtx.OrganizationID)
+		scope = `
+			SELECT c.project_id, c.id AS contract_id
+			FROM contracts c
+			WHERE c.organization_id = $2 AND c.status != 'terminated'`
+
+	default:
+		respondWithError(w, http.StatusForbidden, "Search is available to project owners and contractors")
+		return
+	}
+
+	if projectID != "" {
+		args = append(args, projectID)
+		scope = `SELECT * FROM (` + scope + `) visible WHERE visible.project_id = $3`
+	}
+
+	selects := make([]string, 0, len(types))
+	for _, t := range types {
+		selects = append(selects, searchSources[t])
+	}
+
+	args = append(args, "StartSel="+searchMatchStart+", StopSel="+searchMatchStop+", MaxFragments=2, MaxWords=30, MinWords=10")
+	headlineOptions := "$" + strconv.Itoa(len(args))
+
+	args = append(args, limit)
+	query := `
+		WITH query AS (SELECT websearch_to_tsquery('english', $1) AS q),
+		     scope AS (` + scope + `)
+		SELECT r.type, r.id, r.project_id, p.title, r.contract_id,
+		       ts_headline('english', COALESCE(r.body, ''), query.q, ` + headlineOptions + `),
+		       r.rank, r.created_at
+		FROM (` + strings.Join(selects, "\nUNION ALL\n") + `) r
+		JOIN projects p ON r.project_id = p.id
+		CROSS JOIN query
+		WHERE p.deleted_at IS NULL
+		ORDER BY r.rank DESC, r.created_at DESC
+		LIMIT $` + strconv.Itoa(len(args))
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		log.Printf("ERROR Search: Failed to run search: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to search")
+		return
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var result models.SearchResult
+		var headline string
+		err := rows.Scan(&result.Type, &result.ID, &result.ProjectID, &result.ProjectTitle, &result.ContractID,
+			&headline, &result.Rank, &result.CreatedAt)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan search result")
+			return
+		}
+		result.Highlight = highlightToHTML(headline)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating search results")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, results)
+}
+
+// highlightToHTML escapes user content and only then turns the match markers
+// into <mark> tags, so records cannot inject markup into search results.
+func highlightToHTML(headline string) string {
+	escaped := html.EscapeString(headline)
+	escaped = strings.ReplaceAll(escaped, searchMatchStart, "<mark>")
+	return strings.ReplaceAll(escaped, searchMatchStop, "</mark>")
+}
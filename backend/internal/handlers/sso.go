@@ -0,0 +1,741 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/oidc"
+	"github.com/juazsh/managrr/internal/utils"
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ssoLoginStateExpiry = 10 * time.Minute
+	ssoLinkExpiry       = 30 * time.Minute
+)
+
+var ssoSlugRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}[a-z0-9]$`)
+
+const oidcProviderColumns = `
+	id, contractor_id, organization_id, slug, display_name, issuer_url, client_id, client_secret,
+	scopes, default_user_type, allowed_domains, enabled, created_at, updated_at
+`
+
+func scanOIDCProvider(row rowScanner) (*models.OIDCProvider, error) {
+	var p models.OIDCProvider
+	err := row.Scan(&p.ID, &p.ContractorID, &p.OrganizationID, &p.Slug, &p.DisplayName, &p.IssuerURL, &p.ClientID, &p.ClientSecret,
+		&p.Scopes, &p.DefaultUserType, pq.Array(&p.AllowedDomains), &p.Enabled, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	p.LoginURL = ssoBaseURL(p.Slug) + "/login"
+	p.CallbackURL = ssoBaseURL(p.Slug) + "/callback"
+	return &p, nil
+}
+
+func ssoBaseURL(slug string) string {
+	return strings.TrimRight(os.Getenv("APP_URL"), "/") + "/api/auth/sso/" + slug
+}
+
+func GetSSOProvider(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can configure single sign-on")
+		return
+	}
+
+	db := database.GetDB()
+
+	provider, err := scanOIDCProvider(db.QueryRow(
+		"SELECT "+oidcProviderColumns+" FROM oidc_providers WHERE organization_id = $1", userCtx.OrganizationID))
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusNotFound, "Single sign-on is not configured")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR GetSSOProvider: Failed to query provider: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch provider")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, provider)
+}
+
+func UpsertSSOProvider(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can configure single sign-on")
+		return
+	}
+
+	var req models.UpsertOIDCProviderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	req.Slug = strings.ToLower(strings.TrimSpace(req.Slug))
+	if !ssoSlugRegex.MatchString(req.Slug) {
+		respondWithAPIError(w, apierror.Invalid("slug", "Slug must be 3-64 lowercase letters, digits or dashes"))
+		return
+	}
+
+	if strings.TrimSpace(req.DisplayName) == "" {
+		respondWithAPIError(w, apierror.Invalid("display_name", "Display name is required"))
+		return
+	}
+
+	if err := oidc.CheckURL(req.IssuerURL); err != nil {
+		switch err {
+		case oidc.ErrInsecureURL:
+			respondWithAPIError(w, apierror.Invalid("issuer_url", "Issuer URL must use https"))
+		case oidc.ErrPrivateAddress:
+			respondWithAPIError(w, apierror.Invalid("issuer_url", "Issuer URL must not point at a private or loopback address"))
+		default:
+			respondWithAPIError(w, apierror.Invalid("issuer_url", "Issuer URL must be an absolute https URL"))
+		}
+		return
+	}
+
+	if req.ClientID == "" {
+		respondWithAPIError(w, apierror.Invalid("client_id", "Client ID is required"))
+		return
+	}
+
+	if req.Scopes == "" {
+		req.Scopes = "openid email profile"
+	}
+
+	if !strings.Contains(" "+req.Scopes+" ", " openid ") {
+		respondWithAPIError(w, apierror.Invalid("scopes", "Scopes must include openid"))
+		return
+	}
+
+	if req.DefaultUserType == "" {
+		req.DefaultUserType = models.UserTypeEmployee
+	}
+
+	if req.DefaultUserType != models.UserTypeEmployee && req.DefaultUserType != models.UserTypeContractor {
+		respondWithAPIError(w, apierror.Invalid("default_user_type", "Default user type must be employee or contractor"))
+		return
+	}
+
+	domains := []string{}
+	for _, d := range req.AllowedDomains {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	if _, err := oidc.Discover(req.IssuerURL); err != nil {
+		log.Printf("ERROR UpsertSSOProvider: Discovery failed for %s: %v", req.IssuerURL, err)
+		respondWithError(w, http.StatusBadRequest, "Could not load the provider's OpenID configuration")
+		return
+	}
+
+	db := database.GetDB()
+
+	// A blank client secret on update keeps the stored one so the UI never has
+	// to round-trip it.
+	query := `
+		INSERT INTO oidc_providers (contractor_id, organization_id, slug, display_name, issuer_url, client_id,
+		                            client_secret, scopes, default_user_type, allowed_domains, enabled)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (organization_id) DO UPDATE SET
+			slug = EXCLUDED.slug,
+			display_name = EXCLUDED.display_name,
+			issuer_url = EXCLUDED.issuer_url,
+			client_id = EXCLUDED.client_id,
+			client_secret = COALESCE(EXCLUDED.client_secret, oidc_providers.client_secret),
+			scopes = EXCLUDED.scopes,
+			default_user_type = EXCLUDED.default_user_type,
+			allowed_domains = EXCLUDED.allowed_domains,
+			enabled = EXCLUDED.enabled
+		RETURNING ` + oidcProviderColumns
+
+	var clientSecret *string
+	if req.ClientSecret != nil && *req.ClientSecret != "" {
+		clientSecret = req.ClientSecret
+	}
+
+	provider, err := scanOIDCProvider(db.QueryRow(query, userCtx.UserID, userCtx.OrganizationID, req.Slug, strings.TrimSpace(req.DisplayName),
+		strings.TrimRight(req.IssuerURL, "/"), req.ClientID, clientSecret, req.Scopes, req.DefaultUserType,
+		pq.Array(domains), enabled))
+	if err != nil {
+		if apierror.IsUniqueViolation(err, "oidc_providers_slug_key") {
+			respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeSlugTaken, "Slug is already in use"))
+			return
+		}
+		log.Printf("ERROR UpsertSSOProvider: Failed to save provider: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to save provider")
+		return
+	}
+
+	log.Printf("SUCCESS UpsertSSOProvider: User %s configured provider %s for organization %s", userCtx.UserID, provider.Slug, userCtx.OrganizationID)
+	respondWithJSON(w, http.StatusOK, provider)
+}
+
+func DeleteSSOProvider(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageMembers() {
+		respondWithError(w, http.StatusForbidden, "Only organization owners and admins can configure single sign-on")
+		return
+	}
+
+	db := database.GetDB()
+
+	result, err := db.Exec("DELETE FROM oidc_providers WHERE organization_id = $1", userCtx.OrganizationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to delete provider")
+		return
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		respondWithError(w, http.StatusNotFound, "Single sign-on is not configured")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func StartSSOLogin(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+
+	db := database.GetDB()
+
+	provider, err := scanOIDCProvider(db.QueryRow(
+		"SELECT "+oidcProviderColumns+" FROM oidc_providers WHERE slug = $1 AND enabled = true", slug))
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusNotFound, "Single sign-on provider not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR StartSSOLogin: Failed to query provider: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch provider")
+		return
+	}
+
+	doc, err := oidc.Discover(provider.IssuerURL)
+	if err != nil {
+		log.Printf("ERROR StartSSOLogin: Discovery failed for %s: %v", provider.Slug, err)
+		respondWithError(w, http.StatusBadGateway, "Identity provider is unavailable")
+		return
+	}
+
+	state, err := oidc.GenerateState()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+
+	nonce, err := oidc.GenerateState()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+
+	if _, err := db.Exec("DELETE FROM oidc_login_states WHERE expires_at < NOW()"); err != nil {
+		log.Printf("ERROR StartSSOLogin: Failed to purge expired states: %v", err)
+	}
+
+	_, err = db.Exec(`
+		INSERT INTO oidc_login_states (state, provider_id, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, state, provider.ID, verifier, nonce, time.Now().UTC().Add(ssoLoginStateExpiry))
+	if err != nil {
+		log.Printf("ERROR StartSSOLogin: Failed to store login state: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to start login")
+		return
+	}
+
+	http.Redirect(w, r, oidc.AuthorizationURL(doc, provider.ClientID, provider.CallbackURL, provider.Scopes, state, nonce, verifier), http.StatusFound)
+}
+
+func SSOCallback(w http.ResponseWriter, r *http.Request) {
+	slug := mux.Vars(r)["slug"]
+	query := r.URL.Query()
+
+	if idpError := query.Get("error"); idpError != "" {
+		log.Printf("ERROR SSOCallback: Provider %s returned error %s: %s", slug, idpError, query.Get("error_description"))
+		redirectSSOError(w, r, "Sign-in was cancelled or denied by your identity provider")
+		return
+	}
+
+	state := query.Get("state")
+	code := query.Get("code")
+	if state == "" || code == "" {
+		redirectSSOError(w, r, "Invalid sign-in response")
+		return
+	}
+
+	db := database.GetDB()
+
+	// Deleting the state as we read it makes each login attempt single-use.
+	var providerID, verifier, nonce string
+	err := db.QueryRow(`
+		DELETE FROM oidc_login_states s
+		USING oidc_providers p
+		WHERE s.state = $1 AND s.provider_id = p.id AND p.slug = $2 AND s.expires_at > NOW()
+		RETURNING s.provider_id, s.code_verifier, s.nonce
+	`, state, slug).Scan(&providerID, &verifier, &nonce)
+	if err == sql.ErrNoRows {
+		redirectSSOError(w, r, "Sign-in session expired, please try again")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR SSOCallback: Failed to load login state: %v", err)
+		redirectSSOError(w, r, "Sign-in failed")
+		return
+	}
+
+	provider, err := scanOIDCProvider(db.QueryRow(
+		"SELECT "+oidcProviderColumns+" FROM oidc_providers WHERE id = $1 AND enabled = true", providerID))
+	if err != nil {
+		log.Printf("ERROR SSOCallback: Failed to load provider %s: %v", providerID, err)
+		redirectSSOError(w, r, "Single sign-on provider not found")
+		return
+	}
+
+	doc, err := oidc.Discover(provider.IssuerURL)
+	if err != nil {
+		log.Printf("ERROR SSOCallback: Discovery failed for %s: %v", provider.Slug, err)
+		redirectSSOError(w, r, "Identity provider is unavailable")
+		return
+	}
+
+	clientSecret := ""
+	if provider.ClientSecret != nil {
+		clientSecret = *provider.ClientSecret
+	}
+
+	tokens, err := oidc.ExchangeCode(doc, provider.ClientID, clientSecret, provider.CallbackURL, code, verifier)
+	if err != nil {
+		log.Printf("ERROR SSOCallback: Code exchange failed for %s: %v", provider.Slug, err)
+		redirectSSOError(w, r, "Sign-in failed")
+		return
+	}
+
+	claims, err := oidc.VerifyIDToken(doc, provider.ClientID, tokens.IDToken, nonce)
+	if err != nil {
+		log.Printf("ERROR SSOCallback: ID token rejected for %s: %v", provider.Slug, err)
+		redirectSSOError(w, r, "Sign-in failed")
+		return
+	}
+
+	user, message, err := resolveSSOUser(db, provider, claims)
+	if errors.Is(err, errSSOLinkPending) {
+		redirectSSONotice(w, r, "This email already has an account. We sent a link to it; follow the link to finish signing in with "+provider.DisplayName+".")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR SSOCallback: Failed to resolve user for %s/%s: %v", provider.Slug, claims.Subject, err)
+		redirectSSOError(w, r, "Sign-in failed")
+		return
+	}
+	if user == nil {
+		redirectSSOError(w, r, message)
+		return
+	}
+
+	token, err := utils.GenerateToken(user.ID, user.Email, string(user.UserType))
+	if err != nil {
+		redirectSSOError(w, r, "Sign-in failed")
+		return
+	}
+
+	log.Printf("SUCCESS SSOCallback: User %s signed in via %s", user.ID, provider.Slug)
+
+	// The token travels in the fragment so it never reaches server logs or
+	// Referer headers.
+	appURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+	http.Redirect(w, r, appURL+"/sso/callback#token="+url.QueryEscape(token), http.StatusFound)
+}
+
+func redirectSSOError(w http.ResponseWriter, r *http.Request, message string) {
+	appURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+	http.Redirect(w, r, appURL+"/login?sso_error="+url.QueryEscape(message), http.StatusFound)
+}
+
+func redirectSSONotice(w http.ResponseWriter, r *http.Request, message string) {
+	appURL := strings.TrimRight(os.Getenv("APP_URL"), "/")
+	http.Redirect(w, r, appURL+"/login?sso_notice="+url.QueryEscape(message), http.StatusFound)
+}
+
+// errSSOLinkPending means the ID token matched an existing account outside
+// the provider's organization and a confirmation link was emailed to it.
+var errSSOLinkPending = errors.New("sso: identity link awaits email confirmation")
+
+// resolveSSOUser maps a verified ID token to a users row: an existing linked
+// identity wins, then an account with the same email that already belongs to
+// the provider's organization is linked, and otherwise a new account is
+// provisioned. Any other account with that email has to confirm the link
+// from its inbox first, since whoever runs the provider controls which emails
+// it asserts. A nil user with a message means the sign-in was refused.
+func resolveSSOUser(db *sql.DB, provider *models.OIDCProvider, claims *oidc.IDTokenClaims) (*models.User, string, error) {
+	var user models.User
+	err := db.QueryRow(`
+		SELECT u.id, u.email, u.name, u.user_type
+		FROM user_identities ui
+		JOIN users u ON u.id = ui.user_id
+		WHERE ui.provider_id = $1 AND ui.subject = $2
+	`, provider.ID, claims.Subject).Scan(&user.ID, &user.Email, &user.Name, &user.UserType)
+	if err == nil {
+		_, err = db.Exec(`
+			UPDATE user_identities SET last_login_at = NOW(), email = COALESCE(NULLIF($3, ''), email)
+			WHERE provider_id = $1 AND subject = $2
+		`, provider.ID, claims.Subject, claims.Email)
+		if err != nil {
+			log.Printf("ERROR resolveSSOUser: Failed to update identity: %v", err)
+		}
+		return &user, "", nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, "", err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, "Your identity provider did not supply a verified email address", nil
+	}
+
+	email := strings.ToLower(claims.Email)
+	if len(provider.AllowedDomains) > 0 {
+		domain := email[strings.LastIndex(email, "@")+1:]
+		allowed := false
+		for _, d := range provider.AllowedDomains {
+			if domain == d {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, "Your email domain is not allowed to sign in with this provider", nil
+		}
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, "", err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow("SELECT id, email, name, user_type, disabled_at FROM users WHERE LOWER(email) = $1", email).
+		Scan(&user.ID, &user.Email, &user.Name, &user.UserType, &user.DisabledAt)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, "", err
+	}
+
+	if err == nil {
+		if user.DisabledAt != nil {
+			return nil, "This account has been disabled", nil
+		}
+
+		if user.UserType != provider.DefaultUserType {
+			return nil, "An account with this email already exists with a different role", nil
+		}
+
+		member, message, err := inSSOOrganization(tx, provider, &user)
+		if err != nil {
+			return nil, "", err
+		}
+		if message != "" {
+			return nil, message, nil
+		}
+		if !member {
+			if err := requestSSOLink(db, provider, &user, claims.Subject, email); err != nil {
+				return nil, "", err
+			}
+			return nil, "", errSSOLinkPending
+		}
+	} else {
+		name := strings.TrimSpace(claims.Name)
+		if name == "" {
+			name = email[:strings.Index(email, "@")]
+		}
+
+		// SSO users never know this password; they can set a real one through
+		// the forgot-password flow if they ever need it.
+		placeholderPassword, err := utils.GenerateRandomPassword()
+		if err != nil {
+			return nil, "", err
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(placeholderPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, "", err
+		}
+
+		err = tx.QueryRow(`
+			INSERT INTO users (email, password_hash, name, user_type, email_verified)
+			VALUES ($1, $2, $3, $4, true)
+			RETURNING id, email, name, user_type
+		`, email, string(hashedPassword), name, provider.DefaultUserType).
+			Scan(&user.ID, &user.Email, &user.Name, &user.UserType)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	message, err := linkSSOIdentity(tx, provider, &user, claims.Subject, email)
+	if err != nil || message != "" {
+		return nil, message, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", err
+	}
+
+	return &user, "", nil
+}
+
+// inSSOOrganization reports whether an existing account already belongs to
+// the provider's organization: employees on its crew, or contractor staff
+// who are its members. Contractors who are members of another organization
+// are refused outright with a message.
+func inSSOOrganization(q queryRower, provider *models.OIDCProvider, user *models.User) (bool, string, error) {
+	if user.UserType == models.UserTypeContractor {
+		organizationID, err := organizationIDForUser(q, user.ID)
+		if err == sql.ErrNoRows {
+			return false, "", nil
+		}
+		if err != nil {
+			return false, "", err
+		}
+		if organizationID != provider.OrganizationID {
+			return false, "This account belongs to a different organization", nil
+		}
+		return true, "", nil
+	}
+
+	var onCrew bool
+	err := q.QueryRow(`
+		SELECT EXISTS(SELECT 1 FROM employees WHERE organization_id = $1 AND user_id = $2 AND is_active = true AND deleted_at IS NULL)
+	`, provider.OrganizationID, user.ID).Scan(&onCrew)
+	return onCrew, "", err
+}
+
+// linkSSOIdentity links the identity to the account, putting employees on the
+// provider organization's crew and contractors without an organization on
+// its staff. A message means the link was refused.
+func linkSSOIdentity(tx *sql.Tx, provider *models.OIDCProvider, user *models.User, subject, email string) (string, error) {
+	if user.UserType == models.UserTypeEmployee {
+		var onCrew bool
+		err := tx.QueryRow(`
+			SELECT EXISTS(SELECT 1 FROM employees WHERE organization_id = $1 AND user_id = $2 AND is_active = true AND deleted_at IS NULL)
+		`, provider.OrganizationID, user.ID).Scan(&onCrew)
+		if err != nil {
+			return "", err
+		}
+
+		if !onCrew {
+			_, err = tx.Exec(`
+				INSERT INTO employees (contractor_id, organization_id, user_id, name, email, hourly_rate, is_active)
+				VALUES ($1, $2, $3, $4, $5, 0, true)
+			`, provider.ContractorID, provider.OrganizationID, user.ID, user.Name, user.Email)
+			if err != nil {
+				return "", fmt.Errorf("failed to create employee: %w", err)
+			}
+		}
+	}
+
+	// Contractor accounts signing in through an organization's provider are
+	// staff of that organization; new ones join as foremen.
+	if user.UserType == models.UserTypeContractor {
+		organizationID, err := organizationIDForUser(tx, user.ID)
+		if err != nil && err != sql.ErrNoRows {
+			return "", err
+		}
+
+		if err == sql.ErrNoRows {
+			_, err = tx.Exec(`
+				INSERT INTO organization_members (organization_id, user_id, role)
+				VALUES ($1, $2, $3)
+			`, provider.OrganizationID, user.ID, models.OrganizationRoleForeman)
+			if err != nil {
+				return "", fmt.Errorf("failed to add organization member: %w", err)
+			}
+		} else if organizationID != provider.OrganizationID {
+			return "This account belongs to a different organization", nil
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE users SET email_verified = true WHERE id = $1", user.ID); err != nil {
+		return "", err
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO user_identities (user_id, provider_id, subject, email, last_login_at)
+		VALUES ($1, $2, $3, $4, NOW())
+	`, user.ID, provider.ID, subject, email)
+	if err != nil {
+		return "", fmt.Errorf("failed to link identity: %w", err)
+	}
+	return "", nil
+}
+
+// requestSSOLink emails the account a single-use link that links the
+// identity once followed. Only the newest request per account and provider
+// is kept.
+func requestSSOLink(db *sql.DB, provider *models.OIDCProvider, user *models.User, subject, email string) error {
+	token, err := utils.GenerateVerificationToken()
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Exec(`
+		WITH cleared AS (
+			DELETE FROM sso_link_requests WHERE (user_id = $1 AND provider_id = $2) OR expires_at < NOW()
+		)
+		INSERT INTO sso_link_requests (user_id, provider_id, subject, email, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, user.ID, provider.ID, subject, email, utils.HashLoginCode(token), time.Now().UTC().Add(ssoLinkExpiry))
+	if err != nil {
+		return fmt.Errorf("failed to store link request: %w", err)
+	}
+
+	if err := utils.SendSSOLinkEmail(user.Email, user.Name, provider.DisplayName, token); err != nil {
+		log.Printf("ERROR requestSSOLink: Failed to send link email to %s: %v", user.Email, err)
+	}
+	return nil
+}
+
+// ConfirmSSOLink links an SSO identity to the existing account whose owner
+// followed the emailed link, and signs them in.
+func ConfirmSSOLink(w http.ResponseWriter, r *http.Request) {
+	var req models.ConfirmSSOLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+
+	if req.Token == "" {
+		respondWithAPIError(w, apierror.Invalid("token", "Token is required"))
+		return
+	}
+
+	db := database.GetDB()
+
+	tx, err := db.Begin()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to confirm link")
+		return
+	}
+	defer tx.Rollback()
+
+	// Deleting the request as we read it makes the link single-use. It is
+	// deleted in the transaction that links the identity, so a failure
+	// leaves the link usable for another try.
+	var userID, providerID, subject, email string
+	err = tx.QueryRow(`
+		DELETE FROM sso_link_requests WHERE token_hash = $1 AND expires_at > NOW()
+		RETURNING user_id, provider_id, subject, email
+	`, utils.HashLoginCode(req.Token)).Scan(&userID, &providerID, &subject, &email)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired link"))
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR ConfirmSSOLink: Failed to load link request: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to confirm link")
+		return
+	}
+
+	provider, err := scanOIDCProvider(tx.QueryRow(
+		"SELECT "+oidcProviderColumns+" FROM oidc_providers WHERE id = $1 AND enabled = true", providerID))
+	if err == sql.ErrNoRows {
+		respondWithError(w, http.StatusNotFound, "Single sign-on provider not found")
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR ConfirmSSOLink: Failed to load provider %s: %v", providerID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to confirm link")
+		return
+	}
+
+	var user models.User
+	err = tx.QueryRow(`
+		SELECT id, email, name, phone, user_type, email_verified, time_zone, disabled_at, created_at, updated_at
+		FROM users
+		WHERE id = $1
+	`, userID).Scan(&user.ID, &user.Email, &user.Name, &user.Phone, &user.UserType, &user.EmailVerified, &user.TimeZone, &user.DisabledAt, &user.CreatedAt, &user.UpdatedAt)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to query user")
+		return
+	}
+
+	if user.DisabledAt != nil {
+		respondWithAPIError(w, apierror.ErrAccountDisabled)
+		return
+	}
+
+	message, err := linkSSOIdentity(tx, provider, &user, subject, email)
+	if err != nil {
+		log.Printf("ERROR ConfirmSSOLink: Failed to link identity for %s: %v", user.ID, err)
+		respondWithDBError(w, err, "Failed to confirm link")
+		return
+	}
+	if message != "" {
+		respondWithError(w, http.StatusConflict, message)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to confirm link")
+		return
+	}
+	user.EmailVerified = true
+
+	token, err := utils.GenerateToken(user.ID, user.Email, string(user.UserType))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	log.Printf("SUCCESS ConfirmSSOLink: User %s linked an identity from %s", user.ID, provider.Slug)
+	respondWithJSON(w, http.StatusOK, models.AuthResponse{
+		Token: token,
+		User:  user,
+	})
+}
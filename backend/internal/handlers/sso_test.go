@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"database/sql/driver"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/oidc/oidctest"
+)
+
+const (
+	testAppURL     = "http://app.test"
+	testProviderID = "11111111-1111-1111-1111-111111111111"
+	testOrgID      = "22222222-2222-2222-2222-222222222222"
+	testUserID     = "33333333-3333-3333-3333-333333333333"
+)
+
+// capture is a sqlmock argument matcher that records the value it is given.
+type capture struct{ value string }
+
+func (c *capture) Match(v driver.Value) bool {
+	c.value, _ = v.(string)
+	return true
+}
+
+type ssoTest struct {
+	t      *testing.T
+	idp    *oidctest.Provider
+	mock   sqlmock.Sqlmock
+	router *mux.Router
+}
+
+func newSSOTest(t *testing.T) *ssoTest {
+	t.Setenv("APP_URL", testAppURL)
+	t.Setenv("JWT_SECRET", "test-secret")
+	// The mock IdP listens on plain http on the loopback address.
+	t.Setenv("SSO_ALLOW_INSECURE_ISSUERS", "true")
+
+	idp, err := oidctest.New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(idp)
+	t.Cleanup(server.Close)
+	idp.Issuer = server.URL
+
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.SetDB(db)
+	t.Cleanup(func() {
+		database.SetDB(nil)
+		db.Close()
+	})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/auth/sso/{slug}/login", StartSSOLogin)
+	router.HandleFunc("/api/auth/sso/{slug}/callback", SSOCallback)
+
+	return &ssoTest{t: t, idp: idp, mock: mock, router: router}
+}
+
+func (st *ssoTest) providerRow() *sqlmock.Rows {
+	now := time.Now()
+	return sqlmock.NewRows([]string{
+		"id", "contractor_id", "organization_id", "slug", "display_name", "issuer_url", "client_id", "client_secret",
+		"scopes", "default_user_type", "allowed_domains", "enabled", "created_at", "updated_at",
+	}).AddRow(testProviderID, "44444444-4444-4444-4444-444444444444", testOrgID, "acme", "Acme", st.idp.Issuer, "managrr", nil,
+		"openid email profile", "employee", "{}", true, now, now)
+}
+
+// login starts a login and follows it through the mock IdP, returning the
+// callback request the IdP redirected to and the state, code verifier and
+// nonce the server stored for it.
+func (st *ssoTest) login() (*http.Request, string, string, string) {
+	state, verifier, nonce := &capture{}, &capture{}, &capture{}
+	st.mock.ExpectQuery(`FROM oidc_providers WHERE slug = \$1`).WithArgs("acme").WillReturnRows(st.providerRow())
+	st.mock.ExpectExec(`DELETE FROM oidc_login_states WHERE expires_at`).WillReturnResult(sqlmock.NewResult(0, 0))
+	st.mock.ExpectExec(`INSERT INTO oidc_login_states`).
+		WithArgs(state, testProviderID, verifier, nonce, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	rec := httptest.NewRecorder()
+	st.router.ServeHTTP(rec, httptest.NewRequest("GET", "/api/auth/sso/acme/login", nil))
+	if rec.Code != http.StatusFound {
+		st.t.Fatalf("login: status %d: %s", rec.Code, rec.Body)
+	}
+
+	authorizeURL := rec.Header().Get("Location")
+	if !strings.HasPrefix(authorizeURL, st.idp.Issuer+"/authorize?") {
+		st.t.Fatalf("login redirected to %s", authorizeURL)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(authorizeURL)
+	if err != nil {
+		st.t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	callback, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil || callback.Path != "/api/auth/sso/acme/callback" {
+		st.t.Fatalf("IdP redirected to %q", resp.Header.Get("Location"))
+	}
+	if callback.Query().Get("state") != state.value {
+		st.t.Fatalf("IdP returned state %q, want %q", callback.Query().Get("state"), state.value)
+	}
+
+	return httptest.NewRequest("GET", callback.RequestURI(), nil), state.value, verifier.value, nonce.value
+}
+
+// expectState has the callback find the stored login state.
+func (st *ssoTest) expectState(state, verifier, nonce string) {
+	st.mock.ExpectQuery(`DELETE FROM oidc_login_states s`).WithArgs(state, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"provider_id", "code_verifier", "nonce"}).AddRow(testProviderID, verifier, nonce))
+	st.mock.ExpectQuery(`FROM oidc_providers WHERE id = \$1`).WithArgs(testProviderID).WillReturnRows(st.providerRow())
+}
+
+// expectNoIdentity has the token's subject not be linked to any account yet.
+func (st *ssoTest) expectNoIdentity() {
+	st.mock.ExpectQuery(`FROM user_identities ui`).WithArgs(testProviderID, st.idp.Subject).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "user_type"}))
+}
+
+func (st *ssoTest) expectExistingEmployee(onCrew bool) {
+	st.mock.ExpectBegin()
+	st.mock.ExpectQuery(`FROM users WHERE LOWER\(email\) = \$1`).WithArgs(st.idp.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "user_type", "disabled_at"}).
+			AddRow(testUserID, st.idp.Email, "Existing", "employee", nil))
+	st.mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM employees`).WithArgs(testOrgID, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(onCrew))
+}
+
+func (st *ssoTest) callback(req *http.Request) *url.URL {
+	rec := httptest.NewRecorder()
+	st.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusFound {
+		st.t.Fatalf("callback: status %d: %s", rec.Code, rec.Body)
+	}
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		st.t.Fatal(err)
+	}
+	if err := st.mock.ExpectationsWereMet(); err != nil {
+		st.t.Fatal(err)
+	}
+	return location
+}
+
+func expectSignedIn(t *testing.T, location *url.URL) {
+	t.Helper()
+	if location.Path != "/sso/callback" || !strings.HasPrefix(location.Fragment, "token=") {
+		t.Fatalf("expected a session, redirected to %s", location)
+	}
+}
+
+func expectSSOError(t *testing.T, location *url.URL, message string) {
+	t.Helper()
+	if location.Path != "/login" || location.Query().Get("sso_error") != message {
+		t.Fatalf("expected sso_error %q, redirected to %s", message, location)
+	}
+}
+
+func TestSSOLoginProvisionsNewUser(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, verifier, nonce := st.login()
+
+	st.expectState(state, verifier, nonce)
+	st.expectNoIdentity()
+	st.mock.ExpectBegin()
+	st.mock.ExpectQuery(`FROM users WHERE LOWER\(email\) = \$1`).WithArgs(st.idp.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "user_type", "disabled_at"}))
+	st.mock.ExpectQuery(`INSERT INTO users`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "user_type"}).AddRow(testUserID, st.idp.Email, st.idp.Name, "employee"))
+	st.mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM employees`).WithArgs(testOrgID, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	st.mock.ExpectExec(`INSERT INTO employees`).WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectExec(`UPDATE users SET email_verified = true`).WithArgs(testUserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectExec(`INSERT INTO user_identities`).
+		WithArgs(testUserID, testProviderID, st.idp.Subject, st.idp.Email).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectCommit()
+
+	expectSignedIn(t, st.callback(req))
+}
+
+func TestSSOLoginRejectsWrongCodeVerifier(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, _, nonce := st.login()
+
+	// The IdP refuses the code unless the verifier matches the challenge sent
+	// with the authorization request.
+	st.expectState(state, "not-the-verifier", nonce)
+
+	expectSSOError(t, st.callback(req), "Sign-in failed")
+}
+
+func TestSSOLoginRejectsWrongNonce(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, verifier, nonce := st.login()
+	st.idp.Nonce = "replayed-nonce"
+
+	st.expectState(state, verifier, nonce)
+
+	expectSSOError(t, st.callback(req), "Sign-in failed")
+}
+
+func TestSSOLoginRejectsUnknownState(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, _, _ := st.login()
+
+	st.mock.ExpectQuery(`DELETE FROM oidc_login_states s`).WithArgs(state, "acme").
+		WillReturnRows(sqlmock.NewRows([]string{"provider_id", "code_verifier", "nonce"}))
+
+	expectSSOError(t, st.callback(req), "Sign-in session expired, please try again")
+}
+
+func TestSSOLoginLinksAccountInProviderOrganization(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, verifier, nonce := st.login()
+
+	st.expectState(state, verifier, nonce)
+	st.expectNoIdentity()
+	st.expectExistingEmployee(true)
+	st.mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM employees`).WithArgs(testOrgID, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	st.mock.ExpectExec(`UPDATE users SET email_verified = true`).WithArgs(testUserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectExec(`INSERT INTO user_identities`).
+		WithArgs(testUserID, testProviderID, st.idp.Subject, st.idp.Email).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectCommit()
+
+	expectSignedIn(t, st.callback(req))
+}
+
+// An IdP run by one organization must not take over accounts outside it by
+// asserting their email: the account is emailed a confirmation link and
+// nothing is linked or added to the crew until it is followed.
+func TestSSOLoginDoesNotLinkAccountOutsideProviderOrganization(t *testing.T) {
+	st := newSSOTest(t)
+	req, state, verifier, nonce := st.login()
+
+	st.expectState(state, verifier, nonce)
+	st.expectNoIdentity()
+	st.expectExistingEmployee(false)
+	st.mock.ExpectExec(`INSERT INTO sso_link_requests`).
+		WithArgs(testUserID, testProviderID, st.idp.Subject, st.idp.Email, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectRollback()
+
+	location := st.callback(req)
+	if location.Path != "/login" || location.Query().Get("sso_notice") == "" {
+		t.Fatalf("expected an sso_notice, redirected to %s", location)
+	}
+}
+
+// The link request is only used up once the identity is linked: a failure
+// rolls its deletion back so the emailed link can be followed again.
+func TestConfirmSSOLinkKeepsRequestWhenLinkingFails(t *testing.T) {
+	st := newSSOTest(t)
+	now := time.Now()
+
+	st.mock.ExpectBegin()
+	st.mock.ExpectQuery(`DELETE FROM sso_link_requests WHERE token_hash = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "provider_id", "subject", "email"}).
+			AddRow(testUserID, testProviderID, st.idp.Subject, st.idp.Email))
+	st.mock.ExpectQuery(`FROM oidc_providers WHERE id = \$1`).WithArgs(testProviderID).WillReturnRows(st.providerRow())
+	st.mock.ExpectQuery(`FROM users\s+WHERE id = \$1`).WithArgs(testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "email", "name", "phone", "user_type", "email_verified", "time_zone", "disabled_at", "created_at", "updated_at",
+		}).AddRow(testUserID, st.idp.Email, "Existing", nil, "employee", true, "UTC", nil, now, now))
+	st.mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM employees`).WithArgs(testOrgID, testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	st.mock.ExpectExec(`UPDATE users SET email_verified = true`).WithArgs(testUserID).WillReturnResult(sqlmock.NewResult(0, 1))
+	st.mock.ExpectExec(`INSERT INTO user_identities`).WillReturnError(driver.ErrBadConn)
+	st.mock.ExpectRollback()
+
+	rec := httptest.NewRecorder()
+	ConfirmSSOLink(rec, httptest.NewRequest("POST", "/api/auth/sso/link/confirm", strings.NewReader(`{"token":"link-token"}`)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d, want 500: %s", rec.Code, rec.Body)
+	}
+	if err := st.mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
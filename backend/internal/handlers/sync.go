@@ -0,0 +1,853 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/storage"
+	"github.com/juazsh/managrr/internal/trash"
+	"github.com/lib/pq"
+)
+
+// maxClockSkew is how far in the future a queued item's occurred_at may be,
+// allowing for a device clock that runs slightly fast.
+const maxClockSkew = 5 * time.Minute
+
+// syncUploadRetention is how long an uploaded item's client_id is remembered,
+// so uploading it again within that time does not record it twice.
+const syncUploadRetention = 30 * 24 * time.Hour
+
+// watermarkPayload is what a sync watermark encodes: the oldest transaction
+// still running when the sync read its snapshot, and when it was issued.
+type watermarkPayload struct {
+	Xmin   string `json:"xmin"`
+	Issued int64  `json:"issued"`
+}
+
+func encodeWatermark(xmin string, issued time.Time) string {
+	payload, _ := json.Marshal(watermarkPayload{Xmin: xmin, Issued: issued.Unix()})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeWatermark(raw string) (watermarkPayload, error) {
+	invalid := errors.New("since is not a valid watermark")
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return watermarkPayload{}, invalid
+	}
+
+	var payload watermarkPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return watermarkPayload{}, invalid
+	}
+	if _, err := strconv.ParseUint(payload.Xmin, 10, 64); err != nil {
+		return watermarkPayload{}, invalid
+	}
+	return payload, nil
+}
+
+// Sync returns what the user can see that changed since the watermark in
+// since, along with the watermark to send next time. Without one, or with
+// one older than the trash retention, it returns everything and sets full.
+// A project that became visible since the watermark comes with all of its
+// work logs and expenses, as the client has none of them yet.
+func Sync(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	since := "0"
+	full := true
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		watermark, err := decodeWatermark(raw)
+		if err != nil {
+			respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+			return
+		}
+		if time.Since(time.Unix(watermark.Issued, 0)) < trash.Retention {
+			since = watermark.Xmin
+			full = false
+		}
+	}
+
+	// Work logs and expenses are scoped the same way as the project lists:
+	// contractors see what is charged to their organization's contracts and
+	// employees what they recorded themselves.
+	var visible, workLogScope, expenseScope, subject string
+	switch userCtx.UserType {
+	case string(models.UserTypeHouseOwner):
+		subject = userCtx.UserID
+		visible = `
+			SELECT p.id,
+			       p.restored_xid >= $2::xid8
+			       OR EXISTS(SELECT 1 FROM project_members pm
+			                 WHERE pm.project_id = p.id AND pm.user_id = $1 AND pm.sync_xid >= $2::xid8)
+			       OR EXISTS(SELECT 1 FROM project_ownership_transfers t
+			                 WHERE t.project_id = p.id AND t.to_user_id = $1 AND t.status = 'accepted'
+			                   AND t.sync_xid >= $2::xid8)
+			FROM projects p
+			WHERE p.deleted_at IS NULL
+			  AND (p.owner_id = $1 OR EXISTS(SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $1))
+		`
+
+	case string(models.UserTypeContractor):
+		subject = userCtx.OrganizationID
+		visible = `
+			SELECT p.id, p.restored_xid >= $2::xid8 OR bool_or(c.sync_xid >= $2::xid8)
+			FROM projects p
+			JOIN contracts c ON p.id = c.project_id
+			WHERE c.organization_id = $1 AND c.status != 'terminated' AND p.deleted_at IS NULL
+			GROUP BY p.id
+		`
+		workLogScope = " AND wl.contract_id IN (SELECT id FROM contracts WHERE organization_id = $4)"
+		expenseScope = " AND e.contract_id IN (SELECT id FROM contracts WHERE organization_id = $4)"
+
+	case string(models.UserTypeEmployee):
+		subject = userCtx.UserID
+		visible = `
+			SELECT p.id, p.restored_xid >= $2::xid8 OR bool_or(ep.sync_xid >= $2::xid8)
+			FROM projects p
+			JOIN employee_projects ep ON p.id = ep.project_id
+			WHERE ep.employee_id = $1 AND p.deleted_at IS NULL
+			GROUP BY p.id
+		`
+		workLogScope = " AND wl.employee_id = $4"
+		expenseScope = " AND e.added_by = $4"
+
+	default:
+		respondWithAPIError(w, apierror.Invalid("user_type", "Invalid user type"))
+		return
+	}
+
+	db := database.GetDB()
+
+	// Everything is read from one snapshot. Its xmin becomes the next
+	// watermark: rows written by transactions still running now carry a
+	// later id, so they are picked up next time even if they commit after
+	// this sync has read past them.
+	tx, err := db.BeginTx(r.Context(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+	defer tx.Rollback()
+
+	var xmin string
+	if err := tx.QueryRow("SELECT pg_snapshot_xmin(pg_current_snapshot())::text").Scan(&xmin); err != nil {
+		log.Printf("ERROR Sync: Failed to read snapshot: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+
+	resp := models.SyncResponse{
+		Watermark:  encodeWatermark(xmin, time.Now()),
+		Full:       full,
+		ProjectIDs: []string{},
+		Projects:   []models.Project{},
+		WorkLogs:   []models.WorkLog{},
+		Expenses:   []models.Expense{},
+		Deleted:    []models.SyncTombstone{},
+	}
+
+	newIDs := []string{}
+	rows, err := tx.Query(visible, subject, since)
+	if err != nil {
+		log.Printf("ERROR Sync: Failed to list projects: %v", err)
+		respondWithDBError(w, err, "Failed to sync")
+		return
+	}
+	for rows.Next() {
+		var id string
+		var isNew bool
+		if err := rows.Scan(&id, &isNew); err != nil {
+			rows.Close()
+			respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+			return
+		}
+		resp.ProjectIDs = append(resp.ProjectIDs, id)
+		if isNew {
+			newIDs = append(newIDs, id)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+
+	args := []interface{}{pq.Array(resp.ProjectIDs), since, pq.Array(newIDs)}
+	scopeArgs := args
+	if workLogScope != "" {
+		scopeArgs = append(scopeArgs, subject)
+	}
+
+	if resp.Projects, err = syncProjects(tx, args); err != nil {
+		log.Printf("ERROR Sync: Failed to fetch projects: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+
+	if resp.WorkLogs, err = syncWorkLogs(tx, workLogScope, scopeArgs); err != nil {
+		log.Printf("ERROR Sync: Failed to fetch work logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+
+	expenses, deleted, err := syncExpenses(tx, expenseScope, scopeArgs)
+	if err != nil {
+		log.Printf("ERROR Sync: Failed to fetch expenses: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+		return
+	}
+	resp.Expenses = expenses
+
+	// A full sync replaces whatever the client has, so it needs no record of
+	// what was deleted.
+	if !full {
+		resp.Deleted = deleted
+		tombstones, err := syncTombstones(tx, args[:2])
+		if err != nil {
+			log.Printf("ERROR Sync: Failed to fetch tombstones: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to sync")
+			return
+		}
+		resp.Deleted = append(resp.Deleted, tombstones...)
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// The sync queries take the visible project ids as $1, the watermark as $2
+// and the projects new to the client as $3. A scope narrowing them to the
+// user's own records uses $4.
+const syncChanged = "(%[1]s.sync_xid >= $2::xid8 OR %[1]s.project_id = ANY($3::uuid[]))"
+
+func syncProjects(tx *sql.Tx, args []interface{}) ([]models.Project, error) {
+	rows, err := tx.Query(`
+		SELECT p.id, p.owner_id, p.title, p.description,
+		       p.estimated_cost, p.currency, p.time_zone, p.address, p.status, p.created_at, p.updated_at, p.version
+		FROM projects p
+		WHERE p.id = ANY($1::uuid[]) AND (p.sync_xid >= $2::xid8 OR p.id = ANY($3::uuid[]))
+		ORDER BY p.created_at
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []models.Project{}
+	for rows.Next() {
+		var project models.Project
+		err := rows.Scan(
+			&project.ID,
+			&project.OwnerID,
+			&project.Title,
+			&project.Description,
+			&project.EstimatedCost,
+			&project.Currency,
+			&project.TimeZone,
+			&project.Address,
+			&project.Status,
+			&project.CreatedAt,
+			&project.UpdatedAt,
+			&project.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+func syncWorkLogs(tx *sql.Tx, scope string, args []interface{}) ([]models.WorkLog, error) {
+	rows, err := tx.Query(`
+		SELECT wl.id, wl.employee_id, wl.project_id, wl.contract_id, wl.check_in_time, `+workLogDate+`::text, wl.check_out_time,
+		       wl.check_in_photo_url, wl.check_out_photo_url,
+		       wl.check_in_latitude, wl.check_in_longitude,
+		       wl.check_out_latitude, wl.check_out_longitude,
+		       wl.hours_worked, wl.approved_by, wl.approved_at, wl.created_at
+		FROM work_logs wl
+		JOIN projects p ON wl.project_id = p.id
+		WHERE wl.project_id = ANY($1::uuid[]) AND `+fmt.Sprintf(syncChanged, "wl")+scope+`
+		ORDER BY wl.check_in_time
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workLogs := []models.WorkLog{}
+	for rows.Next() {
+		var wl models.WorkLog
+		err := rows.Scan(
+			&wl.ID,
+			&wl.EmployeeID,
+			&wl.ProjectID,
+			&wl.ContractID,
+			&wl.CheckInTime,
+			&wl.WorkDate,
+			&wl.CheckOutTime,
+			&wl.CheckInPhotoURL,
+			&wl.CheckOutPhotoURL,
+			&wl.CheckInLatitude,
+			&wl.CheckInLongitude,
+			&wl.CheckOutLatitude,
+			&wl.CheckOutLongitude,
+			&wl.HoursWorked,
+			&wl.ApprovedBy,
+			&wl.ApprovedAt,
+			&wl.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		workLogs = append(workLogs, wl)
+	}
+	return workLogs, rows.Err()
+}
+
+// syncExpenses returns the changed expenses, with those that were moved to
+// the trash reported as deleted instead.
+func syncExpenses(tx *sql.Tx, scope string, args []interface{}) ([]models.Expense, []models.SyncTombstone, error) {
+	rows, err := tx.Query(`
+		SELECT e.id, e.project_id, e.contract_id, e.amount, e.currency, e.exchange_rate, e.converted_amount,
+		       e.vendor, e.date::text, e.category, e.description, e.paid_by, e.receipt_photo_url,
+		       e.added_by, e.created_at, e.version, e.deleted_at
+		FROM expenses e
+		WHERE e.project_id = ANY($1::uuid[]) AND `+fmt.Sprintf(syncChanged, "e")+scope+`
+		ORDER BY e.created_at
+	`, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	expenses := []models.Expense{}
+	deleted := []models.SyncTombstone{}
+	for rows.Next() {
+		var expense models.Expense
+		var deletedAt sql.NullTime
+		err := rows.Scan(
+			&expense.ID,
+			&expense.ProjectID,
+			&expense.ContractID,
+			&expense.Amount,
+			&expense.Currency,
+			&expense.ExchangeRate,
+			&expense.ConvertedAmount,
+			&expense.Vendor,
+			&expense.Date,
+			&expense.Category,
+			&expense.Description,
+			&expense.PaidBy,
+			&expense.ReceiptPhotoURL,
+			&expense.AddedBy,
+			&expense.CreatedAt,
+			&expense.Version,
+			&deletedAt,
+		)
+		if err != nil {
+			return nil, nil, err
+		}
+		if deletedAt.Valid {
+			deleted = append(deleted, models.SyncTombstone{
+				Type:      models.SyncEntityExpense,
+				ID:        expense.ID,
+				ProjectID: expense.ProjectID,
+				DeletedAt: deletedAt.Time,
+			})
+			continue
+		}
+		expenses = append(expenses, expense)
+	}
+	return expenses, deleted, rows.Err()
+}
+
+func syncTombstones(tx *sql.Tx, args []interface{}) ([]models.SyncTombstone, error) {
+	rows, err := tx.Query(`
+		SELECT entity_type, entity_id, project_id, deleted_at
+		FROM sync_tombstones
+		WHERE project_id = ANY($1::uuid[]) AND sync_xid >= $2::xid8
+		ORDER BY id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tombstones []models.SyncTombstone
+	for rows.Next() {
+		var t models.SyncTombstone
+		if err := rows.Scan(&t.Type, &t.ID, &t.ProjectID, &t.DeletedAt); err != nil {
+			return nil, err
+		}
+		tombstones = append(tombstones, t)
+	}
+	return tombstones, rows.Err()
+}
+
+// expensesAdded totals the expenses an upload added to one project, so the
+// project's owner and contractor get a single email about them.
+type expensesAdded struct {
+	count    int
+	total    money.Amount
+	currency money.Currency
+}
+
+// UploadSyncItems records check-ins, check-outs and expenses queued while the
+// client was offline. The items field is a JSON array applied in order, each
+// in its own transaction, so a check-out can follow the check-in it closes.
+// Times are the client's: occurred_at says when a check-in or check-out
+// happened. Items that conflict with what the server already has fail on
+// their own, and an item whose client_id was uploaded before is reported as
+// a duplicate with the record created the first time.
+func UploadSyncItems(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxFileSize); err != nil {
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
+		return
+	}
+
+	field := r.FormValue("items")
+	if field == "" {
+		respondWithAPIError(w, apierror.Invalid("items", "items is required"))
+		return
+	}
+	var items []models.SyncUploadItem
+	if err := json.Unmarshal([]byte(field), &items); err != nil {
+		respondWithAPIError(w, apierror.Invalid("items", "items must be a JSON array of sync items"))
+		return
+	}
+	if len(items) == 0 {
+		respondWithAPIError(w, apierror.Invalid("items", "items is required"))
+		return
+	}
+	if len(items) > maxBulkItems {
+		respondWithAPIError(w, apierror.Invalid("items", fmt.Sprintf("items has more than %d items", maxBulkItems)))
+		return
+	}
+
+	db := database.GetDB()
+
+	if _, err := db.Exec(`DELETE FROM sync_uploads WHERE user_id = $1 AND created_at < $2`, userCtx.UserID, time.Now().Add(-syncUploadRetention)); err != nil {
+		log.Printf("ERROR UploadSyncItems: Failed to prune sync uploads: %v", err)
+	}
+
+	upload := &syncUpload{
+		db:      db,
+		store:   storage.NewSupabaseStorage(),
+		userCtx: userCtx,
+		files:   r.MultipartForm.File,
+		added:   make(map[string]*expensesAdded),
+	}
+
+	resp := models.SyncUploadResponse{Results: make([]models.SyncUploadResult, 0, len(items))}
+	for i, item := range items {
+		result := models.SyncUploadResult{Index: i, ClientID: item.ClientID, Type: item.Type}
+		id, duplicate, apiErr := upload.apply(item)
+		switch {
+		case apiErr != nil:
+			result.Status = apiErr.Status
+			result.Error = apiErr
+			resp.Failed++
+		case duplicate:
+			result.ID = id
+			result.Status = http.StatusOK
+			result.Duplicate = true
+			resp.Succeeded++
+		default:
+			result.ID = id
+			result.Status = http.StatusCreated
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	for _, projectID := range upload.projects {
+		a := upload.added[projectID]
+		notifyExpensesAdded(db, projectID, userCtx.UserID, a.count, a.total, a.currency)
+	}
+
+	respondWithJSON(w, http.StatusOK, resp)
+}
+
+// syncUpload applies the items of one upload.
+type syncUpload struct {
+	db       *sql.DB
+	store    *storage.SupabaseStorage
+	userCtx  middleware.UserContext
+	files    map[string][]*multipart.FileHeader
+	added    map[string]*expensesAdded
+	projects []string
+}
+
+// apply records one item, returning the id of what it created and whether it
+// had been uploaded already.
+func (u *syncUpload) apply(item models.SyncUploadItem) (string, bool, *apierror.Error) {
+	if strings.TrimSpace(item.ClientID) == "" {
+		return "", false, apierror.Invalid("client_id", "client_id is required")
+	}
+	if len(item.ClientID) > 255 {
+		return "", false, apierror.Invalid("client_id", "client_id must be at most 255 characters")
+	}
+
+	id, err := syncUploadedID(u.db, u.userCtx.UserID, item.ClientID)
+	if err != nil {
+		log.Printf("ERROR UploadSyncItems: Failed to look up client_id: %v", err)
+		return "", false, apierror.FromStatus(http.StatusInternalServerError, "Failed to check for a previous upload")
+	}
+	if id != "" {
+		return id, true, nil
+	}
+
+	switch item.Type {
+	case models.SyncItemCheckIn:
+		id, apiErr := u.checkIn(item)
+		return u.settle(item, id, apiErr)
+	case models.SyncItemCheckOut:
+		id, apiErr := u.checkOut(item)
+		return u.settle(item, id, apiErr)
+	case models.SyncItemExpense:
+		id, apiErr := u.expense(item)
+		return u.settle(item, id, apiErr)
+	}
+	return "", false, apierror.Invalid("type", "type must be one of: check_in, check_out, expense")
+}
+
+// errSyncDuplicate reports that another upload of the same item was recorded
+// while this one was being applied.
+var errSyncDuplicate = apierror.FromStatus(http.StatusConflict, "The item was uploaded twice at once")
+
+// settle turns losing a race with a concurrent upload of the same item into
+// a duplicate result.
+func (u *syncUpload) settle(item models.SyncUploadItem, id string, apiErr *apierror.Error) (string, bool, *apierror.Error) {
+	if apiErr != errSyncDuplicate {
+		return id, false, apiErr
+	}
+	id, err := syncUploadedID(u.db, u.userCtx.UserID, item.ClientID)
+	if err != nil || id == "" {
+		return "", false, apierror.FromStatus(http.StatusInternalServerError, "Failed to check for a previous upload")
+	}
+	return id, true, nil
+}
+
+func (u *syncUpload) checkIn(item models.SyncUploadItem) (string, *apierror.Error) {
+	if u.userCtx.UserType != string(models.UserTypeEmployee) {
+		return "", apierror.FromStatus(http.StatusForbidden, "Only employees can check in")
+	}
+	at, apiErr := syncOccurredAt(item)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if item.ProjectID == "" {
+		return "", apierror.Invalid("project_id", "project_id is required")
+	}
+	photo, apiErr := syncPhoto(u.files, item.Photo)
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check in")
+	}
+	defer tx.Rollback()
+
+	contractID, apiErr := checkInContract(tx, u.userCtx.UserID, item.ProjectID)
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	open, err := hasOpenWorkLog(tx, u.userCtx.UserID, item.ProjectID)
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check active work log")
+	}
+	if open {
+		return "", apierror.ErrAlreadyCheckedIn
+	}
+
+	overlaps, err := workLogOverlaps(tx, u.userCtx.UserID, "", at, at)
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check work logs")
+	}
+	if overlaps {
+		return "", apierror.ErrWorkLogOverlap
+	}
+
+	photoURL, err := uploadFormFile(u.store, photo, u.userCtx.UserID)
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to upload photo")
+	}
+
+	workLog, err := insertWorkLog(tx, u.userCtx.UserID, item.ProjectID, contractID, photoURL, item.Latitude, item.Longitude, &at)
+	if err != nil {
+		deleteStoredFiles(photoURL)
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to create work log")
+	}
+
+	if apiErr := u.commit(tx, item, workLog.ID); apiErr != nil {
+		deleteStoredFiles(photoURL)
+		return "", apiErr
+	}
+	return workLog.ID, nil
+}
+
+func (u *syncUpload) checkOut(item models.SyncUploadItem) (string, *apierror.Error) {
+	if u.userCtx.UserType != string(models.UserTypeEmployee) {
+		return "", apierror.FromStatus(http.StatusForbidden, "Only employees can check out")
+	}
+	at, apiErr := syncOccurredAt(item)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if item.WorkLogID == "" && item.CheckInClientID == "" {
+		return "", apierror.Invalid("work_log_id", "work_log_id or check_in_client_id is required")
+	}
+	photo, apiErr := syncPhoto(u.files, item.Photo)
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check out")
+	}
+	defer tx.Rollback()
+
+	workLogID := item.WorkLogID
+	if workLogID == "" {
+		err := tx.QueryRow(`
+			SELECT entity_id FROM sync_uploads
+			WHERE user_id = $1 AND client_id = $2 AND item_type = $3
+		`, u.userCtx.UserID, item.CheckInClientID, models.SyncItemCheckIn).Scan(&workLogID)
+		if err == sql.ErrNoRows {
+			return "", apierror.ErrWorkLogNotFound
+		}
+		if err != nil {
+			return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to find work log")
+		}
+	}
+
+	workLog, err := getWorkLog(tx, workLogID)
+	if err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return "", apiErr
+		}
+		return "", apierror.ErrWorkLogNotFound
+	}
+
+	if workLog.EmployeeID != u.userCtx.UserID {
+		return "", apierror.FromStatus(http.StatusForbidden, "You can only check out from your own work log")
+	}
+	if workLog.CheckOutTime != nil {
+		return "", apierror.ErrAlreadyCheckedOut
+	}
+	if at.Before(workLog.CheckInTime) {
+		return "", apierror.ErrCheckOutBeforeIn
+	}
+
+	overlaps, err := workLogOverlaps(tx, u.userCtx.UserID, workLog.ID, workLog.CheckInTime, at)
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check work logs")
+	}
+	if overlaps {
+		return "", apierror.ErrWorkLogOverlap
+	}
+
+	photoURL, err := uploadFormFile(u.store, photo, u.userCtx.UserID)
+	if err != nil {
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to upload photo")
+	}
+
+	if _, err := closeWorkLog(tx, workLog.ID, photoURL, item.Latitude, item.Longitude, &at); err != nil {
+		deleteStoredFiles(photoURL)
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to check out")
+	}
+
+	if apiErr := u.commit(tx, item, workLog.ID); apiErr != nil {
+		deleteStoredFiles(photoURL)
+		return "", apiErr
+	}
+	return workLog.ID, nil
+}
+
+func (u *syncUpload) expense(item models.SyncUploadItem) (string, *apierror.Error) {
+	if item.ProjectID == "" {
+		return "", apierror.Invalid("project_id", "project_id is required")
+	}
+	if item.Expense == nil {
+		return "", apierror.Invalid("expense", "expense is required")
+	}
+
+	contractID, paidBy, apiErr := expenseContract(u.db, item.ProjectID, u.userCtx, item.ContractID)
+	if apiErr != nil {
+		return "", apiErr
+	}
+
+	e, problems, apiErr := validateBulkExpense(u.db, item.ProjectID, "expense.", *item.Expense, u.files)
+	if apiErr != nil {
+		return "", apiErr
+	}
+	if len(problems) > 0 {
+		return "", apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "The expense is invalid").WithDetails(problems...)
+	}
+
+	if e.receipt != nil {
+		url, err := uploadFormFile(u.store, e.receipt, item.ProjectID)
+		if err != nil {
+			return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to upload receipt photo")
+		}
+		e.receiptURL = &url
+	}
+	discard := func() {
+		if e.receiptURL != nil {
+			deleteStoredFiles(*e.receiptURL)
+		}
+	}
+
+	tx, err := u.db.Begin()
+	if err != nil {
+		discard()
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to create expense")
+	}
+	defer tx.Rollback()
+
+	expense, err := insertExpense(tx, newExpense{
+		ProjectID:       item.ProjectID,
+		ContractID:      contractID,
+		Amount:          e.item.Amount,
+		Conversion:      e.conversion,
+		Vendor:          e.item.Vendor,
+		Date:            e.date,
+		Category:        string(e.item.Category),
+		Description:     e.item.Description,
+		PaidBy:          paidBy,
+		ReceiptPhotoURL: e.receiptURL,
+		AddedBy:         u.userCtx.UserID,
+	})
+	if err != nil {
+		discard()
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return "", apiErr
+		}
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to create expense")
+	}
+
+	if apiErr := u.commit(tx, item, expense.ID); apiErr != nil {
+		discard()
+		return "", apiErr
+	}
+
+	a, ok := u.added[item.ProjectID]
+	if !ok {
+		a = &expensesAdded{currency: e.conversion.ProjectCurrency}
+		u.added[item.ProjectID] = a
+		u.projects = append(u.projects, item.ProjectID)
+	}
+	a.count++
+	a.total += e.conversion.Converted
+
+	return expense.ID, nil
+}
+
+// commit records the item's client_id against what it created and commits
+// the item's transaction.
+func (u *syncUpload) commit(tx *sql.Tx, item models.SyncUploadItem, entityID string) *apierror.Error {
+	var recorded bool
+	err := tx.QueryRow(`
+		INSERT INTO sync_uploads (user_id, client_id, item_type, entity_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, client_id) DO NOTHING
+		RETURNING true
+	`, u.userCtx.UserID, item.ClientID, item.Type, entityID).Scan(&recorded)
+	if err == sql.ErrNoRows {
+		return errSyncDuplicate
+	}
+	if err != nil {
+		log.Printf("ERROR UploadSyncItems: Failed to record upload: %v", err)
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to record upload")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apierror.FromStatus(http.StatusInternalServerError, "Failed to record upload")
+	}
+	return nil
+}
+
+// syncUploadedID returns what an earlier upload of clientID created, or ""
+// if it has not been uploaded.
+func syncUploadedID(q queryRower, userID, clientID string) (string, error) {
+	var id string
+	err := q.QueryRow(`SELECT entity_id FROM sync_uploads WHERE user_id = $1 AND client_id = $2`, userID, clientID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return id, err
+}
+
+func syncOccurredAt(item models.SyncUploadItem) (time.Time, *apierror.Error) {
+	if item.OccurredAt == nil {
+		return time.Time{}, apierror.Invalid("occurred_at", "occurred_at is required")
+	}
+	if item.OccurredAt.After(time.Now().Add(maxClockSkew)) {
+		return time.Time{}, apierror.Invalid("occurred_at", "occurred_at is in the future")
+	}
+	return *item.OccurredAt, nil
+}
+
+// syncPhoto returns the uploaded file a check-in or check-out names as its
+// photo.
+func syncPhoto(files map[string][]*multipart.FileHeader, name string) (*multipart.FileHeader, *apierror.Error) {
+	if name == "" {
+		return nil, apierror.Invalid("photo", "Photo file is required")
+	}
+	headers := files[name]
+	if len(headers) == 0 {
+		return nil, apierror.Invalid("photo", "photo names no uploaded file")
+	}
+	if !allowedExtensions[strings.ToLower(filepath.Ext(headers[0].Filename))] {
+		return nil, apierror.ErrUnsupportedImage
+	}
+	if headers[0].Size > maxFileSize {
+		return nil, apierror.ErrFileTooLarge
+	}
+	return headers[0], nil
+}
+
+// workLogOverlaps reports whether the employee has a finished work log, other
+// than exceptID, that overlaps the time from start to end.
+func workLogOverlaps(q queryRower, userID, exceptID string, start, end time.Time) (bool, error) {
+	var overlaps bool
+	err := q.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM work_logs
+			WHERE employee_id = $1 AND id::text != $2 AND check_out_time IS NOT NULL
+			  AND check_in_time <= $4 AND check_out_time > $3
+		)
+	`, userID, exceptID, start, end).Scan(&overlaps)
+	return overlaps, err
+}
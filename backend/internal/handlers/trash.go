@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/pagination"
+	"github.com/juazsh/managrr/internal/trash"
+)
+
+var trashListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"deleted_at": {Column: "deleted_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-deleted_at",
+	IDColumn:    "id",
+	DateColumn:  "deleted_at",
+	Filters: map[string]string{
+		"type": "type",
+	},
+}
+
+// ListTrash returns what the caller deleted and can still restore. Expenses
+// and payment summaries are left out while their project is in the trash,
+// since they cannot be restored until the project is.
+func ListTrash(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	params, err := pagination.Parse(r, trashListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
+
+	db := database.GetDB()
+
+	q := &pagination.Query{}
+	user := q.Arg(userCtx.UserID)
+	from := `FROM (
+		SELECT 'project' AS type, id, title, id AS project_id, deleted_at
+		FROM projects
+		WHERE deleted_by = ` + user + ` AND deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'expense', e.id, COALESCE(e.vendor, e.category), e.project_id, e.deleted_at
+		FROM expenses e
+		JOIN projects p ON e.project_id = p.id AND p.deleted_at IS NULL
+		WHERE e.deleted_by = ` + user + ` AND e.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'payment', ps.id, ps.payment_method || ' ' || ps.amount::text || ' ' || ps.currency, ps.project_id, ps.deleted_at
+		FROM payment_summaries ps
+		JOIN projects p ON ps.project_id = p.id AND p.deleted_at IS NULL
+		WHERE ps.deleted_by = ` + user + ` AND ps.deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'employee', id, name, NULL, deleted_at
+		FROM employees
+		WHERE deleted_by = ` + user + ` AND deleted_at IS NOT NULL
+	) trash`
+
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch trash")
+		return
+	}
+
+	query, args := params.Select(`
+		type, id, title, project_id, deleted_at,
+		`+params.CursorColumn(), from, q)
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch trash")
+		return
+	}
+	defer rows.Close()
+
+	var items []models.TrashItem
+	var cursors []string
+	for rows.Next() {
+		var item models.TrashItem
+		var cursor string
+		if err := rows.Scan(&item.Type, &item.ID, &item.Title, &item.ProjectID, &item.DeletedAt, &cursor); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to scan trash")
+			return
+		}
+		item.PurgeAt = item.DeletedAt.Add(trash.Retention)
+		items = append(items, item)
+		cursors = append(cursors, cursor)
+	}
+
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating trash")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, items, cursors, total))
+}
+
+func RestoreProject(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	projectID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var ownerID string
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NOT NULL", projectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
+		return
+	}
+
+	if ownerID != userCtx.UserID {
+		respondWithError(w, http.StatusForbidden, "Only the project owner can restore this project")
+		return
+	}
+
+	_, err = db.Exec("UPDATE projects SET deleted_at = NULL, deleted_by = NULL WHERE id = $1", projectID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to restore project")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Project restored successfully"})
+}
+
+func RestoreExpense(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	expenseID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var addedBy string
+	var ownerID sql.NullString
+	err := db.QueryRow(`
+		SELECT e.added_by, p.owner_id
+		FROM expenses e
+		JOIN projects p ON e.project_id = p.id AND p.deleted_at IS NULL
+		WHERE e.id = $1 AND e.deleted_at IS NOT NULL
+	`, expenseID).Scan(&addedBy, &ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrExpenseNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch expense")
+		return
+	}
+
+	isCreator := addedBy == userCtx.UserID
+	isProjectOwner := ownerID.Valid && ownerID.String == userCtx.UserID
+
+	if !isCreator && !isProjectOwner {
+		respondWithError(w, http.StatusForbidden, "Only the creator or project owner can restore this expense")
+		return
+	}
+
+	_, err = db.Exec("UPDATE expenses SET deleted_at = NULL, deleted_by = NULL WHERE id = $1", expenseID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to restore expense")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Expense restored successfully"})
+}
+
+func RestorePaymentSummary(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	paymentID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var addedBy string
+	var ownerID sql.NullString
+	err := db.QueryRow(`
+		SELECT ps.added_by, p.owner_id
+		FROM payment_summaries ps
+		JOIN projects p ON ps.project_id = p.id AND p.deleted_at IS NULL
+		WHERE ps.id = $1 AND ps.deleted_at IS NOT NULL
+	`, paymentID).Scan(&addedBy, &ownerID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrPaymentNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch payment summary")
+		return
+	}
+
+	isCreator := addedBy == userCtx.UserID
+	isProjectOwner := ownerID.Valid && ownerID.String == userCtx.UserID
+
+	if !isCreator && !isProjectOwner {
+		respondWithError(w, http.StatusForbidden, "Only the creator or project owner can restore this payment summary")
+		return
+	}
+
+	_, err = db.Exec("UPDATE payment_summaries SET deleted_at = NULL, deleted_by = NULL WHERE id = $1", paymentID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to restore payment summary")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Payment summary restored successfully"})
+}
+
+func RestoreEmployee(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	if userCtx.UserType != string(models.UserTypeContractor) || !userCtx.CanManageBusiness() {
+		respondWithError(w, http.StatusForbidden, "Only contractors can restore employees")
+		return
+	}
+
+	employeeID := mux.Vars(r)["id"]
+
+	db := database.GetDB()
+
+	var organizationID, userID string
+	err := db.QueryRow("SELECT organization_id, user_id FROM employees WHERE id = $1 AND deleted_at IS NOT NULL", employeeID).
+		Scan(&organizationID, &userID)
+	if err == sql.ErrNoRows {
+		respondWithAPIError(w, apierror.ErrEmployeeNotFound)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to verify employee ownership")
+		return
+	}
+
+	if !userCtx.InOrganization(organizationID) {
+		respondWithAPIError(w, apierror.ErrAccessDenied)
+		return
+	}
+
+	var rehired bool
+	err = db.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM employees
+			WHERE organization_id = $1 AND user_id = $2 AND is_active = true AND deleted_at IS NULL
+		)
+	`, organizationID, userID).Scan(&rehired)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to restore employee")
+		return
+	}
+	if rehired {
+		respondWithAPIError(w, apierror.New(http.StatusConflict, apierror.CodeAlreadyMember, "This person is already on your crew"))
+		return
+	}
+
+	_, err = db.Exec("UPDATE employees SET deleted_at = NULL, deleted_by = NULL WHERE id = $1", employeeID)
+	if err != nil {
+		respondWithDBError(w, err, "Failed to restore employee")
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Employee restored successfully"})
+}
@@ -2,15 +2,18 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
 	"log"
 	"net/http"
 	"path/filepath"
 	"strings"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 	"github.com/juazsh/managrr/internal/utils"
 )
@@ -26,7 +29,7 @@ var allowedExtensions = map[string]bool{
 func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -36,10 +39,10 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
@@ -54,7 +57,7 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 	if isOwner {
 		contractIDParam := r.FormValue("contract_id")
 		if contractIDParam == "" {
-			respondWithError(w, http.StatusBadRequest, "contract_id is required")
+			respondWithAPIError(w, apierror.ErrContractRequired)
 			return
 		}
 
@@ -67,7 +70,7 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 		`, contractIDParam, projectID, userCtx.UserID).Scan(&exists)
 
 		if err != nil || !exists {
-			respondWithError(w, http.StatusBadRequest, "Invalid contract_id for this project")
+			respondWithAPIError(w, apierror.ErrInvalidContract)
 			return
 		}
 		contractID = contractIDParam
@@ -76,10 +79,11 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 			SELECT c.id
 			FROM contracts c
 			WHERE c.project_id = $1
-			  AND (c.contractor_id = $2
-			       OR c.contractor_id IN (
-			           SELECT contractor_id FROM employees WHERE user_id = $2
-			       ))
+			  AND c.organization_id IN (
+			           SELECT organization_id FROM organization_members WHERE user_id = $2
+			           UNION
+			           SELECT organization_id FROM employees WHERE user_id = $2 AND is_active = true AND deleted_at IS NULL
+			       )
 			LIMIT 1
 		`
 		err = db.QueryRow(contractQuery, projectID, userCtx.UserID).Scan(&contractID)
@@ -90,25 +94,25 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	file, header, err := r.FormFile("photo")
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Photo file is required")
+		respondWithAPIError(w, apierror.Invalid("photo", "Photo file is required"))
 		return
 	}
 	defer file.Close()
 
 	ext := strings.ToLower(filepath.Ext(header.Filename))
 	if !allowedExtensions[ext] {
-		respondWithError(w, http.StatusBadRequest, "Only JPG, JPEG, and PNG files are allowed")
+		respondWithAPIError(w, apierror.ErrUnsupportedImage)
 		return
 	}
 
 	if header.Size > maxFileSize {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
@@ -181,10 +185,19 @@ func UploadProjectPhoto(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusCreated, photo)
 }
 
+var projectPhotoListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"created_at": {Column: "created_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-created_at",
+	IDColumn:    "id",
+	DateColumn:  "created_at",
+}
+
 func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -194,10 +207,10 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 
@@ -206,7 +219,7 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -214,7 +227,8 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -224,29 +238,39 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
 	contractFilter := r.URL.Query().Get("contract_id")
 
-	query := `
-		SELECT id, project_id, photo_url, uploaded_by, caption, created_at
-		FROM project_photos
-		WHERE project_id = $1
-	`
+	params, err := pagination.Parse(r, projectPhotoListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
 
-	args := []interface{}{projectID}
+	from := "FROM project_photos"
+	q := &pagination.Query{}
+	q.Where("project_id = " + q.Arg(projectID))
 
 	if isContractor {
-		query += " AND contract_id = $2"
-		args = append(args, contractorContractID)
+		q.Where("contract_id = " + q.Arg(contractorContractID))
 	} else if contractFilter != "" {
-		query += " AND contract_id = $2"
-		args = append(args, contractFilter)
+		q.Where("contract_id = " + q.Arg(contractFilter))
+	}
+
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch photos")
+		return
 	}
 
-	query += " ORDER BY created_at DESC"
+	query, args := params.Select(`
+		id, project_id, photo_url, uploaded_by, caption, created_at,
+		`+params.CursorColumn(), from, q)
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -256,8 +280,10 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 	defer rows.Close()
 
 	var photos []models.ProjectPhoto
+	var cursors []string
 	for rows.Next() {
 		var photo models.ProjectPhoto
+		var cursor string
 		err := rows.Scan(
 			&photo.ID,
 			&photo.ProjectID,
@@ -265,12 +291,14 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 			&photo.UploadedBy,
 			&photo.Caption,
 			&photo.CreatedAt,
+			&cursor,
 		)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Failed to scan photo")
 			return
 		}
 		photos = append(photos, photo)
+		cursors = append(cursors, cursor)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -278,9 +306,142 @@ func GetProjectPhotos(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if photos == nil {
-		photos = []models.ProjectPhoto{}
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, photos, cursors, total))
+}
+
+// DeleteProjectPhoto removes a photo and its file. The project's owner can
+// remove any photo; everyone else only the ones they uploaded.
+func DeleteProjectPhoto(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
+	}
+
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+	photoID := vars["photoId"]
+
+	db := database.GetDB()
+
+	isOwner, apiErr := photoProjectOwner(db, projectID, userCtx.UserID)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	photoURL, apiErr := deleteProjectPhoto(db, projectID, photoID, userCtx.UserID, isOwner)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+	deleteStoredFiles(photoURL)
+
+	respondWithJSON(w, http.StatusOK, map[string]string{"message": "Photo deleted successfully"})
+}
+
+// BulkDeleteProjectPhotos removes several of a project's photos with the same
+// rules as DeleteProjectPhoto, each on its own. The response reports how each
+// went.
+func BulkDeleteProjectPhotos(w http.ResponseWriter, r *http.Request) {
+	userCtx, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
+		return
 	}
 
-	respondWithJSON(w, http.StatusOK, photos)
+	vars := mux.Vars(r)
+	projectID := vars["id"]
+
+	var req models.BulkDeletePhotosRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithAPIError(w, apierror.ErrInvalidRequestBody)
+		return
+	}
+	if apiErr := validateBulkIDs("photo_ids", req.PhotoIDs); apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	db := database.GetDB()
+
+	isOwner, apiErr := photoProjectOwner(db, projectID, userCtx.UserID)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
+		return
+	}
+
+	results := newBulkResults(len(req.PhotoIDs))
+	var photoURLs []string
+	for i, photoID := range req.PhotoIDs {
+		photoURL, apiErr := deleteProjectPhoto(db, projectID, photoID, userCtx.UserID, isOwner)
+		if apiErr != nil {
+			results.fail(i, photoID, apiErr)
+			continue
+		}
+		photoURLs = append(photoURLs, photoURL)
+		results.succeed(i, photoID, http.StatusOK)
+	}
+	deleteStoredFiles(photoURLs...)
+
+	results.respond(w)
+}
+
+// photoProjectOwner reports whether the user is the project's owner.
+func photoProjectOwner(db *sql.DB, projectID, userID string) (bool, *apierror.Error) {
+	var ownerID string
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		return false, apierror.ErrProjectNotFound
+	}
+	if err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return false, apiErr
+		}
+		return false, apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch project")
+	}
+	return ownerID == userID, nil
+}
+
+// deleteProjectPhoto removes a photo's row and returns the URL of its file,
+// which the caller deletes once nothing refers to it.
+func deleteProjectPhoto(db *sql.DB, projectID, photoID, userID string, isOwner bool) (string, *apierror.Error) {
+	var photoURL, uploadedBy string
+	err := db.QueryRow(`
+		SELECT photo_url, uploaded_by FROM project_photos
+		WHERE id = $1 AND project_id = $2
+	`, photoID, projectID).Scan(&photoURL, &uploadedBy)
+	if err == sql.ErrNoRows {
+		return "", apierror.ErrPhotoNotFound
+	}
+	if err != nil {
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return "", apiErr
+		}
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to fetch photo")
+	}
+
+	if !isOwner && uploadedBy != userID {
+		return "", apierror.ErrAccessDenied.WithMessage("You can only delete photos you uploaded")
+	}
+
+	if _, err := db.Exec("DELETE FROM project_photos WHERE id = $1", photoID); err != nil {
+		log.Printf("ERROR deleteProjectPhoto: Failed to delete photo %s: %v", photoID, err)
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to delete photo")
+	}
+	return photoURL, nil
+}
+
+// deleteStoredFiles removes uploaded files from storage once nothing refers to
+// them. Failures are only logged.
+func deleteStoredFiles(urls ...string) {
+	if len(urls) == 0 {
+		return
+	}
+	store := storage.NewSupabaseStorage()
+	for _, url := range urls {
+		if err := store.DeleteFile(url); err != nil {
+			log.Printf("ERROR deleteStoredFiles: failed to delete %s: %v", url, err)
+		}
+	}
 }
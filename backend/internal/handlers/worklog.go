@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"database/sql"
-	"fmt"
 	"log"
 	"net/http"
 	"path/filepath"
@@ -11,16 +10,18 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
 	"github.com/juazsh/managrr/internal/database"
 	"github.com/juazsh/managrr/internal/middleware"
 	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/pagination"
 	"github.com/juazsh/managrr/internal/storage"
 )
 
 func CheckIn(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -30,90 +31,61 @@ func CheckIn(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	projectIDStr := r.FormValue("project_id")
 	if projectIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "project_id is required")
+		respondWithAPIError(w, apierror.Invalid("project_id", "project_id is required"))
 		return
 	}
 
-	projectID, err := strconv.Atoi(projectIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid project_id")
+	if _, err := strconv.Atoi(projectIDStr); err != nil {
+		respondWithAPIError(w, apierror.Invalid("project_id", "Invalid project_id"))
 		return
 	}
 
 	file, header, err := r.FormFile("photo")
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Photo file is required")
+		respondWithAPIError(w, apierror.Invalid("photo", "Photo file is required"))
 		return
 	}
 	defer file.Close()
 
 	ext := strings.ToLower(filepath.Ext(header.Filename))
 	if !allowedExtensions[ext] {
-		respondWithError(w, http.StatusBadRequest, "Only JPG, JPEG, and PNG files are allowed")
+		respondWithAPIError(w, apierror.ErrUnsupportedImage)
 		return
 	}
 
 	if header.Size > maxFileSize {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	db := database.GetDB()
 
-	var assigned bool
-	err = db.QueryRow(`
-		SELECT EXISTS(
-			SELECT 1 FROM employee_projects 
-			WHERE employee_id = $1 AND project_id = $2
-		)
-	`, userCtx.UserID, projectID).Scan(&assigned)
-
-	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to verify project assignment")
-		return
-	}
-
-	if !assigned {
-		respondWithError(w, http.StatusForbidden, "You are not assigned to this project")
+	contractID, apiErr := checkInContract(db, userCtx.UserID, projectIDStr)
+	if apiErr != nil {
+		respondWithAPIError(w, apiErr)
 		return
 	}
 
-	var contractID string
-	err = db.QueryRow(`
-		SELECT c.id
-		FROM contracts c
-		JOIN employees e ON c.contractor_id = e.contractor_id
-		WHERE e.user_id = $1 AND c.project_id = $2
-		LIMIT 1
-	`, userCtx.UserID, projectID).Scan(&contractID)
-
+	timeZone, err := projectTimeZone(db, projectIDStr)
 	if err != nil {
-		log.Printf("ERROR: Failed to determine contract_id for employee: %v", err)
-		respondWithError(w, http.StatusInternalServerError, "Failed to determine contract")
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
 		return
 	}
 
-	var hasActiveCheckIn bool
-	err = db.QueryRow(`
-		SELECT EXISTS(
-			SELECT 1 FROM work_logs 
-			WHERE employee_id = $1 AND project_id = $2 AND check_out_time IS NULL
-		)
-	`, userCtx.UserID, projectID).Scan(&hasActiveCheckIn)
-
+	hasActiveCheckIn, err := hasOpenWorkLog(db, userCtx.UserID, projectIDStr)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to check active work log")
 		return
 	}
 
 	if hasActiveCheckIn {
-		respondWithError(w, http.StatusBadRequest, "You are already checked in to this project")
+		respondWithAPIError(w, apierror.ErrAlreadyCheckedIn)
 		return
 	}
 
@@ -136,48 +108,12 @@ func CheckIn(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var workLog models.WorkLog
-	query := `
-		INSERT INTO work_logs (
-			employee_id, project_id, check_in_time, check_in_photo_url,
-			check_in_latitude, check_in_longitude, contract_id, created_at
-		)
-		VALUES ($1, $2, NOW(), $3, $4, $5, $6, NOW())
-		RETURNING id, employee_id, project_id, check_in_time, check_out_time,
-		          check_in_photo_url, check_out_photo_url,
-		          check_in_latitude, check_in_longitude,
-		          check_out_latitude, check_out_longitude,
-		          hours_worked, created_at
-	`
-
-	err = db.QueryRow(
-		query,
-		userCtx.UserID,
-		projectID,
-		photoURL,
-		latitude,
-		longitude,
-		contractID,
-	).Scan(
-		&workLog.ID,
-		&workLog.EmployeeID,
-		&workLog.ProjectID,
-		&workLog.CheckInTime,
-		&workLog.CheckOutTime,
-		&workLog.CheckInPhotoURL,
-		&workLog.CheckOutPhotoURL,
-		&workLog.CheckInLatitude,
-		&workLog.CheckInLongitude,
-		&workLog.CheckOutLatitude,
-		&workLog.CheckOutLongitude,
-		&workLog.HoursWorked,
-		&workLog.CreatedAt,
-	)
-
+	workLog, err := insertWorkLog(db, userCtx.UserID, projectIDStr, contractID, photoURL, latitude, longitude, nil)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to create work log")
 		return
 	}
+	workLog.WorkDate = timeZone.Today(workLog.CheckInTime)
 
 	respondWithJSON(w, http.StatusCreated, workLog)
 }
@@ -185,7 +121,7 @@ func CheckIn(w http.ResponseWriter, r *http.Request) {
 func CheckOut(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -195,69 +131,44 @@ func CheckOut(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseMultipartForm(maxFileSize); err != nil {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	workLogIDStr := r.FormValue("work_log_id")
 	if workLogIDStr == "" {
-		respondWithError(w, http.StatusBadRequest, "work_log_id is required")
+		respondWithAPIError(w, apierror.Invalid("work_log_id", "work_log_id is required"))
 		return
 	}
 
-	workLogID, err := strconv.Atoi(workLogIDStr)
-	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Invalid work_log_id")
+	if _, err := strconv.Atoi(workLogIDStr); err != nil {
+		respondWithAPIError(w, apierror.Invalid("work_log_id", "Invalid work_log_id"))
 		return
 	}
 
 	file, header, err := r.FormFile("photo")
 	if err != nil {
-		respondWithError(w, http.StatusBadRequest, "Photo file is required")
+		respondWithAPIError(w, apierror.Invalid("photo", "Photo file is required"))
 		return
 	}
 	defer file.Close()
 
 	ext := strings.ToLower(filepath.Ext(header.Filename))
 	if !allowedExtensions[ext] {
-		respondWithError(w, http.StatusBadRequest, "Only JPG, JPEG, and PNG files are allowed")
+		respondWithAPIError(w, apierror.ErrUnsupportedImage)
 		return
 	}
 
 	if header.Size > maxFileSize {
-		respondWithError(w, http.StatusBadRequest, "File too large. Maximum size is 5MB")
+		respondWithAPIError(w, apierror.ErrFileTooLarge)
 		return
 	}
 
 	db := database.GetDB()
 
-	var workLog models.WorkLog
-	err = db.QueryRow(`
-		SELECT id, employee_id, project_id, check_in_time, check_out_time,
-		       check_in_photo_url, check_out_photo_url,
-		       check_in_latitude, check_in_longitude,
-		       check_out_latitude, check_out_longitude,
-		       hours_worked, created_at
-		FROM work_logs
-		WHERE id = $1
-	`, workLogID).Scan(
-		&workLog.ID,
-		&workLog.EmployeeID,
-		&workLog.ProjectID,
-		&workLog.CheckInTime,
-		&workLog.CheckOutTime,
-		&workLog.CheckInPhotoURL,
-		&workLog.CheckOutPhotoURL,
-		&workLog.CheckInLatitude,
-		&workLog.CheckInLongitude,
-		&workLog.CheckOutLatitude,
-		&workLog.CheckOutLongitude,
-		&workLog.HoursWorked,
-		&workLog.CreatedAt,
-	)
-
+	workLog, err := getWorkLog(db, workLogIDStr)
 	if err != nil {
-		respondWithError(w, http.StatusNotFound, "Work log not found")
+		respondWithAPIError(w, apierror.ErrWorkLogNotFound)
 		return
 	}
 
@@ -267,7 +178,13 @@ func CheckOut(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if workLog.CheckOutTime != nil {
-		respondWithError(w, http.StatusBadRequest, "Already checked out from this work log")
+		respondWithAPIError(w, apierror.ErrAlreadyCheckedOut)
+		return
+	}
+
+	timeZone, err := projectTimeZone(db, workLog.ProjectID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project")
 		return
 	}
 
@@ -290,28 +207,27 @@ func CheckOut(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	query := `
-		UPDATE work_logs
-		SET check_out_time = NOW(),
-		    check_out_photo_url = $1,
-		    check_out_latitude = $2,
-		    check_out_longitude = $3,
-		    hours_worked = ROUND(EXTRACT(EPOCH FROM (NOW() - check_in_time)) / 3600, 2)
-		WHERE id = $4
-		RETURNING id, employee_id, project_id, check_in_time, check_out_time,
-		          check_in_photo_url, check_out_photo_url,
-		          check_in_latitude, check_in_longitude,
-		          check_out_latitude, check_out_longitude,
-		          hours_worked, created_at
-	`
+	workLog, err = closeWorkLog(db, workLogIDStr, photoURL, latitude, longitude, nil)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to check out")
+		return
+	}
+	workLog.WorkDate = timeZone.Today(workLog.CheckInTime)
 
-	err = db.QueryRow(
-		query,
-		photoURL,
-		latitude,
-		longitude,
-		workLogID,
-	).Scan(
+	respondWithJSON(w, http.StatusOK, workLog)
+}
+
+// workLogColumns are the work_logs columns read by scanWorkLog. work_date is
+// filled in by the caller, since it depends on the project's time zone.
+const workLogColumns = `id, employee_id, project_id, check_in_time, check_out_time,
+	check_in_photo_url, check_out_photo_url,
+	check_in_latitude, check_in_longitude,
+	check_out_latitude, check_out_longitude,
+	hours_worked, created_at`
+
+func scanWorkLog(row rowScanner) (models.WorkLog, error) {
+	var workLog models.WorkLog
+	err := row.Scan(
 		&workLog.ID,
 		&workLog.EmployeeID,
 		&workLog.ProjectID,
@@ -326,92 +242,173 @@ func CheckOut(w http.ResponseWriter, r *http.Request) {
 		&workLog.HoursWorked,
 		&workLog.CreatedAt,
 	)
+	return workLog, err
+}
+
+func getWorkLog(q queryRower, workLogID string) (models.WorkLog, error) {
+	return scanWorkLog(q.QueryRow("SELECT "+workLogColumns+" FROM work_logs WHERE id = $1", workLogID))
+}
+
+// checkInContract checks that the employee is assigned to the project and
+// returns the contract their hours are logged against.
+func checkInContract(q queryRower, userID, projectID string) (string, *apierror.Error) {
+	var assigned bool
+	err := q.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM employee_projects ep
+			JOIN projects p ON p.id = ep.project_id
+			WHERE ep.employee_id = $1 AND ep.project_id = $2 AND p.deleted_at IS NULL
+		)
+	`, userID, projectID).Scan(&assigned)
 
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to check out")
-		return
+		if apiErr := apierror.FromDB(err); apiErr != nil {
+			return "", apiErr
+		}
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to verify project assignment")
 	}
 
-	respondWithJSON(w, http.StatusOK, workLog)
+	if !assigned {
+		return "", apierror.ErrNotAssigned
+	}
+
+	var contractID string
+	err = q.QueryRow(`
+		SELECT c.id
+		FROM contracts c
+		JOIN employees e ON c.organization_id = e.organization_id
+		WHERE e.user_id = $1 AND e.is_active = true AND e.deleted_at IS NULL AND c.project_id = $2
+		  AND c.status != 'terminated'
+		LIMIT 1
+	`, userID, projectID).Scan(&contractID)
+
+	if err != nil {
+		log.Printf("ERROR: Failed to determine contract_id for employee: %v", err)
+		return "", apierror.FromStatus(http.StatusInternalServerError, "Failed to determine contract")
+	}
+	return contractID, nil
+}
+
+func hasOpenWorkLog(q queryRower, userID, projectID string) (bool, error) {
+	var open bool
+	err := q.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM work_logs 
+			WHERE employee_id = $1 AND project_id = $2 AND check_out_time IS NULL
+		)
+	`, userID, projectID).Scan(&open)
+	return open, err
+}
+
+// insertWorkLog records a check-in at the given time, or now if at is nil.
+func insertWorkLog(q queryRower, userID, projectID, contractID, photoURL string, latitude, longitude *float64, at *time.Time) (models.WorkLog, error) {
+	query := `
+		INSERT INTO work_logs (
+			employee_id, project_id, check_in_time, check_in_photo_url,
+			check_in_latitude, check_in_longitude, contract_id, created_at
+		)
+		VALUES ($1, $2, COALESCE($7::timestamptz, NOW()), $3, $4, $5, $6, NOW())
+		RETURNING ` + workLogColumns
+
+	return scanWorkLog(q.QueryRow(query, userID, projectID, photoURL, latitude, longitude, contractID, at))
+}
+
+// closeWorkLog records a check-out at the given time, or now if at is nil.
+func closeWorkLog(q queryRower, workLogID, photoURL string, latitude, longitude *float64, at *time.Time) (models.WorkLog, error) {
+	// Both times are instants, so the hours are right even when the shift
+	// spans midnight or a daylight saving change in the project's zone.
+	query := `
+		UPDATE work_logs
+		SET check_out_time = COALESCE($5::timestamptz, NOW()),
+		    check_out_photo_url = $1,
+		    check_out_latitude = $2,
+		    check_out_longitude = $3,
+		    hours_worked = ROUND(EXTRACT(EPOCH FROM (COALESCE($5::timestamptz, NOW()) - check_in_time)) / 3600, 2)
+		WHERE id = $4
+		RETURNING ` + workLogColumns
+
+	return scanWorkLog(q.QueryRow(query, photoURL, latitude, longitude, workLogID, at))
+}
+
+// workLogDate is the day a work log's check-in falls on in its project's time
+// zone. Lists are filtered by it so from/to cover whole local days.
+const workLogDate = "(wl.check_in_time AT TIME ZONE p.time_zone)::date"
+
+var workLogListSpec = pagination.Spec{
+	Sorts: map[string]pagination.SortField{
+		"check_in_time": {Column: "wl.check_in_time", Type: "timestamptz"},
+		"created_at":    {Column: "wl.created_at", Type: "timestamptz"},
+	},
+	DefaultSort: "-check_in_time",
+	IDColumn:    "wl.id",
+	DateColumn:  workLogDate,
+	Filters: map[string]string{
+		"project_id":  "wl.project_id",
+		"employee_id": "wl.employee_id",
+	},
+}
+
+// workLogsOfOrganization limits the work logs aliased as alias to those
+// recorded against a contract of the organization orgArg stands for.
+func workLogsOfOrganization(alias, orgArg string) string {
+	return alias + ".contract_id IN (SELECT id FROM contracts WHERE organization_id = " + orgArg + ")"
 }
 
 func ListWorkLogs(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
-	projectID := r.URL.Query().Get("project_id")
-	employeeID := r.URL.Query().Get("employee_id")
-	startDate := r.URL.Query().Get("start_date")
-	endDate := r.URL.Query().Get("end_date")
+	params, err := pagination.Parse(r, workLogListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
 
 	db := database.GetDB()
 
-	var query string
-	var args []interface{}
-	argCount := 0
-
-	baseQuery := `
-		SELECT wl.id, wl.employee_id, wl.project_id, wl.check_in_time, wl.check_out_time,
-		       wl.check_in_photo_url, wl.check_out_photo_url,
-		       wl.check_in_latitude, wl.check_in_longitude,
-		       wl.check_out_latitude, wl.check_out_longitude,
-		       wl.hours_worked, wl.created_at,
-		       u.name as employee_name, p.title as project_name
+	from := `
 		FROM work_logs wl
 		JOIN users u ON wl.employee_id = u.id
 		JOIN projects p ON wl.project_id = p.id
 	`
-
-	var whereClauses []string
+	q := &pagination.Query{}
+	q.Where("p.deleted_at IS NULL")
 
 	if userCtx.UserType == string(models.UserTypeEmployee) {
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("wl.employee_id = $%d", argCount))
-		args = append(args, userCtx.UserID)
+		q.Where("wl.employee_id = " + q.Arg(userCtx.UserID))
 	} else if userCtx.UserType == string(models.UserTypeContractor) {
-		baseQuery += " JOIN employees e ON wl.employee_id = e.user_id"
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("e.contractor_id = $%d", argCount))
-		args = append(args, userCtx.UserID)
+		orgArg := q.Arg(userCtx.OrganizationID)
+		from += " JOIN employees e ON wl.employee_id = e.user_id"
+		q.Where("e.organization_id = " + orgArg)
+		q.Where("e.deleted_at IS NULL")
+		// Crew members may also work for other organizations; only hours
+		// logged against this organization's contracts are its business.
+		q.Where(workLogsOfOrganization("wl", orgArg))
 	} else {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
-	if projectID != "" {
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("wl.project_id = $%d", argCount))
-		args = append(args, projectID)
-	}
-
-	if employeeID != "" && userCtx.UserType == string(models.UserTypeContractor) {
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("wl.employee_id = $%d", argCount))
-		args = append(args, employeeID)
-	}
-
-	if startDate != "" {
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("wl.check_in_time >= $%d", argCount))
-		args = append(args, startDate)
-	}
-
-	if endDate != "" {
-		argCount++
-		whereClauses = append(whereClauses, fmt.Sprintf("wl.check_in_time <= $%d", argCount))
-		args = append(args, endDate+" 23:59:59")
-	}
+	params.Filter(q)
 
-	if len(whereClauses) > 0 {
-		query = baseQuery + " WHERE " + strings.Join(whereClauses, " AND ")
-	} else {
-		query = baseQuery
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		log.Printf("ERROR ListWorkLogs: Failed to count work logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch work logs")
+		return
 	}
 
-	query += " ORDER BY wl.check_in_time DESC"
+	query, args := params.Select(`
+		wl.id, wl.employee_id, wl.project_id, wl.check_in_time, `+workLogDate+`::text, wl.check_out_time,
+		wl.check_in_photo_url, wl.check_out_photo_url,
+		wl.check_in_latitude, wl.check_in_longitude,
+		wl.check_out_latitude, wl.check_out_longitude,
+		wl.hours_worked, wl.created_at,
+		u.name as employee_name, p.title as project_name,
+		`+params.CursorColumn(), from, q)
 
 	log.Printf("INFO ListWorkLogs: Executing query with %d args", len(args))
 
@@ -430,13 +427,16 @@ func ListWorkLogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var workLogs []WorkLogResponse
+	var cursors []string
 	for rows.Next() {
 		var wl WorkLogResponse
+		var cursor string
 		err := rows.Scan(
 			&wl.ID,
 			&wl.EmployeeID,
 			&wl.ProjectID,
 			&wl.CheckInTime,
+			&wl.WorkDate,
 			&wl.CheckOutTime,
 			&wl.CheckInPhotoURL,
 			&wl.CheckOutPhotoURL,
@@ -448,6 +448,7 @@ func ListWorkLogs(w http.ResponseWriter, r *http.Request) {
 			&wl.CreatedAt,
 			&wl.EmployeeName,
 			&wl.ProjectName,
+			&cursor,
 		)
 		if err != nil {
 			log.Printf("ERROR ListWorkLogs: Failed to scan work log: %v", err)
@@ -455,19 +456,21 @@ func ListWorkLogs(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		workLogs = append(workLogs, wl)
+		cursors = append(cursors, cursor)
 	}
 
-	if workLogs == nil {
-		workLogs = []WorkLogResponse{}
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating work logs")
+		return
 	}
 
-	respondWithJSON(w, http.StatusOK, workLogs)
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, workLogs, cursors, total))
 }
 
 func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -478,9 +481,9 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	var ownerID string
-	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1", projectID).Scan(&ownerID)
+	err := db.QueryRow("SELECT owner_id FROM projects WHERE id = $1 AND deleted_at IS NULL", projectID).Scan(&ownerID)
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Project not found")
+		respondWithAPIError(w, apierror.ErrProjectNotFound)
 		return
 	}
 	if err != nil {
@@ -488,7 +491,7 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	isOwner := ownerID == userCtx.UserID
+	isOwner := ownerID == userCtx.UserID || isProjectMember(db, projectID, userCtx.UserID)
 
 	var isContractor bool
 	var contractorContractID string
@@ -496,7 +499,8 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 		err = db.QueryRow(`
 			SELECT c.id
 			FROM contracts c
-			WHERE c.project_id = $1 AND c.contractor_id = $2
+			WHERE c.project_id = $1
+			  AND c.organization_id = (SELECT organization_id FROM organization_members WHERE user_id = $2)
 			LIMIT 1
 		`, projectID, userCtx.UserID).Scan(&contractorContractID)
 
@@ -505,39 +509,57 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if !isOwner && !isContractor && userCtx.UserType == string(models.UserTypeEmployee) {
+		foreman, err := foremanAccess(db, projectID, userCtx.UserID)
+		if err == nil && foreman.CanViewCrewHours {
+			isContractor = true
+			contractorContractID = foreman.ContractID
+		}
+	}
+
 	if !isOwner && !isContractor {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
-	query := `
-		SELECT wl.id, wl.employee_id, wl.project_id, wl.check_in_time, wl.check_out_time,
-		       wl.check_in_photo_url, wl.check_out_photo_url,
-		       wl.check_in_latitude, wl.check_in_longitude,
-		       wl.check_out_latitude, wl.check_out_longitude,
-		       wl.hours_worked, wl.created_at,
-		       u.name as employee_name, p.title as project_name
+	params, err := pagination.Parse(r, workLogListSpec)
+	if err != nil {
+		respondWithAPIError(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidQuery, err.Error()))
+		return
+	}
+
+	from := `
 		FROM work_logs wl
 		JOIN users u ON wl.employee_id = u.id
 		JOIN projects p ON wl.project_id = p.id
 	`
-
-	args := []interface{}{projectID}
-	argIndex := 2
-
-	query += ` WHERE wl.project_id = $1`
+	q := &pagination.Query{}
+	q.Where("wl.project_id = " + q.Arg(projectID))
+	q.Where("p.deleted_at IS NULL")
 
 	if isContractor {
-		query += " AND wl.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractorContractID)
-		argIndex++
+		q.Where("wl.contract_id = " + q.Arg(contractorContractID))
 	} else if contractFilter != "" {
-		query += " AND wl.contract_id = $" + strconv.Itoa(argIndex)
-		args = append(args, contractFilter)
-		argIndex++
+		q.Where("wl.contract_id = " + q.Arg(contractFilter))
+	}
+
+	params.Filter(q)
+
+	total, err := params.Count(db, from, q)
+	if err != nil {
+		log.Printf("ERROR GetProjectWorkLogs: Failed to count work logs: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch work logs")
+		return
 	}
 
-	query += ` ORDER BY wl.check_in_time DESC`
+	query, args := params.Select(`
+		wl.id, wl.employee_id, wl.project_id, wl.check_in_time, `+workLogDate+`::text, wl.check_out_time,
+		wl.check_in_photo_url, wl.check_out_photo_url,
+		wl.check_in_latitude, wl.check_in_longitude,
+		wl.check_out_latitude, wl.check_out_longitude,
+		wl.hours_worked, wl.approved_by, wl.approved_at, wl.created_at,
+		u.name as employee_name, p.title as project_name,
+		`+params.CursorColumn(), from, q)
 
 	rows, err := db.Query(query, args...)
 	if err != nil {
@@ -553,13 +575,16 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var workLogs []WorkLogResponse
+	var cursors []string
 	for rows.Next() {
 		var wl WorkLogResponse
+		var cursor string
 		err := rows.Scan(
 			&wl.ID,
 			&wl.EmployeeID,
 			&wl.ProjectID,
 			&wl.CheckInTime,
+			&wl.WorkDate,
 			&wl.CheckOutTime,
 			&wl.CheckInPhotoURL,
 			&wl.CheckOutPhotoURL,
@@ -568,28 +593,33 @@ func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
 			&wl.CheckOutLatitude,
 			&wl.CheckOutLongitude,
 			&wl.HoursWorked,
+			&wl.ApprovedBy,
+			&wl.ApprovedAt,
 			&wl.CreatedAt,
 			&wl.EmployeeName,
 			&wl.ProjectName,
+			&cursor,
 		)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, "Failed to scan work log")
 			return
 		}
 		workLogs = append(workLogs, wl)
+		cursors = append(cursors, cursor)
 	}
 
-	if workLogs == nil {
-		workLogs = []WorkLogResponse{}
+	if err = rows.Err(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Error iterating work logs")
+		return
 	}
 
-	respondWithJSON(w, http.StatusOK, workLogs)
+	respondWithJSON(w, http.StatusOK, pagination.NewPage(params, workLogs, cursors, total))
 }
 
 func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -599,7 +629,7 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 	db := database.GetDB()
 
 	query := `
-		SELECT wl.id, wl.employee_id, wl.project_id, wl.check_in_time, wl.check_out_time,
+		SELECT wl.id, wl.employee_id, wl.project_id, wl.check_in_time, ` + workLogDate + `::text, wl.check_out_time,
 		       wl.check_in_photo_url, wl.check_out_photo_url,
 		       wl.check_in_latitude, wl.check_in_longitude,
 		       wl.check_out_latitude, wl.check_out_longitude,
@@ -608,7 +638,7 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 		FROM work_logs wl
 		JOIN users u ON wl.employee_id = u.id
 		JOIN projects p ON wl.project_id = p.id
-		WHERE wl.id = $1
+		WHERE wl.id = $1 AND p.deleted_at IS NULL
 	`
 
 	type WorkLogDetailResponse struct {
@@ -623,6 +653,7 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 		&wl.EmployeeID,
 		&wl.ProjectID,
 		&wl.CheckInTime,
+		&wl.WorkDate,
 		&wl.CheckOutTime,
 		&wl.CheckInPhotoURL,
 		&wl.CheckOutPhotoURL,
@@ -637,7 +668,7 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 	)
 
 	if err == sql.ErrNoRows {
-		respondWithError(w, http.StatusNotFound, "Work log not found")
+		respondWithAPIError(w, apierror.ErrWorkLogNotFound)
 		return
 	}
 	if err != nil {
@@ -649,22 +680,25 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 		var hasAccess bool
 		err := db.QueryRow(`
 			SELECT EXISTS(
-				SELECT 1 FROM employees e 
-				WHERE e.contractor_id = $1 AND e.user_id = $2
+				SELECT 1 FROM employees e
+				WHERE e.organization_id = $1 AND e.user_id = $2 AND e.deleted_at IS NULL
+			) AND EXISTS(
+				SELECT 1 FROM work_logs wl
+				WHERE wl.id = $3 AND `+workLogsOfOrganization("wl", "$1")+`
 			)
-		`, userCtx.UserID, wl.EmployeeID).Scan(&hasAccess)
+		`, userCtx.OrganizationID, wl.EmployeeID, wl.ID).Scan(&hasAccess)
 
 		if err != nil || !hasAccess {
-			respondWithError(w, http.StatusForbidden, "Access denied")
+			respondWithAPIError(w, apierror.ErrAccessDenied)
 			return
 		}
 	} else if userCtx.UserType == string(models.UserTypeEmployee) {
 		if wl.EmployeeID != userCtx.UserID {
-			respondWithError(w, http.StatusForbidden, "Access denied")
+			respondWithAPIError(w, apierror.ErrAccessDenied)
 			return
 		}
 	} else {
-		respondWithError(w, http.StatusForbidden, "Access denied")
+		respondWithAPIError(w, apierror.ErrAccessDenied)
 		return
 	}
 
@@ -674,7 +708,7 @@ func GetWorkLogDetail(w http.ResponseWriter, r *http.Request) {
 func GetWeeklySummary(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -685,16 +719,23 @@ func GetWeeklySummary(w http.ResponseWriter, r *http.Request) {
 
 	db := database.GetDB()
 
-	weekStart := time.Now().AddDate(0, 0, -int(time.Now().Weekday()))
-	weekStart = time.Date(weekStart.Year(), weekStart.Month(), weekStart.Day(), 0, 0, 0, 0, weekStart.Location())
+	// The week runs from Sunday in the contractor's own zone, since it spans
+	// every project their crews work on.
+	timeZone, err := userTimeZone(db, userCtx.UserID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to fetch weekly summary")
+		return
+	}
+	weekStart := timeZone.StartOfWeek(time.Now())
 
 	var totalHours float64
-	err := db.QueryRow(`
+	err = db.QueryRow(`
 		SELECT COALESCE(SUM(wl.hours_worked), 0)
 		FROM work_logs wl
 		JOIN employees e ON wl.employee_id = e.user_id
-		WHERE e.contractor_id = $1 AND wl.check_in_time >= $2
-	`, userCtx.UserID, weekStart).Scan(&totalHours)
+		WHERE e.organization_id = $1 AND e.deleted_at IS NULL AND wl.check_in_time >= $2
+		  AND `+workLogsOfOrganization("wl", "$1")+`
+	`, userCtx.OrganizationID, weekStart).Scan(&totalHours)
 
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch weekly summary")
@@ -704,6 +745,7 @@ func GetWeeklySummary(w http.ResponseWriter, r *http.Request) {
 	summary := map[string]interface{}{
 		"total_hours": totalHours,
 		"week_start":  weekStart,
+		"time_zone":   timeZone,
 	}
 
 	respondWithJSON(w, http.StatusOK, summary)
@@ -712,7 +754,7 @@ func GetWeeklySummary(w http.ResponseWriter, r *http.Request) {
 func GetSummaryByEmployee(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -728,13 +770,13 @@ func GetSummaryByEmployee(w http.ResponseWriter, r *http.Request) {
 		       COALESCE(SUM(wl.hours_worked), 0) as total_hours
 		FROM employees e
 		JOIN users u ON e.user_id = u.id
-		LEFT JOIN work_logs wl ON wl.employee_id = e.user_id
-		WHERE e.contractor_id = $1 AND e.is_active = true
+		LEFT JOIN work_logs wl ON wl.employee_id = e.user_id AND ` + workLogsOfOrganization("wl", "$1") + `
+		WHERE e.organization_id = $1 AND e.is_active = true AND e.deleted_at IS NULL
 		GROUP BY e.user_id, u.name
 		ORDER BY total_hours DESC
 	`
 
-	rows, err := db.Query(query, userCtx.UserID)
+	rows, err := db.Query(query, userCtx.OrganizationID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch employee summary")
 		return
@@ -768,7 +810,7 @@ func GetSummaryByEmployee(w http.ResponseWriter, r *http.Request) {
 func GetSummaryByProject(w http.ResponseWriter, r *http.Request) {
 	userCtx, ok := middleware.GetUserFromContext(r.Context())
 	if !ok {
-		respondWithError(w, http.StatusUnauthorized, "User not found in context")
+		respondWithAPIError(w, apierror.ErrUnauthenticated)
 		return
 	}
 
@@ -783,13 +825,14 @@ func GetSummaryByProject(w http.ResponseWriter, r *http.Request) {
 		SELECT p.id as project_id, p.title as project_title, 
 		       COALESCE(SUM(wl.hours_worked), 0) as total_hours
 		FROM projects p
-		LEFT JOIN work_logs wl ON wl.project_id = p.id
-		WHERE p.contractor_id = $1
+		JOIN contracts c ON c.project_id = p.id AND c.status != 'terminated'
+		LEFT JOIN work_logs wl ON wl.project_id = p.id AND wl.contract_id = c.id
+		WHERE c.organization_id = $1 AND p.deleted_at IS NULL
 		GROUP BY p.id, p.title
 		ORDER BY total_hours DESC
 	`
 
-	rows, err := db.Query(query, userCtx.UserID)
+	rows, err := db.Query(query, userCtx.OrganizationID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch project summary")
 		return
@@ -819,106 +862,3 @@ func GetSummaryByProject(w http.ResponseWriter, r *http.Request) {
 
 	respondWithJSON(w, http.StatusOK, summaries)
 }
-
-// func GetProjectWorkLogs(w http.ResponseWriter, r *http.Request) {
-// 	userCtx, ok := middleware.GetUserFromContext(r.Context())
-// 	if !ok {
-// 		respondWithError(w, http.StatusUnauthorized, "User not found in context")
-// 		return
-// 	}
-
-// 	vars := mux.Vars(r)
-// 	projectID := vars["id"]
-
-// 	db := database.GetDB()
-
-// 	var hasAccess bool
-// 	if userCtx.UserType == string(models.UserTypeHouseOwner) {
-// 		err := db.QueryRow(`
-// 			SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1 AND owner_id = $2)
-// 		`, projectID, userCtx.UserID).Scan(&hasAccess)
-// 		if err != nil || !hasAccess {
-// 			respondWithError(w, http.StatusForbidden, "Access denied")
-// 			return
-// 		}
-// 	} else if userCtx.UserType == string(models.UserTypeContractor) {
-// 		err := db.QueryRow(`
-// 			SELECT EXISTS(SELECT 1 FROM projects WHERE id = $1 AND contractor_id = $2)
-// 		`, projectID, userCtx.UserID).Scan(&hasAccess)
-// 		if err != nil || !hasAccess {
-// 			respondWithError(w, http.StatusForbidden, "Access denied")
-// 			return
-// 		}
-// 	} else if userCtx.UserType == string(models.UserTypeEmployee) {
-// 		err := db.QueryRow(`
-// 			SELECT EXISTS(SELECT 1 FROM employee_projects WHERE project_id = $1 AND employee_id = $2)
-// 		`, projectID, userCtx.UserID).Scan(&hasAccess)
-// 		if err != nil || !hasAccess {
-// 			respondWithError(w, http.StatusForbidden, "Access denied")
-// 			return
-// 		}
-// 	} else {
-// 		respondWithError(w, http.StatusForbidden, "Access denied")
-// 		return
-// 	}
-
-// 	query := `
-// 		SELECT wl.id, wl.employee_id, wl.project_id, wl.check_in_time, wl.check_out_time,
-// 		       wl.check_in_photo_url, wl.check_out_photo_url,
-// 		       wl.check_in_latitude, wl.check_in_longitude,
-// 		       wl.check_out_latitude, wl.check_out_longitude,
-// 		       wl.hours_worked, wl.created_at,
-// 		       u.name as employee_name, p.title as project_name
-// 		FROM work_logs wl
-// 		JOIN users u ON wl.employee_id = u.id
-// 		JOIN projects p ON wl.project_id = p.id
-// 		WHERE wl.project_id = $1
-// 		ORDER BY wl.check_in_time DESC
-// 	`
-
-// 	rows, err := db.Query(query, projectID)
-// 	if err != nil {
-// 		respondWithError(w, http.StatusInternalServerError, "Failed to fetch work logs")
-// 		return
-// 	}
-// 	defer rows.Close()
-
-// 	type WorkLogResponse struct {
-// 		models.WorkLog
-// 		EmployeeName string `json:"employee_name"`
-// 		ProjectName  string `json:"project_name"`
-// 	}
-
-// 	var workLogs []WorkLogResponse
-// 	for rows.Next() {
-// 		var wl WorkLogResponse
-// 		err := rows.Scan(
-// 			&wl.ID,
-// 			&wl.EmployeeID,
-// 			&wl.ProjectID,
-// 			&wl.CheckInTime,
-// 			&wl.CheckOutTime,
-// 			&wl.CheckInPhotoURL,
-// 			&wl.CheckOutPhotoURL,
-// 			&wl.CheckInLatitude,
-// 			&wl.CheckInLongitude,
-// 			&wl.CheckOutLatitude,
-// 			&wl.CheckOutLongitude,
-// 			&wl.HoursWorked,
-// 			&wl.CreatedAt,
-// 			&wl.EmployeeName,
-// 			&wl.ProjectName,
-// 		)
-// 		if err != nil {
-// 			respondWithError(w, http.StatusInternalServerError, "Failed to scan work log")
-// 			return
-// 		}
-// 		workLogs = append(workLogs, wl)
-// 	}
-
-// 	if workLogs == nil {
-// 		workLogs = []WorkLogResponse{}
-// 	}
-
-// 	respondWithJSON(w, http.StatusOK, workLogs)
-// }
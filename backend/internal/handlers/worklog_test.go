@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/middleware"
+)
+
+// The crew member below works for two organizations: the caller's and
+// another one, whose hours the caller must not see.
+const (
+	crewOrgID      = "55555555-5555-5555-5555-555555555555"
+	crewEmployeeID = "66666666-6666-6666-6666-666666666666"
+	crewWorkLogID  = "77777777-7777-7777-7777-777777777777"
+)
+
+// scopedToCallerContracts matches the condition that limits work logs to the
+// contracts of the organization passed as $1.
+const scopedToCallerContracts = `wl\.contract_id IN \(SELECT id FROM contracts WHERE organization_id = \$1\)`
+
+func newMockDB(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.SetDB(db)
+	t.Cleanup(func() {
+		database.SetDB(nil)
+		db.Close()
+	})
+	return mock
+}
+
+func asCrewContractor(r *http.Request) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), middleware.UserContextKey, middleware.UserContext{
+		UserID:           testUserID,
+		UserType:         "contractor",
+		OrganizationID:   crewOrgID,
+		OrganizationRole: "owner",
+	}))
+}
+
+func serveWorkLogs(t *testing.T, mock sqlmock.Sqlmock, handler http.HandlerFunc, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler(rec, asCrewContractor(r))
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func TestListWorkLogsOnlyIncludesCallerContracts(t *testing.T) {
+	mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) .*e\.organization_id = \$1.*` + scopedToCallerContracts).
+		WithArgs(crewOrgID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`FROM work_logs wl.*` + scopedToCallerContracts).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	rec := serveWorkLogs(t, mock, ListWorkLogs, httptest.NewRequest("GET", "/api/work-logs", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestGetWorkLogDetailRefusesOtherOrganizationsContract(t *testing.T) {
+	mock := newMockDB(t)
+	now := time.Now()
+	mock.ExpectQuery(`FROM work_logs wl.*WHERE wl\.id = \$1`).WithArgs(crewWorkLogID).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "employee_id", "project_id", "check_in_time", "work_date", "check_out_time",
+			"check_in_photo_url", "check_out_photo_url", "check_in_latitude", "check_in_longitude",
+			"check_out_latitude", "check_out_longitude", "hours_worked", "created_at", "employee_name", "project_title",
+		}).AddRow(crewWorkLogID, crewEmployeeID, testProviderID, now, "2026-10-19", nil,
+			"", nil, nil, nil, nil, nil, nil, now, "Sam", "Other organization's job"))
+	// Sam is on the caller's crew, but the log is against the other
+	// organization's contract.
+	mock.ExpectQuery(`FROM employees e.*AND EXISTS\(.*WHERE wl\.id = \$3 AND `+scopedToCallerContracts).
+		WithArgs(crewOrgID, crewEmployeeID, crewWorkLogID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	r := mux.SetURLVars(httptest.NewRequest("GET", "/api/work-logs/"+crewWorkLogID, nil), map[string]string{"id": crewWorkLogID})
+	rec := serveWorkLogs(t, mock, GetWorkLogDetail, r)
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status %d, want 403: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestWeeklySummaryOnlyCountsCallerContracts(t *testing.T) {
+	mock := newMockDB(t)
+	mock.ExpectQuery(`SELECT time_zone FROM users`).WithArgs(testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"time_zone"}).AddRow("UTC"))
+	mock.ExpectQuery(`SUM\(wl\.hours_worked\).*`+scopedToCallerContracts).
+		WithArgs(crewOrgID, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(8.0))
+
+	rec := serveWorkLogs(t, mock, GetWeeklySummary, httptest.NewRequest("GET", "/api/work-logs/summary/weekly", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestSummaryByEmployeeOnlyCountsCallerContracts(t *testing.T) {
+	mock := newMockDB(t)
+	mock.ExpectQuery(`LEFT JOIN work_logs wl ON wl\.employee_id = e\.user_id AND ` + scopedToCallerContracts).
+		WithArgs(crewOrgID).
+		WillReturnRows(sqlmock.NewRows([]string{"employee_id", "employee_name", "total_hours"}).AddRow(crewEmployeeID, "Sam", 8.0))
+
+	rec := serveWorkLogs(t, mock, GetSummaryByEmployee, httptest.NewRequest("GET", "/api/work-logs/summary/by-employee", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestSummaryByProjectOnlyCountsCallerContract(t *testing.T) {
+	mock := newMockDB(t)
+	// Another organization may hold its own contract on the same project.
+	mock.ExpectQuery(`LEFT JOIN work_logs wl ON wl\.project_id = p\.id AND wl\.contract_id = c\.id`).
+		WithArgs(crewOrgID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "project_title", "total_hours"}))
+
+	rec := serveWorkLogs(t, mock, GetSummaryByProject, httptest.NewRequest("GET", "/api/work-logs/summary/by-project", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestApproveWorkLogIgnoresDeletedProjects(t *testing.T) {
+	mock := newMockDB(t)
+	mock.ExpectQuery(`FROM work_logs wl\s+JOIN projects p ON p\.id = wl\.project_id AND p\.deleted_at IS NULL`).
+		WithArgs(crewWorkLogID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "employee_id", "check_out_time", "approved_at", "organization_id"}))
+
+	r := mux.SetURLVars(httptest.NewRequest("POST", "/api/work-logs/"+crewWorkLogID+"/approve", nil), map[string]string{"id": crewWorkLogID})
+	rec := serveWorkLogs(t, mock, ApproveWorkLog, r)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status %d, want 404: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestApproveWorkLogOnlyUpdatesLiveProjects(t *testing.T) {
+	mock := newMockDB(t)
+	mock.ExpectQuery(`FROM work_logs wl\s+JOIN projects p`).WithArgs(crewWorkLogID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "employee_id", "check_out_time", "approved_at", "organization_id"}).
+			AddRow(testProviderID, crewEmployeeID, time.Now(), nil, crewOrgID))
+	// The project is deleted between the lookup and the update.
+	mock.ExpectQuery(`UPDATE work_logs wl SET approved_by = \$1.*AND p\.deleted_at IS NULL`).
+		WithArgs(testUserID, crewWorkLogID).
+		WillReturnRows(sqlmock.NewRows(nil))
+
+	r := mux.SetURLVars(httptest.NewRequest("POST", "/api/work-logs/"+crewWorkLogID+"/approve", nil), map[string]string{"id": crewWorkLogID})
+	rec := serveWorkLogs(t, mock, ApproveWorkLog, r)
+	if rec.Code == http.StatusOK {
+		t.Fatalf("approved a work log on a deleted project: %s", rec.Body)
+	}
+}
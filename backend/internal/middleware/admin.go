@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// RequireAdmin restricts a subrouter to platform admins. It must run after
+// AuthMiddleware. Impersonation tokens never pass, even when the impersonated
+// user would.
+func RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userCtx, ok := GetUserFromContext(r.Context())
+		if !ok {
+			apierror.Write(w, apierror.ErrUnauthenticated)
+			return
+		}
+
+		if userCtx.UserType != string(models.UserTypeAdmin) || userCtx.IsImpersonated() || userCtx.TokenID != "" {
+			apierror.Write(w, apierror.ErrAdminRequired)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ClientIP returns the caller's address, preferring the first hop recorded by
+// the load balancer.
+func ClientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// recordImpersonatedRequest writes every state-changing request made with an
+// impersonation token to the admin audit log. Reads are not recorded; the
+// impersonation itself already is.
+func recordImpersonatedRequest(r *http.Request, userCtx UserContext) error {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+		return nil
+	}
+
+	details, _ := json.Marshal(map[string]string{
+		"method": r.Method,
+		"path":   r.URL.Path,
+	})
+
+	_, err := database.GetDB().Exec(`
+		INSERT INTO admin_audit_logs (admin_id, action, target_user_id, details, ip_address)
+		VALUES ($1, $2, $3, $4, $5)
+	`, userCtx.ImpersonatorID, models.AdminActionImpersonatedWrite, userCtx.UserID, details, ClientIP(r))
+	return err
+}
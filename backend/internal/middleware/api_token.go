@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/utils"
+	"github.com/lib/pq"
+)
+
+// apiTokenRoutes lists the only endpoints reachable with a personal access
+// token, keyed by method and route template. Anything not listed is denied.
+var apiTokenRoutes = map[string]models.APITokenScope{
+	"GET /api/auth/me": "",
+
+	"GET /api/projects":                  models.ScopeProjectsRead,
+	"GET /api/projects/{id}":             models.ScopeProjectsRead,
+	"GET /api/projects/{id}/dashboard":   models.ScopeProjectsRead,
+	"GET /api/projects/{id}/contractors": models.ScopeProjectsRead,
+
+	"GET /api/projects/{id}/expenses":          models.ScopeExpensesRead,
+	"GET /api/projects/{id}/expenses/download": models.ScopeExpensesRead,
+	"GET /api/expenses/{id}":                   models.ScopeExpensesRead,
+
+	"GET /api/projects/{id}/work-logs":       models.ScopeWorkLogsRead,
+	"GET /api/work-logs":                     models.ScopeWorkLogsRead,
+	"GET /api/work-logs/{id}":                models.ScopeWorkLogsRead,
+	"GET /api/work-logs/summary/weekly":      models.ScopeWorkLogsRead,
+	"GET /api/work-logs/summary/by-employee": models.ScopeWorkLogsRead,
+	"GET /api/work-logs/summary/by-project":  models.ScopeWorkLogsRead,
+
+	"GET /api/projects/{project_id}/payments":           models.ScopePaymentsRead,
+	"GET /api/projects/{id}/payment-summaries/download": models.ScopePaymentsRead,
+
+	// GraphQL checks the scope of each field it resolves.
+	"POST /api/graphql": "",
+}
+
+func authenticateAPIToken(w http.ResponseWriter, r *http.Request, next http.Handler, token string) {
+	requiredScope, allowed := requiredAPITokenScope(r)
+	if !allowed {
+		respondWithError(w, http.StatusForbidden, "This endpoint cannot be accessed with an API token")
+		return
+	}
+
+	db := database.GetDB()
+
+	var userCtx UserContext
+	var scopes []string
+	err := db.QueryRow(`
+		SELECT t.id, t.scopes, u.id, u.email, u.user_type
+		FROM api_tokens t
+		JOIN users u ON t.user_id = u.id
+		WHERE t.token_hash = $1
+		  AND t.revoked_at IS NULL
+		  AND t.expires_at > NOW()
+		  AND u.disabled_at IS NULL
+	`, utils.HashAPIToken(token)).Scan(&userCtx.TokenID, pq.Array(&scopes), &userCtx.UserID, &userCtx.Email, &userCtx.UserType)
+	if err == sql.ErrNoRows {
+		apierror.Write(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid, expired or revoked API token"))
+		return
+	}
+	if err != nil {
+		log.Printf("ERROR: Failed to look up API token: %v", err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to validate API token")
+		return
+	}
+	userCtx.Scopes = scopes
+
+	if err := loadOrganization(&userCtx); err != nil {
+		log.Printf("ERROR: Failed to load organization for user %s: %v", userCtx.UserID, err)
+		respondWithError(w, http.StatusInternalServerError, "Failed to load organization")
+		return
+	}
+
+	if requiredScope != "" && !hasScope(scopes, requiredScope) {
+		respondWithError(w, http.StatusForbidden, "API token is missing the "+string(requiredScope)+" scope")
+		return
+	}
+
+	_, err = db.Exec(`
+		UPDATE api_tokens
+		SET last_used_at = NOW()
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+	`, userCtx.TokenID)
+	if err != nil {
+		log.Printf("WARNING: Failed to record API token usage: %v", err)
+	}
+
+	ctx := context.WithValue(r.Context(), UserContextKey, userCtx)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func requiredAPITokenScope(r *http.Request) (models.APITokenScope, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "", false
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "", false
+	}
+
+	scope, ok := apiTokenRoutes[r.Method+" "+template]
+	return scope, ok
+}
+
+func hasScope(scopes []string, scope models.APITokenScope) bool {
+	for _, s := range scopes {
+		if s == string(scope) {
+			return true
+		}
+	}
+	return false
+}
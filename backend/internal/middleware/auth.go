@@ -2,9 +2,14 @@ package middleware
 
 import (
 	"context"
+	"database/sql"
+	"log"
 	"net/http"
 	"strings"
 
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+	"github.com/juazsh/managrr/internal/models"
 	"github.com/juazsh/managrr/internal/utils"
 )
 
@@ -16,6 +21,14 @@ type UserContext struct {
 	UserID   string
 	Email    string
 	UserType string
+	TokenID  string
+	Scopes   []string
+
+	OrganizationID   string
+	OrganizationRole string
+
+	// ImpersonatorID is the platform admin acting as this user, if any.
+	ImpersonatorID string
 }
 
 func AuthMiddleware(next http.Handler) http.Handler {
@@ -33,16 +46,50 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		}
 
 		token := parts[1]
+		if utils.IsAPIToken(token) {
+			authenticateAPIToken(w, r, next, token)
+			return
+		}
+
 		claims, err := utils.ValidateToken(token)
 		if err != nil {
-			respondWithError(w, http.StatusUnauthorized, "Invalid or expired token")
+			apierror.Write(w, apierror.New(http.StatusUnauthorized, apierror.CodeInvalidToken, "Invalid or expired token"))
 			return
 		}
 
 		userCtx := UserContext{
-			UserID:   claims.UserID,
-			Email:    claims.Email,
-			UserType: claims.UserType,
+			UserID:         claims.UserID,
+			Email:          claims.Email,
+			UserType:       claims.UserType,
+			ImpersonatorID: claims.ImpersonatorID,
+		}
+
+		// Tokens are stateless, so disabling an account or revoking an admin
+		// only takes effect if it is re-checked on every request.
+		active, err := accountActive(userCtx)
+		if err != nil {
+			log.Printf("ERROR: Failed to check account status for user %s: %v", userCtx.UserID, err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to verify account")
+			return
+		}
+		if !active {
+			apierror.Write(w, apierror.New(http.StatusUnauthorized, apierror.CodeAccountDisabled, "Account is disabled"))
+			return
+		}
+
+		if err := loadOrganization(&userCtx); err != nil {
+			log.Printf("ERROR: Failed to load organization for user %s: %v", userCtx.UserID, err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to load organization")
+			return
+		}
+
+		if userCtx.IsImpersonated() {
+			// Fail closed: an impersonated write that cannot be audited is refused.
+			if err := recordImpersonatedRequest(r, userCtx); err != nil {
+				log.Printf("ERROR: Failed to audit impersonated request by admin %s: %v", userCtx.ImpersonatorID, err)
+				respondWithError(w, http.StatusInternalServerError, "Failed to record audit log")
+				return
+			}
 		}
 
 		ctx := context.WithValue(r.Context(), UserContextKey, userCtx)
@@ -50,6 +97,89 @@ func AuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// accountActive reports whether the token's user still exists and is not
+// disabled. For impersonation tokens the admin must also still be an active
+// admin.
+func accountActive(userCtx UserContext) (bool, error) {
+	db := database.GetDB()
+
+	var active bool
+	err := db.QueryRow(`
+		SELECT disabled_at IS NULL FROM users WHERE id = $1
+	`, userCtx.UserID).Scan(&active)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil || !active || userCtx.ImpersonatorID == "" {
+		return active, err
+	}
+
+	err = db.QueryRow(`
+		SELECT disabled_at IS NULL AND user_type = $2 FROM users WHERE id = $1
+	`, userCtx.ImpersonatorID, models.UserTypeAdmin).Scan(&active)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return active, err
+}
+
+// loadOrganization attaches the contractor organization a user belongs to, if
+// any. Access checks for contractor-side resources compare against it rather
+// than the individual user ID.
+func loadOrganization(userCtx *UserContext) error {
+	if userCtx.UserType != string(models.UserTypeContractor) {
+		return nil
+	}
+
+	err := database.GetDB().QueryRow(`
+		SELECT organization_id, role FROM organization_members WHERE user_id = $1
+	`, userCtx.UserID).Scan(&userCtx.OrganizationID, &userCtx.OrganizationRole)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	return err
+}
+
+// InOrganization reports whether the user is a member of the given
+// contractor organization.
+func (u UserContext) InOrganization(organizationID string) bool {
+	return u.OrganizationID != "" && u.OrganizationID == organizationID
+}
+
+// HasOrganizationRole reports whether the user holds one of the given roles in
+// their organization.
+func (u UserContext) HasOrganizationRole(roles ...models.OrganizationRole) bool {
+	for _, role := range roles {
+		if u.OrganizationRole == string(role) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManageBusiness is true for members who run day-to-day operations:
+// employees, estimates, expenses and payments.
+func (u UserContext) CanManageBusiness() bool {
+	return u.HasOrganizationRole(models.OrganizationRoleOwner, models.OrganizationRoleAdmin, models.OrganizationRoleOfficeManager)
+}
+
+// CanManageMembers is true for members who may change the organization and
+// its membership.
+func (u UserContext) CanManageMembers() bool {
+	return u.HasOrganizationRole(models.OrganizationRoleOwner, models.OrganizationRoleAdmin)
+}
+
+// IsImpersonated reports whether an admin is acting as this user.
+func (u UserContext) IsImpersonated() bool {
+	return u.ImpersonatorID != ""
+}
+
+// HasScope reports whether the request may read what scope covers. Sessions
+// may read everything; API tokens only what they were granted.
+func (u UserContext) HasScope(scope models.APITokenScope) bool {
+	return u.TokenID == "" || hasScope(u.Scopes, scope)
+}
+
 func GetUserFromContext(ctx context.Context) (UserContext, bool) {
 	user, ok := ctx.Value(UserContextKey).(UserContext)
 	return user, ok
@@ -80,7 +210,5 @@ func GetUserEmail(ctx context.Context) (string, bool) {
 }
 
 func respondWithError(w http.ResponseWriter, code int, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	w.Write([]byte(`{"error":"` + message + `"}`))
+	apierror.Write(w, apierror.FromStatus(code, message))
 }
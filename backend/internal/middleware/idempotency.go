@@ -0,0 +1,241 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/database"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayHeader is set on responses replayed from a stored key.
+	IdempotentReplayHeader = "Idempotent-Replayed"
+
+	idempotencyKeyTTL    = 24 * time.Hour
+	maxIdempotencyKeyLen = 255
+	// maxIdempotentBody covers the largest upload any POST accepts: a project
+	// update with ten 5MB photos.
+	maxIdempotentBody = 64 << 20
+)
+
+// Idempotency makes POST requests that carry an Idempotency-Key header safe to
+// retry. The first request with a key runs normally and its response is
+// stored; a retry with the same key and the same request gets the stored
+// response back instead of running the handler again. Reusing a key for a
+// different request is rejected. Keys are scoped to the caller, so it must run
+// after AuthMiddleware, and expire after idempotencyKeyTTL.
+//
+// Server errors are not stored, so a request that failed with a 5xx can be
+// retried with the same key.
+func Idempotency(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			apierror.Write(w, apierror.New(http.StatusBadRequest, apierror.CodeInvalidIdempotencyKey,
+				"Idempotency-Key must be at most 255 characters"))
+			return
+		}
+
+		userID, ok := GetUserID(r.Context())
+		if !ok {
+			apierror.Write(w, apierror.ErrUnauthenticated)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBody+1))
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Failed to read request body")
+			return
+		}
+		if len(body) > maxIdempotentBody {
+			respondWithError(w, http.StatusRequestEntityTooLarge, "Request too large")
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := requestFingerprint(r, body)
+
+		db := database.GetDB()
+		stored, claimed, err := claimIdempotencyKey(db, userID, key, r.Method, r.URL.Path, fingerprint)
+		if err != nil {
+			log.Printf("ERROR Idempotency: Failed to claim key: %v", err)
+			respondWithError(w, http.StatusInternalServerError, "Failed to process idempotency key")
+			return
+		}
+
+		if !claimed {
+			switch {
+			case stored.method != r.Method || stored.path != r.URL.Path || stored.fingerprint != fingerprint:
+				apierror.Write(w, apierror.New(http.StatusUnprocessableEntity, apierror.CodeIdempotencyKeyReused,
+					"Idempotency-Key was already used for a different request"))
+			case !stored.statusCode.Valid:
+				apierror.Write(w, apierror.New(http.StatusConflict, apierror.CodeRequestInProgress,
+					"A request with this Idempotency-Key is still being processed"))
+			default:
+				if stored.contentType.Valid {
+					w.Header().Set("Content-Type", stored.contentType.String)
+				}
+				w.Header().Set(IdempotentReplayHeader, "true")
+				w.WriteHeader(int(stored.statusCode.Int64))
+				w.Write(stored.body)
+			}
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: w}
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			// The handler panicked or failed; release the key so the client
+			// can retry.
+			if _, err := db.Exec(`DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2`, userID, key); err != nil {
+				log.Printf("ERROR Idempotency: Failed to release key: %v", err)
+			}
+		}()
+
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		if rec.status >= 500 {
+			return
+		}
+
+		_, err = db.Exec(`
+			UPDATE idempotency_keys
+			SET status_code = $3, content_type = $4, response_body = $5
+			WHERE user_id = $1 AND key = $2
+		`, userID, key, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+		if err != nil {
+			log.Printf("ERROR Idempotency: Failed to store response: %v", err)
+			return
+		}
+		completed = true
+	})
+}
+
+type storedResponse struct {
+	method      string
+	path        string
+	fingerprint string
+	statusCode  sql.NullInt64
+	contentType sql.NullString
+	body        []byte
+}
+
+// claimIdempotencyKey records key as in flight. When the key is already taken
+// it returns what was stored for it instead.
+func claimIdempotencyKey(db *sql.DB, userID, key, method, path, fingerprint string) (*storedResponse, bool, error) {
+	if _, err := db.Exec(`DELETE FROM idempotency_keys WHERE user_id = $1 AND expires_at < NOW()`, userID); err != nil {
+		return nil, false, err
+	}
+
+	result, err := db.Exec(`
+		INSERT INTO idempotency_keys (user_id, key, method, path, fingerprint, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, key) DO NOTHING
+	`, userID, key, method, path, fingerprint, time.Now().UTC().Add(idempotencyKeyTTL))
+	if err != nil {
+		return nil, false, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return nil, false, err
+	} else if n == 1 {
+		return nil, true, nil
+	}
+
+	var stored storedResponse
+	err = db.QueryRow(`
+		SELECT method, path, fingerprint, status_code, content_type, response_body
+		FROM idempotency_keys
+		WHERE user_id = $1 AND key = $2
+	`, userID, key).Scan(&stored.method, &stored.path, &stored.fingerprint, &stored.statusCode, &stored.contentType, &stored.body)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The first request failed and released the key in between.
+		return claimIdempotencyKey(db, userID, key, method, path, fingerprint)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &stored, false, nil
+}
+
+// requestFingerprint hashes what makes a request the same request. Multipart
+// bodies are hashed part by part, since clients pick a new boundary every time
+// they rebuild a form for a retry.
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		if hashMultipart(h, body, params["boundary"]) == nil {
+			return hex.EncodeToString(h.Sum(nil))
+		}
+		h.Reset()
+		io.WriteString(h, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery+"\n")
+	}
+
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func hashMultipart(w io.Writer, body []byte, boundary string) error {
+	mr := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		partHash := sha256.New()
+		if _, err := io.Copy(partHash, part); err != nil {
+			return err
+		}
+		io.WriteString(w, part.FormName()+"\x00"+part.FileName()+"\x00"+hex.EncodeToString(partHash.Sum(nil))+"\n")
+	}
+}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(code int) {
+	if rec.status == 0 {
+		rec.status = code
+	}
+	rec.ResponseWriter.WriteHeader(code)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
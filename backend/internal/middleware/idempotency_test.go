@@ -0,0 +1,209 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/juazsh/managrr/internal/database"
+)
+
+const (
+	testUserID         = "33333333-3333-3333-3333-333333333333"
+	testIdempotencyKey = "retry-me"
+	testBody           = `{"title":"Kitchen remodel"}`
+)
+
+func newIdempotencyTest(t *testing.T) sqlmock.Sqlmock {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatal(err)
+	}
+	database.SetDB(db)
+	t.Cleanup(func() {
+		database.SetDB(nil)
+		db.Close()
+	})
+	return mock
+}
+
+func idempotentRequest(body string) *http.Request {
+	r := httptest.NewRequest("POST", "/api/projects", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+	return r.WithContext(context.WithValue(r.Context(), UserContextKey, UserContext{UserID: testUserID}))
+}
+
+// countingHandler counts how often the wrapped handler actually runs.
+func countingHandler(status int, calls *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":"p1"}`))
+	})
+}
+
+func expectClaim(mock sqlmock.Sqlmock, claimed bool) {
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE user_id = \$1 AND expires_at < NOW\(\)`).
+		WithArgs(testUserID).WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := int64(0)
+	if claimed {
+		rows = 1
+	}
+	mock.ExpectExec(`INSERT INTO idempotency_keys`).
+		WithArgs(testUserID, testIdempotencyKey, "POST", "/api/projects", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, rows))
+}
+
+func expectStored(mock sqlmock.Sqlmock, fingerprint string, status interface{}) {
+	mock.ExpectQuery(`SELECT method, path, fingerprint, status_code, content_type, response_body`).
+		WithArgs(testUserID, testIdempotencyKey).
+		WillReturnRows(sqlmock.NewRows([]string{"method", "path", "fingerprint", "status_code", "content_type", "response_body"}).
+			AddRow("POST", "/api/projects", fingerprint, status, "application/json", []byte(`{"id":"p1"}`)))
+}
+
+func serveIdempotent(t *testing.T, mock sqlmock.Sqlmock, next http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	Idempotency(next).ServeHTTP(rec, r)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+func TestIdempotencyStoresFirstResponse(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	expectClaim(mock, true)
+	mock.ExpectExec(`UPDATE idempotency_keys`).
+		WithArgs(testUserID, testIdempotencyKey, http.StatusCreated, "application/json", []byte(`{"id":"p1"}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), idempotentRequest(testBody))
+	if calls != 1 || rec.Code != http.StatusCreated || rec.Header().Get(IdempotentReplayHeader) != "" {
+		t.Fatalf("calls = %d, status %d, replayed %q", calls, rec.Code, rec.Header().Get(IdempotentReplayHeader))
+	}
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	r := idempotentRequest(testBody)
+	expectClaim(mock, false)
+	expectStored(mock, requestFingerprint(r, []byte(testBody)), http.StatusCreated)
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), r)
+	if calls != 0 {
+		t.Fatalf("handler ran %d times on a replay", calls)
+	}
+	if rec.Code != http.StatusCreated || rec.Body.String() != `{"id":"p1"}` ||
+		rec.Header().Get(IdempotentReplayHeader) != "true" || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("replay: status %d, headers %v, body %s", rec.Code, rec.Header(), rec.Body)
+	}
+}
+
+func TestIdempotencyRejectsKeyReusedForDifferentRequest(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	expectClaim(mock, false)
+	expectStored(mock, requestFingerprint(idempotentRequest(testBody), []byte(testBody)), http.StatusCreated)
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), idempotentRequest(`{"title":"Bathroom"}`))
+	if calls != 0 || rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "idempotency_key_reused") {
+		t.Fatalf("calls = %d, status %d: %s", calls, rec.Code, rec.Body)
+	}
+}
+
+func TestIdempotencyRejectsRequestStillInProgress(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	r := idempotentRequest(testBody)
+	expectClaim(mock, false)
+	expectStored(mock, requestFingerprint(r, []byte(testBody)), nil)
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), r)
+	if calls != 0 || rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "request_in_progress") {
+		t.Fatalf("calls = %d, status %d: %s", calls, rec.Code, rec.Body)
+	}
+}
+
+// Server errors are not stored, so the same key can be retried.
+func TestIdempotencyReleasesKeyOnServerError(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	expectClaim(mock, true)
+	mock.ExpectExec(`DELETE FROM idempotency_keys WHERE user_id = \$1 AND key = \$2`).
+		WithArgs(testUserID, testIdempotencyKey).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusInternalServerError, &calls), idempotentRequest(testBody))
+	if calls != 1 || rec.Code != http.StatusInternalServerError {
+		t.Fatalf("calls = %d, status %d", calls, rec.Code)
+	}
+}
+
+func TestIdempotencyIgnoresRequestsWithoutKey(t *testing.T) {
+	mock := newIdempotencyTest(t)
+
+	r := idempotentRequest(testBody)
+	r.Header.Del(IdempotencyKeyHeader)
+	calls := 0
+	serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), r)
+
+	get := httptest.NewRequest("GET", "/api/projects", nil)
+	get.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+	serveIdempotent(t, mock, countingHandler(http.StatusOK, &calls), get)
+
+	if calls != 2 {
+		t.Fatalf("handler ran %d times, want both requests passed through", calls)
+	}
+}
+
+func TestIdempotencyRejectsLongKey(t *testing.T) {
+	mock := newIdempotencyTest(t)
+	r := idempotentRequest(testBody)
+	r.Header.Set(IdempotencyKeyHeader, strings.Repeat("k", maxIdempotencyKeyLen+1))
+
+	calls := 0
+	rec := serveIdempotent(t, mock, countingHandler(http.StatusCreated, &calls), r)
+	if calls != 0 || rec.Code != http.StatusBadRequest {
+		t.Fatalf("calls = %d, status %d", calls, rec.Code)
+	}
+}
+
+// A client rebuilding a multipart form for a retry picks a new boundary; the
+// retry must still count as the same request.
+func TestRequestFingerprintIgnoresMultipartBoundary(t *testing.T) {
+	form := func(boundary, caption string) *http.Request {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		mw.SetBoundary(boundary)
+		mw.WriteField("caption", caption)
+		part, _ := mw.CreateFormFile("photos", "site.jpg")
+		part.Write([]byte("jpeg bytes"))
+		mw.Close()
+
+		r := httptest.NewRequest("POST", "/api/projects/p1/updates", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		return r
+	}
+	fingerprint := func(r *http.Request) string {
+		var body bytes.Buffer
+		body.ReadFrom(r.Body)
+		return requestFingerprint(r, body.Bytes())
+	}
+
+	first := fingerprint(form("boundary-one", "Framing done"))
+	if retry := fingerprint(form("boundary-two", "Framing done")); retry != first {
+		t.Error("same form with a new boundary got a different fingerprint")
+	}
+	if other := fingerprint(form("boundary-one", "Drywall done")); other == first {
+		t.Error("different form got the same fingerprint")
+	}
+}
@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/openapi"
+)
+
+func init() {
+	// Upload handlers accept photos by file extension; let the multipart
+	// decoder read the image parts clients send so the rest of the form can
+	// be validated.
+	for _, contentType := range []string{"image/jpeg", "image/jpg", "image/png"} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+var validationOptions = &openapi3filter.Options{
+	// AuthMiddleware and RequireAdmin enforce security; the document only
+	// describes it.
+	AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	MultiError:         false,
+}
+
+// ValidateRequest checks each request against the OpenAPI document before it
+// reaches its handler and rejects those that do not match with a 400. Routes
+// missing from the document are let through and logged so the drift gets
+// fixed.
+func ValidateRequest(next http.Handler) http.Handler {
+	doc, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("Failed to load OpenAPI document: %v", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		route := mux.CurrentRoute(r)
+		if route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		pathItem := doc.Paths.Value(path)
+		var operation *openapi3.Operation
+		if pathItem != nil {
+			operation = pathItem.GetOperation(r.Method)
+		}
+		if operation == nil {
+			log.Printf("WARNING ValidateRequest: %s %s is not in the OpenAPI document", r.Method, path)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: mux.Vars(r),
+			Route: &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  pathItem,
+				Method:    r.Method,
+				Operation: operation,
+			},
+			Options: validationOptions,
+		}
+
+		if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+			respondWithValidationError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func respondWithValidationError(w http.ResponseWriter, err error) {
+	apiErr := apierror.New(http.StatusBadRequest, apierror.CodeValidationFailed, "Invalid request")
+
+	var requestErr *openapi3filter.RequestError
+	if errors.As(err, &requestErr) {
+		reason := requestErr.Reason
+		field := ""
+		var schemaErr *openapi3.SchemaError
+		if errors.As(requestErr.Err, &schemaErr) {
+			reason = schemaErr.Reason
+			field = strings.Join(schemaErr.JSONPointer(), ".")
+		} else if reason == "" && requestErr.Err != nil {
+			reason = requestErr.Err.Error()
+		}
+
+		switch {
+		case requestErr.Parameter != nil:
+			apiErr.Message = fmt.Sprintf("Invalid %s parameter %s", requestErr.Parameter.In, requestErr.Parameter.Name)
+			if field == "" {
+				field = requestErr.Parameter.Name
+			}
+		case requestErr.RequestBody != nil:
+			apiErr.Message = "Invalid request body"
+			if schemaErr == nil {
+				apiErr.Code = apierror.CodeInvalidRequestBody
+			}
+		}
+
+		if reason != "" {
+			if field != "" {
+				apiErr.Message += ": " + field + ": " + reason
+				apiErr.Details = []apierror.FieldError{{Field: field, Message: reason}}
+			} else {
+				apiErr.Message += ": " + reason
+			}
+		}
+	}
+
+	apierror.Write(w, apiErr)
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AdminAction string
+
+const (
+	AdminActionSearchUsers        AdminAction = "search_users"
+	AdminActionViewUser           AdminAction = "view_user"
+	AdminActionSearchProjects     AdminAction = "search_projects"
+	AdminActionDisableUser        AdminAction = "disable_user"
+	AdminActionEnableUser         AdminAction = "enable_user"
+	AdminActionResendVerification AdminAction = "resend_verification"
+	AdminActionForcePasswordReset AdminAction = "force_password_reset"
+	AdminActionImpersonate        AdminAction = "impersonate"
+	AdminActionImpersonatedWrite  AdminAction = "impersonated_request"
+)
+
+type AdminAuditLog struct {
+	ID              string          `json:"id"`
+	AdminID         string          `json:"admin_id"`
+	AdminName       string          `json:"admin_name"`
+	Action          AdminAction     `json:"action"`
+	TargetUserID    *string         `json:"target_user_id,omitempty"`
+	TargetProjectID *string         `json:"target_project_id,omitempty"`
+	Details         json.RawMessage `json:"details,omitempty"`
+	IPAddress       *string         `json:"ip_address,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+}
+
+type AdminProjectSummary struct {
+	ID         string        `json:"id"`
+	Title      string        `json:"title"`
+	Status     ProjectStatus `json:"status"`
+	OwnerID    string        `json:"owner_id"`
+	OwnerName  string        `json:"owner_name"`
+	OwnerEmail string        `json:"owner_email"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type AdminContractSummary struct {
+	ID               string         `json:"id"`
+	ProjectID        string         `json:"project_id"`
+	ProjectTitle     string         `json:"project_title"`
+	OrganizationID   string         `json:"organization_id"`
+	OrganizationName string         `json:"organization_name"`
+	Status           ContractStatus `json:"status"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+type AdminUserDetail struct {
+	User      User                   `json:"user"`
+	Projects  []AdminProjectSummary  `json:"projects"`
+	Contracts []AdminContractSummary `json:"contracts"`
+}
+
+type AdminReasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      User      `json:"user"`
+}
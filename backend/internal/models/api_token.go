@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type APITokenScope string
+
+const (
+	ScopeProjectsRead APITokenScope = "projects:read"
+	ScopeExpensesRead APITokenScope = "expenses:read"
+	ScopeWorkLogsRead APITokenScope = "work_logs:read"
+	ScopePaymentsRead APITokenScope = "payments:read"
+)
+
+var ValidAPITokenScopes = map[APITokenScope]bool{
+	ScopeProjectsRead: true,
+	ScopeExpensesRead: true,
+	ScopeWorkLogsRead: true,
+	ScopePaymentsRead: true,
+}
+
+type APIToken struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Name        string     `json:"name"`
+	TokenPrefix string     `json:"token_prefix"`
+	Scopes      []string   `json:"scopes"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+type CreateAPITokenResponse struct {
+	Token    string   `json:"token"`
+	APIToken APIToken `json:"api_token"`
+}
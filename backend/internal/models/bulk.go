@@ -0,0 +1,20 @@
+package models
+
+import "github.com/juazsh/managrr/internal/apierror"
+
+// BulkResult is the outcome of one item of a bulk request. Index is the
+// item's position in the request and ID the record it named.
+type BulkResult struct {
+	Index  int             `json:"index"`
+	ID     string          `json:"id"`
+	Status int             `json:"status"`
+	Error  *apierror.Error `json:"error,omitempty"`
+}
+
+// BulkResponse answers a bulk request whose items are applied one at a time,
+// so some can succeed while others fail.
+type BulkResponse struct {
+	Results   []BulkResult `json:"results"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+}
@@ -15,6 +15,7 @@ type Contract struct {
 	ID           string          `json:"id"`
 	ProjectID    string          `json:"project_id"`
 	ContractorID string          `json:"contractor_id"`
+	OrganizationID string        `json:"organization_id"`
 	OwnerID      string          `json:"owner_id"`
 	Status       ContractStatus  `json:"status"`
 	StartDate    *time.Time      `json:"start_date,omitempty"`
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusRevoked  InvitationStatus = "revoked"
+)
+
+type ContractorInvitation struct {
+	ID         string           `json:"id"`
+	ProjectID  string           `json:"project_id"`
+	InvitedBy  string           `json:"invited_by"`
+	Email      string           `json:"email"`
+	Token      string           `json:"-"`
+	Status     InvitationStatus `json:"status"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	AcceptedBy *string          `json:"accepted_by,omitempty"`
+	AcceptedAt *time.Time       `json:"accepted_at,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token"`
+}
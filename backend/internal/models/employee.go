@@ -1,37 +1,48 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/juazsh/managrr/internal/money"
+)
 
 type Employee struct {
-	ID           string    `json:"id"`
-	ContractorID string    `json:"contractor_id"`
-	UserID       string    `json:"user_id"`
-	Name         string    `json:"name"`
-	Email        string    `json:"email"`
-	Phone        *string   `json:"phone,omitempty"`
-	HourlyRate   float64   `json:"hourly_rate"`
-	IsActive     bool      `json:"is_active"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID             string       `json:"id"`
+	ContractorID   string       `json:"contractor_id"`
+	OrganizationID string       `json:"organization_id"`
+	UserID         string       `json:"user_id"`
+	Name           string       `json:"name"`
+	Email          string       `json:"email"`
+	Phone          *string      `json:"phone,omitempty"`
+	HourlyRate     money.Amount `json:"hourly_rate"`
+	IsActive       bool         `json:"is_active"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	Version        int          `json:"version,omitempty"`
 }
 
 type AddEmployeeRequest struct {
-	Name       string  `json:"name"`
-	Email      string  `json:"email"`
-	Phone      *string `json:"phone,omitempty"`
-	HourlyRate float64 `json:"hourly_rate"`
+	Name       string       `json:"name"`
+	Email      string       `json:"email"`
+	Phone      *string      `json:"phone,omitempty"`
+	HourlyRate money.Amount `json:"hourly_rate"`
 }
 
 type UpdateEmployeeRequest struct {
-	Name       string  `json:"name"`
-	Phone      *string `json:"phone,omitempty"`
-	HourlyRate float64 `json:"hourly_rate"`
+	Name       string       `json:"name"`
+	Phone      *string      `json:"phone,omitempty"`
+	HourlyRate money.Amount `json:"hourly_rate"`
 }
 
 type AssignProjectRequest struct {
 	ProjectID string `json:"project_id"`
 }
 
+type BulkAssignProjectRequest struct {
+	ProjectID   string   `json:"project_id"`
+	EmployeeIDs []string `json:"employee_ids"`
+}
+
 type AssignedProject struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
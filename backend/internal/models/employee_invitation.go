@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/juazsh/managrr/internal/money"
+)
+
+type EmployeeInvitationType string
+
+const (
+	EmployeeInvitationSetPassword EmployeeInvitationType = "set_password"
+	EmployeeInvitationJoinCrew    EmployeeInvitationType = "join_crew"
+)
+
+type EmployeeInvitation struct {
+	ID             string                 `json:"id"`
+	ContractorID   string                 `json:"contractor_id"`
+	OrganizationID string                 `json:"organization_id"`
+	UserID         string                 `json:"user_id"`
+	EmployeeID     *string                `json:"employee_id,omitempty"`
+	Email          string                 `json:"email"`
+	Name           string                 `json:"name"`
+	Phone          *string                `json:"phone,omitempty"`
+	HourlyRate     money.Amount           `json:"hourly_rate"`
+	InvitationType EmployeeInvitationType `json:"invitation_type"`
+	Token          string                 `json:"-"`
+	Status         InvitationStatus       `json:"status"`
+	ExpiresAt      time.Time              `json:"expires_at"`
+	AcceptedAt     *time.Time             `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+type AcceptEmployeeInvitationRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password,omitempty"`
+}
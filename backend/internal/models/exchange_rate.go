@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/juazsh/managrr/internal/money"
+)
+
+type ExchangeRateSource string
+
+const (
+	ExchangeRateSourceManual ExchangeRateSource = "manual"
+	ExchangeRateSourceImport ExchangeRateSource = "import"
+)
+
+// ProjectExchangeRate is how many units of the project currency one unit of
+// Currency buys, from EffectiveDate until the next rate for that currency.
+type ProjectExchangeRate struct {
+	ID            string             `json:"id"`
+	ProjectID     string             `json:"project_id"`
+	Currency      money.Currency     `json:"currency"`
+	Rate          money.Rate         `json:"rate"`
+	EffectiveDate string             `json:"effective_date"`
+	Source        ExchangeRateSource `json:"source"`
+	CreatedBy     *string            `json:"created_by,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+type SetExchangeRateRequest struct {
+	Currency      money.Currency `json:"currency"`
+	Rate          money.Rate     `json:"rate"`
+	EffectiveDate string         `json:"effective_date"`
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/juazsh/managrr/internal/money"
 )
 
 type ExpenseCategory string
@@ -24,7 +26,10 @@ type Expense struct {
 	ID              string          `json:"id"`
 	ProjectID       string          `json:"project_id"`
 	ContractID      *string         `json:"contract_id,omitempty"`
-	Amount          float64         `json:"amount"`
+	Amount          money.Amount    `json:"amount"`
+	Currency        money.Currency  `json:"currency"`
+	ExchangeRate    money.Rate      `json:"exchange_rate"`
+	ConvertedAmount money.Amount    `json:"converted_amount"`
 	Vendor          *string         `json:"vendor,omitempty"`
 	Date            string          `json:"date"`
 	Category        ExpenseCategory `json:"category"`
@@ -33,14 +38,32 @@ type Expense struct {
 	ReceiptPhotoURL *string         `json:"receipt_photo_url,omitempty"`
 	AddedBy         string          `json:"added_by"`
 	CreatedAt       time.Time       `json:"created_at"`
+	Version         int             `json:"version,omitempty"`
 }
 
 type CreateExpenseRequest struct {
 	ProjectID   string          `json:"project_id"`
-	Amount      float64         `json:"amount"`
+	Amount      money.Amount    `json:"amount"`
+	Currency    money.Currency  `json:"currency,omitempty"`
 	Vendor      *string         `json:"vendor,omitempty"`
 	Date        string          `json:"date"`
 	Category    ExpenseCategory `json:"category"`
 	Description *string         `json:"description,omitempty"`
 	PaidBy      ExpensePaidBy   `json:"paid_by"`
 }
+
+// BulkExpenseItem is one expense of a bulk create. ReceiptPhoto names the
+// multipart file field holding its receipt, if it has one.
+type BulkExpenseItem struct {
+	Amount       money.Amount    `json:"amount"`
+	Currency     money.Currency  `json:"currency,omitempty"`
+	Vendor       *string         `json:"vendor,omitempty"`
+	Date         string          `json:"date"`
+	Category     ExpenseCategory `json:"category"`
+	Description  *string         `json:"description,omitempty"`
+	ReceiptPhoto string          `json:"receipt_photo,omitempty"`
+}
+
+type BulkCreateExpensesResponse struct {
+	Expenses []Expense `json:"expenses"`
+}
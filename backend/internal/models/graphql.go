@@ -0,0 +1,38 @@
+package models
+
+import "encoding/json"
+
+// GraphQLRequest is a GraphQL query in the usual JSON envelope. Query may be
+// left out when Extensions names a query the server already has.
+type GraphQLRequest struct {
+	Query         string                 `json:"query,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    GraphQLExtensions      `json:"extensions,omitempty"`
+}
+
+type GraphQLExtensions struct {
+	PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQuery names a query by the SHA-256 of its text, following the
+// automatic persisted queries protocol of Apollo clients.
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// GraphQLResponse is the result of a GraphQL query. Errors in resolving one
+// field leave it null and are listed in Errors alongside the rest of Data.
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// GraphQLError describes one error. Extensions carries the same code as the
+// REST endpoints use for the same failure.
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+type LoginCodeChannel string
+
+const (
+	LoginCodeChannelEmail LoginCodeChannel = "email"
+	LoginCodeChannelSMS   LoginCodeChannel = "sms"
+)
+
+type PasswordlessLoginRequest struct {
+	Channel LoginCodeChannel `json:"channel"`
+	Email   string           `json:"email,omitempty"`
+	Phone   string           `json:"phone,omitempty"`
+}
+
+type PasswordlessVerifyRequest struct {
+	Token string `json:"token,omitempty"`
+	Phone string `json:"phone,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type OIDCProvider struct {
+	ID              string    `json:"id"`
+	ContractorID    string    `json:"contractor_id"`
+	OrganizationID  string    `json:"organization_id"`
+	Slug            string    `json:"slug"`
+	DisplayName     string    `json:"display_name"`
+	IssuerURL       string    `json:"issuer_url"`
+	ClientID        string    `json:"client_id"`
+	ClientSecret    *string   `json:"-"`
+	Scopes          string    `json:"scopes"`
+	DefaultUserType UserType  `json:"default_user_type"`
+	AllowedDomains  []string  `json:"allowed_domains"`
+	Enabled         bool      `json:"enabled"`
+	LoginURL        string    `json:"login_url"`
+	CallbackURL     string    `json:"callback_url"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type UpsertOIDCProviderRequest struct {
+	Slug            string   `json:"slug"`
+	DisplayName     string   `json:"display_name"`
+	IssuerURL       string   `json:"issuer_url"`
+	ClientID        string   `json:"client_id"`
+	ClientSecret    *string  `json:"client_secret,omitempty"`
+	Scopes          string   `json:"scopes"`
+	DefaultUserType UserType `json:"default_user_type"`
+	AllowedDomains  []string `json:"allowed_domains"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+// ConfirmSSOLinkRequest carries the token from the email sent when an SSO
+// sign-in matched an account outside the provider's organization.
+type ConfirmSSOLinkRequest struct {
+	Token string `json:"token"`
+}
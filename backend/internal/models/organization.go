@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner         OrganizationRole = "owner"
+	OrganizationRoleAdmin         OrganizationRole = "admin"
+	OrganizationRoleOfficeManager OrganizationRole = "office_manager"
+	OrganizationRoleForeman       OrganizationRole = "foreman"
+)
+
+var ValidOrganizationRoles = map[OrganizationRole]bool{
+	OrganizationRoleOwner:         true,
+	OrganizationRoleAdmin:         true,
+	OrganizationRoleOfficeManager: true,
+	OrganizationRoleForeman:       true,
+}
+
+type Organization struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrganizationMember struct {
+	ID             string           `json:"id"`
+	OrganizationID string           `json:"organization_id"`
+	UserID         string           `json:"user_id"`
+	Name           string           `json:"name"`
+	Email          string           `json:"email"`
+	Role           OrganizationRole `json:"role"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+type OrganizationWithMembership struct {
+	Organization
+	Role    OrganizationRole     `json:"role"`
+	Members []OrganizationMember `json:"members"`
+}
+
+type UpdateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+type AddOrganizationMemberRequest struct {
+	Email string           `json:"email"`
+	Name  string           `json:"name"`
+	Phone *string          `json:"phone,omitempty"`
+	Role  OrganizationRole `json:"role"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	Role OrganizationRole `json:"role"`
+}
+
+type OrganizationInvitation struct {
+	ID             string           `json:"id"`
+	OrganizationID string           `json:"organization_id"`
+	InvitedBy      string           `json:"invited_by"`
+	Email          string           `json:"email"`
+	Role           OrganizationRole `json:"role"`
+	Token          string           `json:"-"`
+	Status         InvitationStatus `json:"status"`
+	ExpiresAt      time.Time        `json:"expires_at"`
+	AcceptedBy     *string          `json:"accepted_by,omitempty"`
+	AcceptedAt     *time.Time       `json:"accepted_at,omitempty"`
+	CreatedAt      time.Time        `json:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type OwnershipTransferStatus string
+
+const (
+	OwnershipTransferStatusPending   OwnershipTransferStatus = "pending"
+	OwnershipTransferStatusAccepted  OwnershipTransferStatus = "accepted"
+	OwnershipTransferStatusDeclined  OwnershipTransferStatus = "declined"
+	OwnershipTransferStatusCancelled OwnershipTransferStatus = "cancelled"
+)
+
+type ProjectOwnershipTransfer struct {
+	ID          string                  `json:"id"`
+	ProjectID   string                  `json:"project_id"`
+	FromUserID  string                  `json:"from_user_id"`
+	ToEmail     string                  `json:"to_email"`
+	ToUserID    *string                 `json:"to_user_id,omitempty"`
+	Token       string                  `json:"-"`
+	Status      OwnershipTransferStatus `json:"status"`
+	ExpiresAt   time.Time               `json:"expires_at"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+type InitiateOwnershipTransferRequest struct {
+	Email string `json:"email"`
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/juazsh/managrr/internal/money"
 )
 
 type PaymentMethod string
@@ -25,20 +27,28 @@ const (
 )
 
 type PaymentSummary struct {
-	ID            string        `json:"id"`
-	ProjectID     string        `json:"project_id"`
-	ContractID    *string       `json:"contract_id,omitempty"`
-	Amount        float64       `json:"amount"`
-	PaymentMethod PaymentMethod `json:"payment_method"`
-	PaymentDate   string        `json:"payment_date"`
-	ScreenshotURL *string       `json:"screenshot_url,omitempty"`
-	Notes         *string       `json:"notes,omitempty"`
-	AddedBy       string        `json:"added_by"`
-	Status        PaymentStatus `json:"status"`
-	ConfirmedBy   *string       `json:"confirmed_by,omitempty"`
-	ConfirmedAt   *time.Time    `json:"confirmed_at,omitempty"`
-	DisputedAt    *time.Time    `json:"disputed_at,omitempty"`
-	DisputeReason *string       `json:"dispute_reason,omitempty"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID              string         `json:"id"`
+	ProjectID       string         `json:"project_id"`
+	ContractID      *string        `json:"contract_id,omitempty"`
+	Amount          money.Amount   `json:"amount"`
+	Currency        money.Currency `json:"currency"`
+	ExchangeRate    money.Rate     `json:"exchange_rate"`
+	ConvertedAmount money.Amount   `json:"converted_amount"`
+	PaymentMethod   PaymentMethod  `json:"payment_method"`
+	PaymentDate     string         `json:"payment_date"`
+	ScreenshotURL   *string        `json:"screenshot_url,omitempty"`
+	Notes           *string        `json:"notes,omitempty"`
+	AddedBy         string         `json:"added_by"`
+	Status          PaymentStatus  `json:"status"`
+	ConfirmedBy     *string        `json:"confirmed_by,omitempty"`
+	ConfirmedAt     *time.Time     `json:"confirmed_at,omitempty"`
+	DisputedAt      *time.Time     `json:"disputed_at,omitempty"`
+	DisputeReason   *string        `json:"dispute_reason,omitempty"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	Version         int            `json:"version,omitempty"`
+}
+
+type BulkConfirmPaymentsRequest struct {
+	PaymentIDs []string `json:"payment_ids"`
 }
@@ -14,3 +14,7 @@ type ProjectPhoto struct {
 type UploadPhotoRequest struct {
 	Caption *string `json:"caption,omitempty"`
 }
+
+type BulkDeletePhotosRequest struct {
+	PhotoIDs []string `json:"photo_ids"`
+}
@@ -2,6 +2,9 @@ package models
 
 import (
 	"time"
+
+	"github.com/juazsh/managrr/internal/calendar"
+	"github.com/juazsh/managrr/internal/money"
 )
 
 type ProjectStatus string
@@ -13,31 +16,37 @@ const (
 )
 
 type Project struct {
-	ID            string        `json:"id"`
-	OwnerID       string        `json:"owner_id"`
-	ContractorID  *string       `json:"contractor_id,omitempty"`
-	Title         string        `json:"title"`
-	Description   string        `json:"description"`
-	EstimatedCost float64       `json:"estimated_cost"`
-	Address       *string       `json:"address,omitempty"`
-	Status        ProjectStatus `json:"status"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
+	ID            string         `json:"id"`
+	OwnerID       string         `json:"owner_id"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	EstimatedCost money.Amount   `json:"estimated_cost"`
+	Currency      money.Currency `json:"currency"`
+	TimeZone      calendar.Zone  `json:"time_zone"`
+	Address       *string        `json:"address,omitempty"`
+	Status        ProjectStatus  `json:"status"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	Version       int            `json:"version,omitempty"`
 }
 
 type CreateProjectRequest struct {
-	Title         string  `json:"title"`
-	Description   string  `json:"description"`
-	EstimatedCost float64 `json:"estimated_cost"`
-	Address       *string `json:"address,omitempty"`
+	Title         string         `json:"title"`
+	Description   string         `json:"description"`
+	EstimatedCost money.Amount   `json:"estimated_cost"`
+	Currency      money.Currency `json:"currency,omitempty"`
+	TimeZone      calendar.Zone  `json:"time_zone,omitempty"`
+	Address       *string        `json:"address,omitempty"`
 }
 
 type UpdateProjectRequest struct {
-	Title         *string        `json:"title,omitempty"`
-	Description   *string        `json:"description,omitempty"`
-	EstimatedCost *float64       `json:"estimated_cost,omitempty"`
-	Address       *string        `json:"address,omitempty"`
-	Status        *ProjectStatus `json:"status,omitempty"`
+	Title         *string         `json:"title,omitempty"`
+	Description   *string         `json:"description,omitempty"`
+	EstimatedCost *money.Amount   `json:"estimated_cost,omitempty"`
+	Currency      *money.Currency `json:"currency,omitempty"`
+	TimeZone      *calendar.Zone  `json:"time_zone,omitempty"`
+	Address       *string         `json:"address,omitempty"`
+	Status        *ProjectStatus  `json:"status,omitempty"`
 }
 
 type AssignContractorRequest struct {
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type ProjectForeman struct {
+	ID                   string    `json:"id"`
+	ProjectID            string    `json:"project_id"`
+	EmployeeID           string    `json:"employee_id"`
+	UserID               string    `json:"user_id"`
+	Name                 string    `json:"name"`
+	Email                string    `json:"email"`
+	OrganizationID       string    `json:"organization_id"`
+	ContractID           string    `json:"contract_id"`
+	CanPostUpdates       bool      `json:"can_post_updates"`
+	CanLogExpenses       bool      `json:"can_log_expenses"`
+	CanApproveTimesheets bool      `json:"can_approve_timesheets"`
+	CanViewCrewHours     bool      `json:"can_view_crew_hours"`
+	GrantedBy            *string   `json:"granted_by,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type SetProjectForemanRequest struct {
+	EmployeeID           string `json:"employee_id"`
+	CanPostUpdates       bool   `json:"can_post_updates"`
+	CanLogExpenses       bool   `json:"can_log_expenses"`
+	CanApproveTimesheets bool   `json:"can_approve_timesheets"`
+	CanViewCrewHours     bool   `json:"can_view_crew_hours"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type ProjectMemberRole string
+
+const (
+	ProjectMemberRoleCoOwner ProjectMemberRole = "co_owner"
+	ProjectMemberRoleViewer  ProjectMemberRole = "viewer"
+)
+
+type ProjectMember struct {
+	ID                  string            `json:"id"`
+	ProjectID           string            `json:"project_id"`
+	UserID              string            `json:"user_id"`
+	Name                string            `json:"name"`
+	Email               string            `json:"email"`
+	Role                ProjectMemberRole `json:"role"`
+	CanApproveEstimates bool              `json:"can_approve_estimates"`
+	CanConfirmPayments  bool              `json:"can_confirm_payments"`
+	AddedBy             *string           `json:"added_by,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+type ProjectMemberInvitation struct {
+	ID                  string            `json:"id"`
+	ProjectID           string            `json:"project_id"`
+	InvitedBy           string            `json:"invited_by"`
+	Email               string            `json:"email"`
+	Role                ProjectMemberRole `json:"role"`
+	CanApproveEstimates bool              `json:"can_approve_estimates"`
+	CanConfirmPayments  bool              `json:"can_confirm_payments"`
+	Token               string            `json:"-"`
+	Status              InvitationStatus  `json:"status"`
+	ExpiresAt           time.Time         `json:"expires_at"`
+	AcceptedBy          *string           `json:"accepted_by,omitempty"`
+	AcceptedAt          *time.Time        `json:"accepted_at,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+}
+
+type InviteProjectMemberRequest struct {
+	Email               string            `json:"email"`
+	Role                ProjectMemberRole `json:"role"`
+	CanApproveEstimates bool              `json:"can_approve_estimates"`
+	CanConfirmPayments  bool              `json:"can_confirm_payments"`
+}
+
+type UpdateProjectMemberRequest struct {
+	Role                *ProjectMemberRole `json:"role,omitempty"`
+	CanApproveEstimates *bool              `json:"can_approve_estimates,omitempty"`
+	CanConfirmPayments  *bool              `json:"can_confirm_payments,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type SearchResultType string
+
+const (
+	SearchResultExpense  SearchResultType = "expense"
+	SearchResultUpdate   SearchResultType = "update"
+	SearchResultPhoto    SearchResultType = "photo"
+	SearchResultPayment  SearchResultType = "payment"
+	SearchResultEstimate SearchResultType = "estimate"
+	SearchResultContract SearchResultType = "contract"
+)
+
+type SearchResult struct {
+	Type         SearchResultType `json:"type"`
+	ID           string           `json:"id"`
+	ProjectID    string           `json:"project_id"`
+	ProjectTitle string           `json:"project_title"`
+	ContractID   *string          `json:"contract_id,omitempty"`
+	// Highlight is HTML-escaped text with matches wrapped in <mark> tags.
+	Highlight string    `json:"highlight"`
+	Rank      float64   `json:"rank"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+
+	"github.com/juazsh/managrr/internal/apierror"
+)
+
+type SyncEntityType string
+
+const (
+	SyncEntityWorkLog SyncEntityType = "work_log"
+	SyncEntityExpense SyncEntityType = "expense"
+)
+
+// SyncResponse holds everything the user can see that changed since their
+// watermark. ProjectIDs lists every project they can see now; clients drop
+// their copy of any other project and what belongs to it.
+type SyncResponse struct {
+	Watermark  string          `json:"watermark"`
+	Full       bool            `json:"full"`
+	ProjectIDs []string        `json:"project_ids"`
+	Projects   []Project       `json:"projects"`
+	WorkLogs   []WorkLog       `json:"work_logs"`
+	Expenses   []Expense       `json:"expenses"`
+	Deleted    []SyncTombstone `json:"deleted"`
+}
+
+// SyncTombstone reports a deleted expense or work log.
+type SyncTombstone struct {
+	Type      SyncEntityType `json:"type"`
+	ID        string         `json:"id"`
+	ProjectID string         `json:"project_id"`
+	DeletedAt time.Time      `json:"deleted_at"`
+}
+
+type SyncItemType string
+
+const (
+	SyncItemCheckIn  SyncItemType = "check_in"
+	SyncItemCheckOut SyncItemType = "check_out"
+	SyncItemExpense  SyncItemType = "expense"
+)
+
+// SyncUploadItem is one action queued while offline. ClientID is generated
+// by the client and makes uploading the item again harmless. A check-out
+// names its work log by WorkLogID or, when the check-in was queued too, by
+// the check-in's CheckInClientID. Photo names the multipart file field holding
+// a check-in or check-out photo.
+type SyncUploadItem struct {
+	ClientID        string           `json:"client_id"`
+	Type            SyncItemType     `json:"type"`
+	OccurredAt      *time.Time       `json:"occurred_at,omitempty"`
+	ProjectID       string           `json:"project_id,omitempty"`
+	WorkLogID       string           `json:"work_log_id,omitempty"`
+	CheckInClientID string           `json:"check_in_client_id,omitempty"`
+	Photo           string           `json:"photo,omitempty"`
+	Latitude        *float64         `json:"latitude,omitempty"`
+	Longitude       *float64         `json:"longitude,omitempty"`
+	ContractID      string           `json:"contract_id,omitempty"`
+	Expense         *BulkExpenseItem `json:"expense,omitempty"`
+}
+
+// SyncUploadResult is the outcome of one uploaded item. ID is the record it
+// created. Duplicate is set when the item had already been uploaded, in
+// which case ID is the record created then.
+type SyncUploadResult struct {
+	Index     int             `json:"index"`
+	ClientID  string          `json:"client_id"`
+	Type      SyncItemType    `json:"type"`
+	ID        string          `json:"id,omitempty"`
+	Status    int             `json:"status"`
+	Duplicate bool            `json:"duplicate,omitempty"`
+	Error     *apierror.Error `json:"error,omitempty"`
+}
+
+type SyncUploadResponse struct {
+	Results   []SyncUploadResult `json:"results"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+const (
+	TrashItemProject  = "project"
+	TrashItemExpense  = "expense"
+	TrashItemPayment  = "payment"
+	TrashItemEmployee = "employee"
+)
+
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	ProjectID *string   `json:"project_id,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/juazsh/managrr/internal/calendar"
 )
 
 type UserType string
@@ -10,20 +12,24 @@ const (
 	UserTypeHouseOwner UserType = "house_owner"
 	UserTypeContractor UserType = "contractor"
 	UserTypeEmployee   UserType = "employee"
+	UserTypeAdmin      UserType = "admin"
 )
 
 type User struct {
-	ID                         string     `json:"id"`
-	Email                      string     `json:"email"`
-	Phone                      *string    `json:"phone,omitempty"`
-	PasswordHash               string     `json:"-"`
-	UserType                   UserType   `json:"user_type"`
-	Name                       string     `json:"name"`
-	EmailVerified              bool       `json:"email_verified"`
-	VerificationToken          *string    `json:"-"`
-	VerificationTokenExpiresAt *time.Time `json:"-"`
-	CreatedAt                  time.Time  `json:"created_at"`
-	UpdatedAt                  time.Time  `json:"updated_at"`
+	ID                         string        `json:"id"`
+	Email                      string        `json:"email"`
+	Phone                      *string       `json:"phone,omitempty"`
+	PasswordHash               string        `json:"-"`
+	UserType                   UserType      `json:"user_type"`
+	Name                       string        `json:"name"`
+	EmailVerified              bool          `json:"email_verified"`
+	TimeZone                   calendar.Zone `json:"time_zone"`
+	VerificationToken          *string       `json:"-"`
+	VerificationTokenExpiresAt *time.Time    `json:"-"`
+	DisabledAt                 *time.Time    `json:"disabled_at,omitempty"`
+	DisabledReason             *string       `json:"disabled_reason,omitempty"`
+	CreatedAt                  time.Time     `json:"created_at"`
+	UpdatedAt                  time.Time     `json:"updated_at"`
 }
 
 type RegisterRequest struct {
@@ -32,6 +38,17 @@ type RegisterRequest struct {
 	Name     string   `json:"name"`
 	Phone    *string  `json:"phone,omitempty"`
 	UserType UserType `json:"user_type"`
+	// TimeZone defaults to UTC.
+	TimeZone calendar.Zone `json:"time_zone,omitempty"`
+
+	InvitationToken *string `json:"invitation_token,omitempty"`
+}
+
+// UpdateCurrentUserRequest changes the caller's own profile. Omitted fields
+// are left as they are.
+type UpdateCurrentUserRequest struct {
+	Name     *string        `json:"name,omitempty"`
+	TimeZone *calendar.Zone `json:"time_zone,omitempty"`
 }
 
 type LoginRequest struct {
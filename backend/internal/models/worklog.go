@@ -10,6 +10,7 @@ type WorkLog struct {
 	ProjectID         string     `json:"project_id"`
 	ContractID        *string    `json:"contract_id,omitempty"`
 	CheckInTime       time.Time  `json:"check_in_time"`
+	WorkDate          string     `json:"work_date"`
 	CheckOutTime      *time.Time `json:"check_out_time,omitempty"`
 	CheckInPhotoURL   string     `json:"check_in_photo_url"`
 	CheckOutPhotoURL  *string    `json:"check_out_photo_url,omitempty"`
@@ -18,6 +19,8 @@ type WorkLog struct {
 	CheckOutLatitude  *float64   `json:"check_out_latitude,omitempty"`
 	CheckOutLongitude *float64   `json:"check_out_longitude,omitempty"`
 	HoursWorked       *float64   `json:"hours_worked,omitempty"`
+	ApprovedBy        *string    `json:"approved_by,omitempty"`
+	ApprovedAt        *time.Time `json:"approved_at,omitempty"`
 	CreatedAt         time.Time  `json:"created_at"`
 }
 
@@ -0,0 +1,124 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code such as USD or CAD.
+type Currency string
+
+// DefaultCurrency is the currency of projects created without one.
+const DefaultCurrency Currency = "USD"
+
+var (
+	ErrUnknownCurrency     = errors.New("must be an ISO 4217 currency code such as USD or CAD")
+	ErrUnsupportedCurrency = errors.New("uses more than two decimal places, which amounts cannot hold")
+)
+
+// currencyDigits lists the active ISO 4217 currencies with the number of
+// decimal places each uses. Fund codes and precious metals are left out.
+var currencyDigits = map[Currency]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2, "AUD": 2,
+	"AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2, "BHD": 3, "BIF": 0,
+	"BMD": 2, "BND": 2, "BOB": 2, "BRL": 2, "BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2,
+	"BZD": 2, "CAD": 2, "CDF": 2, "CHF": 2, "CLP": 0, "CNY": 2, "COP": 2, "CRC": 2,
+	"CUP": 2, "CVE": 2, "CZK": 2, "DJF": 0, "DKK": 2, "DOP": 2, "DZD": 2, "EGP": 2,
+	"ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2, "FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2,
+	"GIP": 2, "GMD": 2, "GNF": 0, "GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2,
+	"HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2,
+	"JOD": 3, "JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0,
+	"KWD": 3, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2, "LSL": 2,
+	"LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2, "MNT": 2, "MOP": 2,
+	"MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NAD": 2,
+	"NGN": 2, "NIO": 2, "NOK": 2, "NPR": 2, "NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2,
+	"PGK": 2, "PHP": 2, "PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2,
+	"RUB": 2, "RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2,
+	"SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2, "SYP": 2,
+	"SZL": 2, "THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2, "TRY": 2, "TTD": 2,
+	"TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0, "USD": 2, "UYU": 2, "UZS": 2, "VES": 2,
+	"VND": 0, "VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XOF": 0, "XPF": 0, "YER": 2,
+	"ZAR": 2, "ZMW": 2, "ZWG": 2,
+}
+
+// currencySymbols are the symbols used when formatting. Currencies without
+// one are written with their code.
+var currencySymbols = map[Currency]string{
+	"USD": "$", "CAD": "CA$", "EUR": "€", "GBP": "£", "AUD": "A$", "NZD": "NZ$",
+	"MXN": "MX$", "JPY": "¥", "CNY": "CN¥", "INR": "₹", "HKD": "HK$", "KRW": "₩",
+	"BRL": "R$", "ILS": "₪",
+}
+
+// ParseCurrency reads a currency code, in any case. Currencies that use more
+// than two decimal places are rejected because an Amount holds cents.
+func ParseCurrency(s string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(s)))
+	digits, ok := currencyDigits[c]
+	if !ok {
+		return "", ErrUnknownCurrency
+	}
+	if digits > 2 {
+		return "", ErrUnsupportedCurrency
+	}
+	return c, nil
+}
+
+// Digits returns the number of decimal places the currency uses.
+func (c Currency) Digits() int {
+	if digits, ok := currencyDigits[c]; ok {
+		return digits
+	}
+	return 2
+}
+
+// Check reports whether a can be paid in the currency, which for currencies
+// without minor units means it must be whole.
+func (c Currency) Check(a Amount) error {
+	if c.Digits() == 0 && a%100 != 0 {
+		return fmt.Errorf("must be a whole number of %s", c)
+	}
+	return nil
+}
+
+// Format writes a for display, e.g. "$1,250.50", "CA$80.00" or "CHF 12.00".
+func (c Currency) Format(a Amount) string {
+	sign := ""
+	n := int64(a)
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+
+	number := group(n / 100)
+	if c.Digits() > 0 {
+		number += fmt.Sprintf(".%02d", n%100)
+	}
+
+	if symbol, ok := currencySymbols[c]; ok {
+		return sign + symbol + number
+	}
+	return sign + string(c) + " " + number
+}
+
+// ExcelFormat is a spreadsheet number format that shows amounts the way
+// Format does.
+func (c Currency) ExcelFormat() string {
+	prefix := string(c) + " "
+	if symbol, ok := currencySymbols[c]; ok {
+		prefix = symbol
+	}
+	format := `"` + prefix + `"#,##0`
+	if c.Digits() > 0 {
+		format += ".00"
+	}
+	return format
+}
+
+func group(n int64) string {
+	s := fmt.Sprintf("%d", n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,159 @@
+// Package money represents currency amounts exactly.
+//
+// An Amount is a whole number of cents. It is parsed from and written to
+// Postgres NUMERIC columns and JSON as decimal text, never through a float,
+// so totals in reports add up to the cent. Amounts entered by users are never
+// negative and carry at most two decimal places; anything else is rejected
+// rather than rounded.
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Amount is a currency amount in cents.
+type Amount int64
+
+var (
+	ErrInvalid   = errors.New("must be a decimal number like 1250.50")
+	ErrPrecision = errors.New("must have at most two decimal places")
+	ErrNegative  = errors.New("must not be negative")
+	ErrTooLarge  = errors.New("is too large")
+)
+
+// Parse reads a non-negative decimal such as "1250", "1250.5" or "1250.50".
+// Signs, exponents, thousands separators and a third decimal place are all
+// errors.
+func Parse(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		if _, err := parse(s[1:], maxInputDigits); err == nil {
+			return 0, ErrNegative
+		}
+		return 0, ErrInvalid
+	}
+	return parse(s, maxInputDigits)
+}
+
+// Inputs are limited to what a DECIMAL(12, 2) column holds. Values read back
+// from the database may be sums, so they are allowed as much as fits in an
+// int64.
+const (
+	maxInputDigits = 10
+	maxScanDigits  = 16
+)
+
+func parse(s string, maxDigits int) (Amount, error) {
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !digits(whole) || !digits(frac) || hasPoint && frac == "" {
+		return 0, ErrInvalid
+	}
+	if len(frac) > 2 {
+		if strings.TrimRight(frac[2:], "0") != "" {
+			return 0, ErrPrecision
+		}
+		frac = frac[:2]
+	}
+
+	whole = strings.TrimLeft(whole, "0")
+	if len(whole) > maxDigits {
+		return 0, ErrTooLarge
+	}
+
+	cents, _ := strconv.ParseInt(whole+(frac + "00")[:2], 10, 64)
+	return Amount(cents), nil
+}
+
+func digits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Cents returns the amount as a whole number of cents.
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+// Float64 returns the amount in currency units, for spreadsheet cells and
+// other outputs that only take floats. Do not do arithmetic on the result.
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+// String formats the amount with exactly two decimal places.
+func (a Amount) String() string {
+	sign := ""
+	n := int64(a)
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, n/100, n%100)
+}
+
+// MarshalJSON writes the amount as a JSON number with two decimal places.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, with the same rules as Parse. Strings
+// are refused, as the API documents amounts as numbers.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		return fmt.Errorf("money: amount must be a JSON number, not a string")
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Scan reads a NUMERIC column. Postgres sends those as text, which is parsed
+// exactly; integers are accepted for COUNT-style expressions.
+func (a *Amount) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		*a = Amount(v * 100)
+		return nil
+	case nil:
+		*a = 0
+		return nil
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	v, err := parse(strings.TrimPrefix(s, "-"), maxScanDigits)
+	if err != nil {
+		return fmt.Errorf("money: cannot scan %q: %w", s, err)
+	}
+	if negative {
+		v = -v
+	}
+	*a = v
+	return nil
+}
+
+// Value writes the amount as decimal text for a NUMERIC parameter.
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
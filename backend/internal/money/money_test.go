@@ -0,0 +1,198 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+		err  error
+	}{
+		{"1250", 125000, nil},
+		{"1250.5", 125050, nil},
+		{"1250.50", 125050, nil},
+		{" 0.01 ", 1, nil},
+		{".5", 50, nil},
+		{"007", 700, nil},
+		{"1250.500", 125050, nil},
+		{"9999999999.99", 999999999999, nil},
+
+		{"-1", 0, ErrNegative},
+		{"-", 0, ErrInvalid},
+		{"-abc", 0, ErrInvalid},
+		{"+1", 0, ErrInvalid},
+		{"1e3", 0, ErrInvalid},
+		{"1E3", 0, ErrInvalid},
+		{"1,250", 0, ErrInvalid},
+		{"", 0, ErrInvalid},
+		{".", 0, ErrInvalid},
+		{"1.", 0, ErrInvalid},
+		{"1.2.3", 0, ErrInvalid},
+		{"NaN", 0, ErrInvalid},
+		{"1250.505", 0, ErrPrecision},
+		{"0.001", 0, ErrPrecision},
+		{"12345678901", 0, ErrTooLarge},
+		{"00012345678901.00", 0, ErrTooLarge},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != tt.err || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v; want %d, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{`1250.5`, 125050, false},
+		{`0`, 0, false},
+		{`"1250.50"`, 0, true},
+		{`""`, 0, true},
+		{`-3`, 0, true},
+		{`1.005`, 0, true},
+		{`1e3`, 0, true},
+	}
+	for _, tt := range tests {
+		var got Amount
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	// null leaves the amount as it was, so optional fields can be omitted.
+	got := Amount(42)
+	if err := json.Unmarshal([]byte(`null`), &got); err != nil || got != 42 {
+		t.Errorf("Unmarshal(null) = %d, %v; want 42 unchanged", got, err)
+	}
+
+	out, err := json.Marshal(struct {
+		A Amount `json:"a"`
+		B Amount `json:"b"`
+	}{125050, -5})
+	if err != nil || string(out) != `{"a":1250.50,"b":-0.05}` {
+		t.Errorf("Marshal = %s, %v", out, err)
+	}
+}
+
+func TestRateJSON(t *testing.T) {
+	var r Rate
+	if err := json.Unmarshal([]byte(`"1.35"`), &r); err == nil {
+		t.Errorf("Unmarshal of a string rate = %q, want an error", r)
+	}
+	if err := json.Unmarshal([]byte(`1.3500`), &r); err != nil || r != "1.35" {
+		t.Errorf("Unmarshal(1.3500) = %q, %v; want 1.35", r, err)
+	}
+	for _, in := range []string{`0`, `-1`, `1.123456789`, `1e2`} {
+		if err := json.Unmarshal([]byte(in), &r); err == nil {
+			t.Errorf("Unmarshal(%s) = %q, want an error", in, r)
+		}
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    Amount
+		wantErr bool
+	}{
+		{[]byte("1250.50"), 125050, false},
+		{"1250.5", 125050, false},
+		{"-12.34", -1234, false},
+		{"12.3400", 1234, false},
+		{"1234567890123456.78", 123456789012345678, false},
+		{int64(3), 300, false},
+		{nil, 0, false},
+		{"12.345", 0, true},
+		{"abc", 0, true},
+		{"12345678901234567", 0, true},
+		{12.5, 0, true},
+	}
+	for _, tt := range tests {
+		got := Amount(99)
+		err := got.Scan(tt.src)
+		if (err != nil) != tt.wantErr || !tt.wantErr && got != tt.want {
+			t.Errorf("Scan(%#v) = %d, %v; want %d, error %v", tt.src, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValueScanRoundTrip(t *testing.T) {
+	for _, a := range []Amount{0, 1, 5, 99, 100, 125050, -1234, 999999999999} {
+		v, err := a.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Amount
+		if err := got.Scan(v); err != nil || got != a {
+			t.Errorf("Scan(Value(%d)) = %d, %v", a, got, err)
+		}
+	}
+
+	for _, r := range []Rate{"1", "1.35", "0.00000001", "149.505"} {
+		v, err := r.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got Rate
+		if err := got.Scan(v); err != nil || got != r {
+			t.Errorf("Scan(Value(%q)) = %q, %v", r, got, err)
+		}
+	}
+
+	// Postgres pads NUMERIC(18, 8) rates with zeros.
+	var r Rate
+	if err := r.Scan([]byte("1.35000000")); err != nil || r != "1.35" {
+		t.Errorf("Scan(1.35000000) = %q, %v; want 1.35", r, err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount Amount
+		rate   Rate
+		to     Currency
+		want   Amount
+	}{
+		// Two-digit currencies round to the cent, halves away from zero.
+		{10000, "1.3642", "CAD", 13642},
+		{5, "0.5", "EUR", 3},
+		{-5, "0.5", "EUR", -3},
+		{4, "0.5", "EUR", 2},
+		{1, "0.49", "EUR", 0},
+		{125050, "", "USD", 125050},
+		{125050, OneToOne, "USD", 125050},
+
+		// Zero-digit currencies round to whole units.
+		{10000, "149.505", "JPY", 1495100},
+		{10000, "149.494", "JPY", 1494900},
+		{1, "149", "JPY", 100},
+		{1, "49", "JPY", 0},
+		{1, "50", "JPY", 100},
+		{-1, "50", "JPY", -100},
+		{123456, "1", "KRW", 123500},
+	}
+	for _, tt := range tests {
+		got, err := tt.amount.Convert(tt.rate, tt.to)
+		if err != nil || got != tt.want {
+			t.Errorf("%d.Convert(%q, %s) = %d, %v; want %d", tt.amount, tt.rate, tt.to, got, err, tt.want)
+		}
+	}
+
+	for _, rate := range []Rate{"abc", "0", "-1.5", "1.123456789", "1e3"} {
+		if got, err := Amount(100).Convert(rate, "USD"); err != ErrInvalidRate {
+			t.Errorf("Convert(%q) = %d, %v; want ErrInvalidRate", rate, got, err)
+		}
+	}
+
+	if got, err := Amount(1<<62).Convert("1000", "USD"); err != ErrTooLarge {
+		t.Errorf("Convert overflowing = %d, %v; want ErrTooLarge", got, err)
+	}
+}
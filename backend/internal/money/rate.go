@@ -0,0 +1,138 @@
+package money
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rate is an exchange rate: how many units of one currency a unit of another
+// buys. It is kept as decimal text, up to eight decimal places, so it can be
+// stored and applied without rounding.
+type Rate string
+
+// OneToOne converts a currency to itself.
+const OneToOne Rate = "1"
+
+var ErrInvalidRate = errors.New("must be a positive decimal with at most eight decimal places")
+
+// ParseRate reads a positive rate such as "1.3642" or "0.73".
+func ParseRate(s string) (Rate, error) {
+	s = strings.TrimSpace(s)
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	if whole == "" && frac == "" || !digits(whole) || !digits(frac) || hasPoint && frac == "" || len(whole) > 10 {
+		return "", ErrInvalidRate
+	}
+	if len(frac) > 8 {
+		return "", ErrInvalidRate
+	}
+
+	r := normalizeRate(s)
+	if strings.Trim(string(r), "0.") == "" {
+		return "", ErrInvalidRate
+	}
+	return r, nil
+}
+
+// normalizeRate drops leading zeros and trailing decimal zeros, so the rate
+// Postgres returns as 1.35000000 reads back as 1.35.
+func normalizeRate(s string) Rate {
+	whole, frac, _ := strings.Cut(s, ".")
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+	frac = strings.TrimRight(frac, "0")
+	if frac == "" {
+		return Rate(whole)
+	}
+	return Rate(whole + "." + frac)
+}
+
+// Convert applies the rate to a, rounding half away from zero to the
+// decimal places of the target currency. The zero Rate converts one to one,
+// as it is written; any other rate ParseRate would refuse is an error.
+func (a Amount) Convert(r Rate, to Currency) (Amount, error) {
+	if r == "" {
+		r = OneToOne
+	}
+	if _, err := ParseRate(string(r)); err != nil {
+		return 0, err
+	}
+	rate, _ := new(big.Rat).SetString(string(r))
+
+	cents := new(big.Rat).Mul(new(big.Rat).SetInt64(int64(a)), rate)
+
+	unit := int64(1)
+	if to.Digits() == 0 {
+		unit = 100
+	}
+	cents.Quo(cents, new(big.Rat).SetInt64(unit))
+
+	num, den := cents.Num(), cents.Denom()
+	q, m := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(m), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	q.Mul(q, big.NewInt(unit))
+	if !q.IsInt64() {
+		return 0, ErrTooLarge
+	}
+	return Amount(q.Int64()), nil
+}
+
+// MarshalJSON writes the rate as a JSON number.
+func (r Rate) MarshalJSON() ([]byte, error) {
+	if r == "" {
+		return []byte(OneToOne), nil
+	}
+	return []byte(r), nil
+}
+
+// UnmarshalJSON accepts a JSON number, with the same rules as ParseRate. Strings
+// are refused, as the API documents rates as numbers.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		return fmt.Errorf("money: rate must be a JSON number, not a string")
+	}
+	v, err := ParseRate(s)
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// Scan reads a NUMERIC column.
+func (r *Rate) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		*r = normalizeRate(string(v))
+	case string:
+		*r = normalizeRate(v)
+	case int64:
+		*r = Rate(strconv.FormatInt(v, 10))
+	default:
+		return fmt.Errorf("money: cannot scan rate from %T", src)
+	}
+	return nil
+}
+
+// Value writes the rate as decimal text for a NUMERIC parameter.
+func (r Rate) Value() (driver.Value, error) {
+	if r == "" {
+		return string(OneToOne), nil
+	}
+	return string(r), nil
+}
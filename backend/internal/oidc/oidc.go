@@ -0,0 +1,426 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const cacheTTL = 15 * time.Minute
+
+// httpClient only connects to public addresses, so an issuer cannot point
+// discovery, token or key requests at the server's own network.
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if ip := net.ParseIP(host); ip == nil || !publicAddress(ip) {
+					return ErrPrivateAddress
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+var (
+	ErrInsecureURL    = errors.New("provider URLs must use https")
+	ErrPrivateAddress = errors.New("provider URLs must not point at a private or loopback address")
+)
+
+// AllowInsecure reports whether http and private addresses are allowed for
+// providers, which SSO_ALLOW_INSECURE_ISSUERS=true turns on for development
+// against cmd/mockidp.
+func AllowInsecure() bool {
+	return os.Getenv("SSO_ALLOW_INSECURE_ISSUERS") == "true"
+}
+
+func publicAddress(ip net.IP) bool {
+	if AllowInsecure() {
+		return true
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// CheckURL rejects provider URLs that are not https or that name a loopback
+// or private host. Names that resolve to such addresses are refused when
+// httpClient connects.
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return fmt.Errorf("%q is not an absolute http(s) URL", raw)
+	}
+	if AllowInsecure() {
+		return nil
+	}
+	if u.Scheme != "https" {
+		return ErrInsecureURL
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrPrivateAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && !publicAddress(ip) {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+type Discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type IDTokenClaims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	IDToken     string `json:"id_token"`
+	TokenType   string `json:"token_type"`
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type cachedDiscovery struct {
+	doc       *Discovery
+	fetchedAt time.Time
+}
+
+type cachedKeys struct {
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+var (
+	cacheMu        sync.Mutex
+	discoveryCache = map[string]cachedDiscovery{}
+	jwksCache      = map[string]cachedKeys{}
+)
+
+func Discover(issuer string) (*Discovery, error) {
+	issuer = strings.TrimRight(issuer, "/")
+
+	cacheMu.Lock()
+	cached, ok := discoveryCache[issuer]
+	cacheMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < cacheTTL {
+		return cached.doc, nil
+	}
+
+	if err := CheckURL(issuer); err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Get(issuer + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("discovery document returned status %d", resp.StatusCode)
+	}
+
+	var doc Discovery
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode discovery document: %w", err)
+	}
+
+	if strings.TrimRight(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match configured issuer %q", doc.Issuer, issuer)
+	}
+
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document is missing required endpoints")
+	}
+
+	for _, endpoint := range []string{doc.AuthorizationEndpoint, doc.TokenEndpoint, doc.JWKSURI} {
+		if err := CheckURL(endpoint); err != nil {
+			return nil, fmt.Errorf("discovery document endpoint: %w", err)
+		}
+	}
+
+	cacheMu.Lock()
+	discoveryCache[issuer] = cachedDiscovery{doc: &doc, fetchedAt: time.Now()}
+	cacheMu.Unlock()
+
+	return &doc, nil
+}
+
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+func GenerateState() (string, error) {
+	return randomString(24)
+}
+
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func AuthorizationURL(doc *Discovery, clientID, redirectURI, scopes, state, nonce, codeVerifier string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", clientID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("scope", scopes)
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", CodeChallenge(codeVerifier))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return doc.AuthorizationEndpoint + separator + params.Encode()
+}
+
+func ExchangeCode(doc *Discovery, clientID, clientSecret, redirectURI, code, codeVerifier string) (*TokenResponse, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", clientID)
+	form.Set("code_verifier", codeVerifier)
+	if clientSecret != "" {
+		form.Set("client_secret", clientSecret)
+	}
+
+	req, err := http.NewRequest("POST", doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var tokens TokenResponse
+	if err := json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("token response did not include an id_token")
+	}
+
+	return &tokens, nil
+}
+
+func VerifyIDToken(doc *Discovery, clientID, rawIDToken, expectedNonce string) (*IDTokenClaims, error) {
+	claims := &IDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return signingKey(doc.JWKSURI, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if claims.Nonce != expectedNonce {
+		return nil, fmt.Errorf("id_token nonce mismatch")
+	}
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id_token is missing the sub claim")
+	}
+
+	return claims, nil
+}
+
+func signingKey(jwksURI, kid string) (interface{}, error) {
+	keys, err := fetchKeys(jwksURI, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+
+	// The provider may have rotated keys since we cached them.
+	keys, err = fetchKeys(jwksURI, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, ok := lookupKey(keys, kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("no signing key found for kid %q", kid)
+}
+
+func lookupKey(keys map[string]interface{}, kid string) (interface{}, bool) {
+	if kid != "" {
+		key, ok := keys[kid]
+		return key, ok
+	}
+	if len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+func fetchKeys(jwksURI string, force bool) (map[string]interface{}, error) {
+	cacheMu.Lock()
+	cached, ok := jwksCache[jwksURI]
+	cacheMu.Unlock()
+	if ok && !force && time.Since(cached.fetchedAt) < cacheTTL {
+		return cached.keys, nil
+	}
+
+	resp, err := httpClient.Get(jwksURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = key
+	}
+
+	cacheMu.Lock()
+	jwksCache[jwksURI] = cachedKeys{keys: keys, fetchedAt: time.Now()}
+	cacheMu.Unlock()
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func randomString(n int) (string, error) {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juazsh/managrr/internal/oidc/oidctest"
+)
+
+func TestCheckURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://login.example.com", nil},
+		{"https://login.example.com/realms/acme", nil},
+		{"http://login.example.com", ErrInsecureURL},
+		{"https://localhost:9000", ErrPrivateAddress},
+		{"https://idp.localhost", ErrPrivateAddress},
+		{"https://127.0.0.1", ErrPrivateAddress},
+		{"https://[::1]", ErrPrivateAddress},
+		{"https://10.0.0.5", ErrPrivateAddress},
+		{"https://192.168.1.10", ErrPrivateAddress},
+		{"https://169.254.169.254", ErrPrivateAddress},
+		{"https://0.0.0.0", ErrPrivateAddress},
+	}
+	for _, tt := range tests {
+		if err := CheckURL(tt.url); err != tt.want {
+			t.Errorf("CheckURL(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+
+	for _, raw := range []string{"", "login.example.com", "ftp://login.example.com", "https://"} {
+		if err := CheckURL(raw); err == nil || err == ErrInsecureURL || err == ErrPrivateAddress {
+			t.Errorf("CheckURL(%q) = %v, want a malformed URL error", raw, err)
+		}
+	}
+}
+
+func TestCheckURLAllowsInsecureInDevelopment(t *testing.T) {
+	t.Setenv("SSO_ALLOW_INSECURE_ISSUERS", "true")
+	for _, raw := range []string{"http://localhost:9000", "http://127.0.0.1:9000", "https://10.0.0.5"} {
+		if err := CheckURL(raw); err != nil {
+			t.Errorf("CheckURL(%q) = %v, want it allowed", raw, err)
+		}
+	}
+}
+
+// A provider whose name resolves to a private address is refused when
+// connecting, not only when the URL names the address itself.
+func TestDiscoverRefusesPrivateAddresses(t *testing.T) {
+	idp, err := oidctest.New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewTLSServer(idp)
+	defer server.Close()
+	idp.Issuer = server.URL
+
+	if _, err := httpClient.Get(server.URL + "/.well-known/openid-configuration"); !errors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("fetching from a loopback address: %v, want ErrPrivateAddress", err)
+	}
+	if _, err := Discover(server.URL); err != ErrPrivateAddress {
+		t.Fatalf("Discover = %v, want ErrPrivateAddress", err)
+	}
+}
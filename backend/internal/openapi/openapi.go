@@ -0,0 +1,162 @@
+// Package openapi holds the API's OpenAPI 3 document and serves it.
+//
+// openapi.yaml is the contract for every route registered by internal/routes.
+// It is embedded in the binary, checked against the router by the tests and at
+// startup, and used by middleware.ValidateRequest to reject requests that do
+// not match it.
+package openapi
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/money"
+)
+
+//go:embed openapi.yaml
+var specYAML []byte
+
+var (
+	loadOnce sync.Once
+	doc      *openapi3.T
+	specJSON []byte
+	loadErr  error
+)
+
+func init() {
+	openapi3.DefineNumberFormatValidator("money", moneyFormat{})
+}
+
+// moneyFormat backs `format: money`: a non-negative amount with at most two
+// decimal places, checked by the same rules as money.Parse.
+type moneyFormat struct{}
+
+func (moneyFormat) Validate(value float64) error {
+	_, err := money.Parse(strconv.FormatFloat(value, 'f', -1, 64))
+	return err
+}
+
+// Load parses and validates the embedded document. It is safe to call more
+// than once; the document is only parsed the first time.
+func Load() (*openapi3.T, error) {
+	loadOnce.Do(func() {
+		loader := openapi3.NewLoader()
+		doc, loadErr = loader.LoadFromData(specYAML)
+		if loadErr != nil {
+			loadErr = fmt.Errorf("parse openapi.yaml: %w", loadErr)
+			return
+		}
+		if loadErr = doc.Validate(context.Background()); loadErr != nil {
+			loadErr = fmt.Errorf("validate openapi.yaml: %w", loadErr)
+			return
+		}
+		specJSON, loadErr = json.Marshal(doc)
+	})
+	return doc, loadErr
+}
+
+// CheckRoutes compares the router with the document and describes every
+// route that is missing from it and every documented operation that has no
+// route. An empty result means the two agree.
+func CheckRoutes(router *mux.Router) ([]string, error) {
+	if _, err := Load(); err != nil {
+		return nil, err
+	}
+
+	registered := map[string]bool{}
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Routes without methods are prefixes such as the static file
+			// server, not API operations.
+			return nil
+		}
+		for _, method := range methods {
+			if method == http.MethodOptions {
+				continue
+			}
+			registered[method+" "+path] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	documented := map[string]bool{}
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			documented[method+" "+path] = true
+		}
+	}
+
+	var problems []string
+	for route := range registered {
+		if !documented[route] {
+			problems = append(problems, route+" is registered but not documented")
+		}
+	}
+	for route := range documented {
+		if !registered[route] {
+			problems = append(problems, route+" is documented but not registered")
+		}
+	}
+	sort.Strings(problems)
+
+	return problems, nil
+}
+
+// ServeSpec serves the document as JSON.
+func ServeSpec(w http.ResponseWriter, r *http.Request) {
+	if _, err := Load(); err != nil {
+		http.Error(w, "OpenAPI document unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(specJSON)
+}
+
+// ServeDocs serves a Swagger UI page for the document.
+func ServeDocs(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(strings.TrimSpace(docsPage)))
+}
+
+const docsPage = `
+<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Managrr API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      url: '/api/openapi.json',
+      dom_id: '#swagger-ui',
+      persistAuthorization: true,
+    });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,22 @@
+package openapi_test
+
+import (
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/openapi"
+	"github.com/juazsh/managrr/internal/routes"
+)
+
+func TestRoutesMatchDocument(t *testing.T) {
+	router := mux.NewRouter()
+	routes.Register(router)
+
+	problems, err := openapi.CheckRoutes(router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, problem := range problems {
+		t.Error(problem)
+	}
+}
@@ -0,0 +1,290 @@
+// Package pagination implements the cursor pagination, sorting and filtering
+// shared by every list endpoint.
+//
+// Lists are paged with keyset cursors over (sort column, id), so a page is as
+// cheap to fetch deep into a project's history as at the start. Cursors are
+// opaque to clients and only valid for the sort they were issued under.
+package pagination
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// SortField maps a sort key clients may request to its column. Type is the
+// Postgres type cursor values are cast back to. Column may be an expression
+// and must never be NULL, so wrap nullable columns in COALESCE.
+type SortField struct {
+	Column string
+	Type   string
+}
+
+// Spec describes what a list endpoint supports.
+type Spec struct {
+	Sorts map[string]SortField
+	// DefaultSort is a key of Sorts, prefixed with "-" for descending.
+	DefaultSort string
+	IDColumn    string
+	// DateColumn is filtered by the from/to range. Leave empty when the list
+	// has no natural date.
+	DateColumn string
+	// Filters maps query parameters to the column they match exactly.
+	Filters map[string]string
+}
+
+// Params is a parsed list request.
+type Params struct {
+	Limit int
+
+	spec    Spec
+	sort    string
+	field   SortField
+	desc    bool
+	after   []interface{}
+	from    *time.Time
+	to      *time.Time
+	filters map[string]string
+}
+
+// Page is the envelope every list endpoint responds with.
+type Page[T any] struct {
+	Data       []T     `json:"data"`
+	NextCursor *string `json:"next_cursor"`
+	Total      int     `json:"total"`
+}
+
+type cursorPayload struct {
+	Sort string          `json:"s"`
+	Key  json.RawMessage `json:"k"`
+}
+
+// Parse reads limit, sort, cursor, from/to and the spec's filters from the
+// query string. Errors are safe to show to the client.
+func Parse(r *http.Request, spec Spec) (*Params, error) {
+	query := r.URL.Query()
+
+	p := &Params{
+		Limit:   DefaultLimit,
+		spec:    spec,
+		filters: map[string]string{},
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return nil, errors.New("limit must be a positive integer")
+		}
+		if limit > MaxLimit {
+			limit = MaxLimit
+		}
+		p.Limit = limit
+	}
+
+	p.sort = query.Get("sort")
+	if p.sort == "" {
+		p.sort = spec.DefaultSort
+	}
+	key := strings.TrimPrefix(p.sort, "-")
+	field, ok := spec.Sorts[key]
+	if !ok {
+		return nil, fmt.Errorf("sort must be one of %s", strings.Join(sortKeys(spec), ", "))
+	}
+	p.field = field
+	p.desc = strings.HasPrefix(p.sort, "-")
+
+	if raw := query.Get("cursor"); raw != "" {
+		after, err := decodeCursor(raw, p.sort)
+		if err != nil {
+			return nil, err
+		}
+		p.after = after
+	}
+
+	// start_date/end_date predate the shared parameters and are still
+	// accepted.
+	from, err := parseBound(firstOf(query.Get("from"), query.Get("start_date")), false)
+	if err != nil {
+		return nil, errors.New("from must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+	}
+	to, err := parseBound(firstOf(query.Get("to"), query.Get("end_date")), true)
+	if err != nil {
+		return nil, errors.New("to must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+	}
+	p.from, p.to = from, to
+
+	for param := range spec.Filters {
+		if value := query.Get(param); value != "" {
+			p.filters[param] = value
+		}
+	}
+
+	return p, nil
+}
+
+// Filter adds the request's field filters and date range to q. Use the same
+// query for Count and Select so the total matches what is being paged.
+func (p *Params) Filter(q *Query) {
+	for param, value := range p.filters {
+		q.Where(p.spec.Filters[param] + " = " + q.Arg(value))
+	}
+
+	if p.spec.DateColumn != "" {
+		if p.from != nil {
+			q.Where(p.spec.DateColumn + " >= " + q.Arg(*p.from))
+		}
+		if p.to != nil {
+			q.Where(p.spec.DateColumn + " < " + q.Arg(*p.to))
+		}
+	}
+}
+
+// Count returns how many rows match q across all pages.
+func (p *Params) Count(db *sql.DB, from string, q *Query) (int, error) {
+	var total int
+	err := db.QueryRow("SELECT COUNT(*) "+from+q.WhereSQL(), q.Args()...).Scan(&total)
+	return total, err
+}
+
+// Select builds the query for one page. columns must end with CursorColumn so
+// each row's cursor can be scanned last. One extra row is fetched to tell
+// whether another page follows.
+func (p *Params) Select(columns, from string, q *Query) (string, []interface{}) {
+	page := q.clone()
+
+	op := ">"
+	direction := "ASC"
+	if p.desc {
+		op = "<"
+		direction = "DESC"
+	}
+
+	if p.after != nil {
+		sortArg := page.Arg(p.after[0])
+		idArg := page.Arg(p.after[1])
+		page.Where(fmt.Sprintf("(%s, %s) %s (%s::%s, %s::uuid)",
+			p.field.Column, p.spec.IDColumn, op, sortArg, p.field.Type, idArg))
+	}
+
+	return fmt.Sprintf("SELECT %s %s%s ORDER BY %s %s, %s %s LIMIT %d",
+		columns, from, page.WhereSQL(), p.field.Column, direction, p.spec.IDColumn, direction, p.Limit+1), page.Args()
+}
+
+// CursorColumn is the select expression each row's cursor is read from.
+func (p *Params) CursorColumn() string {
+	return fmt.Sprintf("json_build_array(%s, %s)::text", p.field.Column, p.spec.IDColumn)
+}
+
+// NewPage trims the look-ahead row and issues the next cursor. cursors holds
+// the scanned CursorColumn of each item, in order.
+func NewPage[T any](p *Params, items []T, cursors []string, total int) Page[T] {
+	page := Page[T]{Data: items, Total: total}
+	if page.Data == nil {
+		page.Data = []T{}
+	}
+
+	if len(items) > p.Limit {
+		page.Data = items[:p.Limit]
+		next := encodeCursor(p.sort, cursors[p.Limit-1])
+		page.NextCursor = &next
+	}
+
+	return page
+}
+
+func encodeCursor(sortKey, key string) string {
+	payload, _ := json.Marshal(cursorPayload{Sort: sortKey, Key: json.RawMessage(key)})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeCursor(raw, sortKey string) ([]interface{}, error) {
+	invalid := errors.New("cursor is invalid")
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, invalid
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, invalid
+	}
+	if payload.Sort != sortKey {
+		return nil, errors.New("cursor was issued for a different sort")
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(payload.Key)))
+	decoder.UseNumber()
+	var key []interface{}
+	if err := decoder.Decode(&key); err != nil || len(key) != 2 {
+		return nil, invalid
+	}
+
+	// Values go back to Postgres as text and are cast by Select, so numbers
+	// keep their exact representation.
+	for i, v := range key {
+		switch value := v.(type) {
+		case string:
+		case json.Number:
+			key[i] = value.String()
+		default:
+			return nil, invalid
+		}
+	}
+
+	return key, nil
+}
+
+// parseBound accepts a calendar date or an RFC 3339 timestamp. A date used as
+// an upper bound covers the whole day.
+func parseBound(raw string, upper bool) (*time.Time, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		if upper {
+			t = t.Add(time.Microsecond)
+		}
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		return nil, err
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+func firstOf(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func sortKeys(spec Spec) []string {
+	keys := make([]string, 0, len(spec.Sorts))
+	for key := range spec.Sorts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
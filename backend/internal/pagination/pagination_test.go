@@ -0,0 +1,137 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testSpec = Spec{
+	Sorts: map[string]SortField{
+		"created_at": {Column: "p.created_at", Type: "timestamptz"},
+		"cost":       {Column: "COALESCE(p.estimated_cost, 0)", Type: "numeric"},
+	},
+	DefaultSort: "-created_at",
+	IDColumn:    "p.id",
+}
+
+func parse(t *testing.T, query string) (*Params, error) {
+	t.Helper()
+	return Parse(httptest.NewRequest("GET", "/api/projects?"+query, nil), testSpec)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		sort string
+		key  string
+		want []interface{}
+	}{
+		{"-created_at", `["2026-10-19T08:30:00.123456+00:00","9b2f7d4e-0000-4000-8000-000000000001"]`,
+			[]interface{}{"2026-10-19T08:30:00.123456+00:00", "9b2f7d4e-0000-4000-8000-000000000001"}},
+		// Numbers come back as their exact text, not through a float.
+		{"cost", `[1250.50,"9b2f7d4e-0000-4000-8000-000000000002"]`,
+			[]interface{}{"1250.50", "9b2f7d4e-0000-4000-8000-000000000002"}},
+		{"-cost", `[12345678901234567.89,"9b2f7d4e-0000-4000-8000-000000000003"]`,
+			[]interface{}{"12345678901234567.89", "9b2f7d4e-0000-4000-8000-000000000003"}},
+	}
+	for _, tt := range tests {
+		p, err := parse(t, "limit=1&sort="+tt.sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := NewPage(p, []string{"a", "b"}, []string{tt.key, "unused"}, 2)
+		if page.NextCursor == nil {
+			t.Fatalf("%s: no next cursor for a full page", tt.sort)
+		}
+
+		next, err := parse(t, "limit=1&sort="+tt.sort+"&cursor="+*page.NextCursor)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.sort, err)
+		}
+		if !reflect.DeepEqual(next.after, tt.want) {
+			t.Errorf("%s: cursor decoded to %#v, want %#v", tt.sort, next.after, tt.want)
+		}
+	}
+}
+
+func TestCursorRejectsOtherSort(t *testing.T) {
+	p, err := parse(t, "limit=1&sort=cost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := NewPage(p, []int{1, 2}, []string{`[10,"id-1"]`, `[20,"id-2"]`}, 2)
+
+	_, err = parse(t, "sort=-cost&cursor="+*page.NextCursor)
+	if err == nil || !strings.Contains(err.Error(), "different sort") {
+		t.Errorf("err = %v, want the cursor refused for a different sort", err)
+	}
+
+	// Without a sort the default applies, which is not the cursor's either.
+	if _, err := parse(t, "cursor="+*page.NextCursor); err == nil {
+		t.Error("cursor accepted under the default sort")
+	}
+}
+
+func TestCursorRejectsTampering(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	cursors := map[string]string{
+		"not base64":         "!!!",
+		"padded base64":      base64.URLEncoding.EncodeToString([]byte(`{"s":"cost","k":[1,"id"]}`)),
+		"not JSON":           encode("cost:1:id"),
+		"missing key":        encode(`{"s":"cost"}`),
+		"one value":          encode(`{"s":"cost","k":[1]}`),
+		"three values":       encode(`{"s":"cost","k":[1,"id","x"]}`),
+		"object value":       encode(`{"s":"cost","k":[{"a":1},"id"]}`),
+		"null value":         encode(`{"s":"cost","k":[null,"id"]}`),
+		"boolean value":      encode(`{"s":"cost","k":[true,"id"]}`),
+		"key is not a list":  encode(`{"s":"cost","k":"1,id"}`),
+		"injected condition": encode(`{"s":"cost","k":[1,"id"]) OR (1=1"}`),
+	}
+	for name, cursor := range cursors {
+		if _, err := parse(t, "sort=cost&cursor="+cursor); err == nil || err.Error() != "cursor is invalid" {
+			t.Errorf("%s: err = %v, want cursor is invalid", name, err)
+		}
+	}
+}
+
+func TestCursorValuesAreBoundAsArguments(t *testing.T) {
+	p, err := parse(t, "limit=1&sort=cost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := NewPage(p, []int{1, 2}, []string{`[10.5,"id-1') OR ('1'='1"]`, `[20,"id-2"]`}, 2)
+
+	next, err := parse(t, "limit=1&sort=cost&cursor="+*page.NextCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := &Query{}
+	q.Where("p.deleted_at IS NULL")
+	sql, args := next.Select("p.id", "FROM projects p", q)
+
+	want := "SELECT p.id FROM projects p WHERE p.deleted_at IS NULL AND (COALESCE(p.estimated_cost, 0), p.id) > ($1::numeric, $2::uuid) ORDER BY COALESCE(p.estimated_cost, 0) ASC, p.id ASC LIMIT 2"
+	if sql != want {
+		t.Errorf("sql = %s\nwant  %s", sql, want)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"10.5", "id-1') OR ('1'='1"}) {
+		t.Errorf("args = %#v", args)
+	}
+}
+
+func TestLastPageHasNoCursor(t *testing.T) {
+	p, err := parse(t, "limit=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := NewPage(p, []int{1, 2}, []string{`["a","1"]`, `["b","2"]`}, 2)
+	if page.NextCursor != nil || len(page.Data) != 2 {
+		t.Errorf("page = %+v, want both items and no cursor", page)
+	}
+
+	empty := NewPage[int](p, nil, nil, 0)
+	if empty.Data == nil || empty.NextCursor != nil {
+		t.Errorf("empty page = %+v, want an empty list and no cursor", empty)
+	}
+}
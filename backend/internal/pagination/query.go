@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Query collects the WHERE conditions of a list query together with their
+// positional arguments, so conditions can be added in any order without
+// tracking $n by hand.
+type Query struct {
+	conds []string
+	args  []interface{}
+}
+
+// Arg registers a value and returns its placeholder.
+func (q *Query) Arg(value interface{}) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// Where adds a condition; all conditions are ANDed.
+func (q *Query) Where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+func (q *Query) Args() []interface{} {
+	return q.args
+}
+
+// WhereSQL renders the conditions with a leading space, or nothing when there
+// are none.
+func (q *Query) WhereSQL() string {
+	if len(q.conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conds, " AND ")
+}
+
+func (q *Query) clone() *Query {
+	return &Query{
+		conds: append([]string(nil), q.conds...),
+		args:  append([]interface{}(nil), q.args...),
+	}
+}
@@ -0,0 +1,186 @@
+// Package routes registers every API route on a router. The OpenAPI document
+// in internal/openapi describes each of them.
+package routes
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/handlers"
+	"github.com/juazsh/managrr/internal/middleware"
+	"github.com/juazsh/managrr/internal/openapi"
+)
+
+// Register adds the API, its middleware and the health check to router.
+func Register(router *mux.Router) {
+	router.Use(middleware.CORSMiddleware)
+
+	api := router.PathPrefix("/api").Subrouter()
+
+	// Requests are validated against the OpenAPI document only once they are
+	// authenticated, so anonymous callers learn nothing about protected routes
+	// from validation errors.
+	public := api.PathPrefix("").Subrouter()
+	public.Use(middleware.ValidateRequest)
+
+	public.HandleFunc("/openapi.json", openapi.ServeSpec).Methods("GET", "OPTIONS")
+	public.HandleFunc("/docs", openapi.ServeDocs).Methods("GET", "OPTIONS")
+	public.HandleFunc("/auth/register", handlers.Register).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/login", handlers.Login).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/verify-email", handlers.VerifyEmail).Methods("GET", "OPTIONS")
+	public.HandleFunc("/auth/forgot-password", handlers.ForgotPassword).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/reset-password", handlers.ResetPassword).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/passwordless/request", handlers.RequestPasswordlessLogin).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/passwordless/verify", handlers.VerifyPasswordlessLogin).Methods("POST", "OPTIONS")
+	public.HandleFunc("/invitations/lookup", handlers.GetInvitationByToken).Methods("GET", "OPTIONS")
+	public.HandleFunc("/project-invitations/lookup", handlers.GetProjectMemberInvitationByToken).Methods("GET", "OPTIONS")
+	public.HandleFunc("/ownership-transfers/lookup", handlers.GetOwnershipTransferByToken).Methods("GET", "OPTIONS")
+	public.HandleFunc("/employee-invitations/lookup", handlers.GetEmployeeInvitationByToken).Methods("GET", "OPTIONS")
+	public.HandleFunc("/employee-invitations/accept", handlers.AcceptEmployeeInvitation).Methods("POST", "OPTIONS")
+	public.HandleFunc("/auth/sso/{slug}/login", handlers.StartSSOLogin).Methods("GET", "OPTIONS")
+	public.HandleFunc("/auth/sso/{slug}/callback", handlers.SSOCallback).Methods("GET", "OPTIONS")
+	public.HandleFunc("/auth/sso/link/confirm", handlers.ConfirmSSOLink).Methods("POST", "OPTIONS")
+
+	protected := api.PathPrefix("").Subrouter()
+	protected.Use(middleware.AuthMiddleware)
+	protected.Use(middleware.ValidateRequest)
+	protected.Use(middleware.Idempotency)
+	protected.Use(middleware.ConditionalGET)
+
+	protected.HandleFunc("/auth/me", handlers.GetCurrentUser).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/auth/me", handlers.UpdateCurrentUser).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/search", handlers.SearchAll).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/users/contractors", handlers.ListContractors).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/trash", handlers.ListTrash).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/sync", handlers.Sync).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/sync/upload", handlers.UploadSyncItems).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/graphql", handlers.GraphQL).Methods("POST", "OPTIONS")
+
+	protected.HandleFunc("/tokens", handlers.CreateAPIToken).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/tokens", handlers.ListAPITokens).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/tokens/{id}", handlers.RevokeAPIToken).Methods("DELETE", "OPTIONS")
+
+	protected.HandleFunc("/sso/provider", handlers.GetSSOProvider).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/sso/provider", handlers.UpsertSSOProvider).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/sso/provider", handlers.DeleteSSOProvider).Methods("DELETE", "OPTIONS")
+
+	protected.HandleFunc("/organization", handlers.GetOrganization).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/organization", handlers.UpdateOrganization).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/organization/members", handlers.AddOrganizationMember).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/organization/members/{id}", handlers.UpdateOrganizationMember).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/organization/members/{id}", handlers.RemoveOrganizationMember).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/organization/invitations/accept", handlers.AcceptOrganizationInvitation).Methods("POST", "OPTIONS")
+
+	protected.HandleFunc("/projects", handlers.CreateProject).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects", handlers.ListProjects).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}", handlers.GetProject).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}", handlers.UpdateProject).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/projects/{id}", handlers.DeleteProject).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/restore", handlers.RestoreProject).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/assign-contractor", handlers.AssignContractor).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/contractors", handlers.AssignContractor).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/contractors/{contractorId}", handlers.RemoveContractor).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/contractors", handlers.ListProjectContractors).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/invitations", handlers.InviteContractor).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/invitations", handlers.ListProjectInvitations).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/invitations/accept", handlers.AcceptContractorInvitation).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/invitations/{id}/resend", handlers.ResendContractorInvitation).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/invitations/{id}", handlers.RevokeContractorInvitation).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/members", handlers.InviteProjectMember).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/members", handlers.ListProjectMembers).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/members/{memberId}", handlers.UpdateProjectMember).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/members/{memberId}", handlers.RemoveProjectMember).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/member-invitations", handlers.ListProjectMemberInvitations).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/ownership-transfer", handlers.InitiateOwnershipTransfer).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/ownership-transfer", handlers.GetPendingOwnershipTransfer).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/ownership-transfer", handlers.CancelOwnershipTransfer).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/ownership-transfers/accept", handlers.AcceptOwnershipTransfer).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/ownership-transfers/decline", handlers.DeclineOwnershipTransfer).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/foremen", handlers.ListProjectForemen).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/foremen", handlers.SetProjectForeman).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/foremen/{employeeId}", handlers.RemoveProjectForeman).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/project-invitations/accept", handlers.AcceptProjectMemberInvitation).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/project-invitations/{id}", handlers.RevokeProjectMemberInvitation).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/photos", handlers.UploadProjectPhoto).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/photos", handlers.GetProjectPhotos).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/photos/bulk-delete", handlers.BulkDeleteProjectPhotos).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/photos/{photoId}", handlers.DeleteProjectPhoto).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/work-logs", handlers.GetProjectWorkLogs).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/expenses", handlers.GetProjectExpenses).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/expenses/bulk", handlers.BulkCreateExpenses).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/updates", handlers.CreateProjectUpdate).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/updates", handlers.GetProjectUpdates).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/dashboard", handlers.GetProjectDashboard).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/search", handlers.SearchProject).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{project_id}/payments", handlers.AddPaymentSummary).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{project_id}/payments", handlers.ListPaymentSummaries).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/expenses/download", handlers.DownloadExpensesExcel).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/payment-summaries/download", handlers.DownloadPaymentSummaryExcel).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/exchange-rates", handlers.ListExchangeRates).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/exchange-rates", handlers.SetExchangeRate).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/exchange-rates/import", handlers.ImportExchangeRates).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/projects/{id}/exchange-rates/{rateId}", handlers.DeleteExchangeRate).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/payments/bulk-confirm", handlers.BulkConfirmPayments).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/payments/{id}", handlers.UpdatePaymentSummary).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/payments/{id}", handlers.DeletePaymentSummary).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/payments/{id}/restore", handlers.RestorePaymentSummary).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/payments/{id}/confirm", handlers.ConfirmPayment).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/payments/{id}/dispute", handlers.DisputePayment).Methods("POST", "OPTIONS")
+
+	protected.HandleFunc("/employees", handlers.AddEmployee).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees", handlers.ListEmployees).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/employees/bulk-assign-project", handlers.BulkAssignProject).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees/bulk-unassign-project", handlers.BulkUnassignProject).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees/invitations", handlers.ListEmployeeInvitations).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/employees/invitations/{id}/resend", handlers.ResendEmployeeInvitation).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees/invitations/{id}", handlers.RevokeEmployeeInvitation).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/employees/{id}", handlers.GetEmployee).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/employees/{id}", handlers.UpdateEmployee).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/employees/{id}", handlers.DeleteEmployee).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/employees/{id}/restore", handlers.RestoreEmployee).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees/{id}/assign-project", handlers.AssignProject).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/employees/{id}/unassign-project", handlers.UnassignProject).Methods("POST", "OPTIONS")
+
+	protected.HandleFunc("/work-logs/summary/weekly", handlers.GetWeeklySummary).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/work-logs/summary/by-employee", handlers.GetSummaryByEmployee).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/work-logs/summary/by-project", handlers.GetSummaryByProject).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/work-logs/check-in", handlers.CheckIn).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/work-logs/check-out", handlers.CheckOut).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/work-logs/{id}", handlers.GetWorkLogDetail).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/work-logs/{id}/approve", handlers.ApproveWorkLog).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/work-logs", handlers.ListWorkLogs).Methods("GET", "OPTIONS")
+
+	protected.HandleFunc("/expenses", handlers.AddExpense).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/expenses/{id}", handlers.GetExpenseByID).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/expenses/{id}", handlers.UpdateExpense).Methods("PUT", "OPTIONS")
+	protected.HandleFunc("/expenses/{id}", handlers.DeleteExpense).Methods("DELETE", "OPTIONS")
+	protected.HandleFunc("/expenses/{id}/restore", handlers.RestoreExpense).Methods("POST", "OPTIONS")
+
+	protected.HandleFunc("/contracts/project/{id}", handlers.GetContractsByProject).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/contracts/{id}", handlers.GetContract).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/contracts/{id}/status", handlers.UpdateContractStatus).Methods("PUT", "OPTIONS")
+
+	protected.HandleFunc("/estimates", handlers.CreateEstimate).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/estimates/contract/{contractId}", handlers.GetEstimatesByContract).Methods("GET", "OPTIONS")
+	protected.HandleFunc("/estimates/{id}/approve", handlers.ApproveEstimate).Methods("POST", "OPTIONS")
+	protected.HandleFunc("/estimates/{id}/reject", handlers.RejectEstimate).Methods("POST", "OPTIONS")
+
+	admin := protected.PathPrefix("/admin").Subrouter()
+	admin.Use(middleware.RequireAdmin)
+
+	admin.HandleFunc("/users", handlers.AdminSearchUsers).Methods("GET", "OPTIONS")
+	admin.HandleFunc("/users/{id}", handlers.AdminGetUser).Methods("GET", "OPTIONS")
+	admin.HandleFunc("/users/{id}/disable", handlers.AdminDisableUser).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/users/{id}/enable", handlers.AdminEnableUser).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/users/{id}/resend-verification", handlers.AdminResendVerification).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/users/{id}/force-password-reset", handlers.AdminForcePasswordReset).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/users/{id}/impersonate", handlers.AdminImpersonateUser).Methods("POST", "OPTIONS")
+	admin.HandleFunc("/projects", handlers.AdminSearchProjects).Methods("GET", "OPTIONS")
+	admin.HandleFunc("/audit-logs", handlers.AdminListAuditLogs).Methods("GET", "OPTIONS")
+
+	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status":"ok","message":"Server is running"}`))
+	}).Methods("GET", "OPTIONS")
+}
@@ -0,0 +1,38 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/juazsh/managrr/internal/routes"
+)
+
+func serve(method, path, body string) *httptest.ResponseRecorder {
+	router := mux.NewRouter()
+	routes.Register(router)
+
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, r)
+	return rec
+}
+
+// Anonymous callers must be turned away before their request is validated,
+// so validation errors never describe a protected route to them.
+func TestProtectedRoutesAuthenticateBeforeValidating(t *testing.T) {
+	rec := serve("POST", "/api/tokens", `{"name": 42}`)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status %d, want 401: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestPublicRoutesAreValidated(t *testing.T) {
+	rec := serve("POST", "/api/auth/login", `{"email": 42}`)
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "validation_failed") {
+		t.Fatalf("status %d, want a validation error: %s", rec.Code, rec.Body)
+	}
+}
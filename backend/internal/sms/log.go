@@ -0,0 +1,39 @@
+package sms
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogProvider writes messages to the server log, or appends them to Path when
+// set, instead of delivering them. It is meant for local development.
+type LogProvider struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+func (p *LogProvider) Send(to, message string) error {
+	if p.Path == "" {
+		log.Printf("📱 SMS to %s: %s", to, message)
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f, err := os.OpenFile(p.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open SMS log file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), to, message); err != nil {
+		return fmt.Errorf("failed to write SMS log file: %w", err)
+	}
+
+	return nil
+}
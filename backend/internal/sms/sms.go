@@ -0,0 +1,42 @@
+package sms
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+type Provider interface {
+	Send(to, message string) error
+}
+
+var provider Provider
+
+func Init() error {
+	driver := strings.ToLower(os.Getenv("SMS_DRIVER"))
+
+	switch driver {
+	case "", "log":
+		provider = &LogProvider{Path: os.Getenv("SMS_LOG_FILE")}
+	case "twilio":
+		p := &TwilioProvider{
+			AccountSID: os.Getenv("TWILIO_ACCOUNT_SID"),
+			AuthToken:  os.Getenv("TWILIO_AUTH_TOKEN"),
+			From:       os.Getenv("TWILIO_FROM_NUMBER"),
+		}
+		if p.AccountSID == "" || p.AuthToken == "" || p.From == "" {
+			return fmt.Errorf("twilio SMS driver requires TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN and TWILIO_FROM_NUMBER")
+		}
+		provider = p
+	default:
+		return fmt.Errorf("unknown SMS_DRIVER %q", driver)
+	}
+
+	log.Printf("SMS driver initialized: %T", provider)
+	return nil
+}
+
+func GetProvider() Provider {
+	return provider
+}
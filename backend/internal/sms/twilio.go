@@ -0,0 +1,47 @@
+package sms
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type TwilioProvider struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+}
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+func (p *TwilioProvider) Send(to, message string) error {
+	endpoint := fmt.Sprintf("https://api.twilio.com/2010-04-01/Accounts/%s/Messages.json", p.AccountSID)
+
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", p.From)
+	form.Set("Body", message)
+
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create SMS request: %w", err)
+	}
+	req.SetBasicAuth(p.AccountSID, p.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send SMS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("SMS provider returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -160,3 +161,40 @@ func (s *SupabaseStorage) UploadReceiptPhoto(file multipart.File, header *multip
 	publicURL := fmt.Sprintf("%s/storage/v1/object/public/receipts/%s", s.URL, filename)
 	return publicURL, nil
 }
+
+// DeleteFile removes an object given the public URL one of the Upload
+// methods returned. URLs that do not point into this storage are ignored.
+func (s *SupabaseStorage) DeleteFile(publicURL string) error {
+	prefix := s.URL + "/storage/v1/object/public/"
+	if s.URL == "" || !strings.HasPrefix(publicURL, prefix) {
+		return nil
+	}
+
+	object := strings.TrimPrefix(publicURL, prefix)
+	if !strings.Contains(object, "/") {
+		return fmt.Errorf("invalid storage URL: %s", publicURL)
+	}
+
+	storageURL := fmt.Sprintf("%s/storage/v1/object/%s", s.URL, object)
+
+	req, err := http.NewRequest("DELETE", storageURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.APIKey)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("delete failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+// Package trash purges soft-deleted rows once their retention period is over.
+//
+// Deleting a project, expense, payment summary or employee only stamps
+// deleted_at. Until Retention has passed the owner can restore it from the
+// trash; after that Purge removes the row for good, together with everything
+// that cascades from it and the files those rows pointed at in storage.
+package trash
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/juazsh/managrr/internal/storage"
+	"github.com/lib/pq"
+)
+
+// Retention is how long a deleted row stays restorable.
+const Retention = 30 * 24 * time.Hour
+
+// Every is how often Start runs Purge.
+const Every = time.Hour
+
+// TombstoneRetention is how long the record of a hard-deleted row is kept for
+// sync clients. It outlives Retention, the longest a sync watermark is
+// honoured, so no client misses a deletion.
+const TombstoneRetention = 2 * Retention
+
+// purge describes one soft-deletable table and the query listing the stored
+// files that go with a set of its rows.
+type purge struct {
+	table string
+	files string
+}
+
+// Projects come first: removing a project cascades to its expenses and
+// payment summaries, so their files have to be collected along with it.
+var purges = []purge{
+	{
+		table: "projects",
+		files: `
+			SELECT photo_url FROM project_photos WHERE project_id = ANY($1::uuid[])
+			UNION ALL
+			SELECT pup.photo_url FROM project_update_photos pup
+			JOIN project_updates pu ON pup.project_update_id = pu.id
+			WHERE pu.project_id = ANY($1::uuid[])
+			UNION ALL
+			SELECT check_in_photo_url FROM work_logs WHERE project_id = ANY($1::uuid[])
+			UNION ALL
+			SELECT check_out_photo_url FROM work_logs WHERE project_id = ANY($1::uuid[])
+			UNION ALL
+			SELECT receipt_photo_url FROM expenses WHERE project_id = ANY($1::uuid[])
+			UNION ALL
+			SELECT screenshot_url FROM payment_summaries WHERE project_id = ANY($1::uuid[])
+		`,
+	},
+	{
+		table: "expenses",
+		files: `SELECT receipt_photo_url FROM expenses WHERE id = ANY($1::uuid[])`,
+	},
+	{
+		table: "payment_summaries",
+		files: `SELECT screenshot_url FROM payment_summaries WHERE id = ANY($1::uuid[])`,
+	},
+	{
+		table: "employees",
+	},
+}
+
+// Start runs Purge in the background every Every until the process exits.
+func Start(db *sql.DB) {
+	go func() {
+		for {
+			if err := Purge(db, time.Now().Add(-Retention)); err != nil {
+				log.Printf("ERROR trash: %v", err)
+			}
+			if err := PurgeTombstones(db, time.Now().Add(-TombstoneRetention)); err != nil {
+				log.Printf("ERROR trash: %v", err)
+			}
+			time.Sleep(Every)
+		}
+	}()
+}
+
+// Purge hard-deletes every row deleted before cutoff and then removes the
+// files that belonged to it. Storage is cleaned up after the transaction has
+// committed, so a failed purge never leaves rows pointing at missing files.
+func Purge(db *sql.DB, cutoff time.Time) error {
+	var files []string
+	purged := 0
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, p := range purges {
+		ids, err := expiredIDs(tx, p.table, cutoff)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		if p.files != "" {
+			urls, err := fileURLs(tx, p.files, ids)
+			if err != nil {
+				return fmt.Errorf("failed to collect %s files: %w", p.table, err)
+			}
+			files = append(files, urls...)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM `+p.table+` WHERE id = ANY($1::uuid[])`, pq.Array(ids)); err != nil {
+			return fmt.Errorf("failed to purge %s: %w", p.table, err)
+		}
+		purged += len(ids)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	if purged == 0 {
+		return nil
+	}
+	log.Printf("trash: purged %d rows and %d files", purged, len(files))
+
+	store := storage.NewSupabaseStorage()
+	for _, url := range files {
+		if err := store.DeleteFile(url); err != nil {
+			log.Printf("ERROR trash: failed to delete %s: %v", url, err)
+		}
+	}
+	return nil
+}
+
+// PurgeTombstones removes sync tombstones recorded before cutoff.
+func PurgeTombstones(db *sql.DB, cutoff time.Time) error {
+	if _, err := db.Exec(`DELETE FROM sync_tombstones WHERE deleted_at < $1`, cutoff); err != nil {
+		return fmt.Errorf("failed to purge sync tombstones: %w", err)
+	}
+	return nil
+}
+
+func expiredIDs(tx *sql.Tx, table string, cutoff time.Time) ([]string, error) {
+	rows, err := tx.Query(`
+		SELECT id FROM `+table+`
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+		FOR UPDATE
+	`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func fileURLs(tx *sql.Tx, query string, ids []string) ([]string, error) {
+	rows, err := tx.Query(query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var urls []string
+	for rows.Next() {
+		var url sql.NullString
+		if err := rows.Scan(&url); err != nil {
+			return nil, err
+		}
+		if url.Valid && url.String != "" {
+			urls = append(urls, url.String)
+		}
+	}
+	return urls, rows.Err()
+}
@@ -7,6 +7,8 @@ import (
 	"log"
 	"net/smtp"
 	"os"
+
+	"github.com/juazsh/managrr/internal/money"
 )
 
 func GenerateVerificationToken() (string, error) {
@@ -56,7 +58,7 @@ Thanks,
 Managrr Team
 `, verificationLink)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
@@ -121,7 +123,7 @@ Thanks,
 Managrr Team
 `, resetLink)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
@@ -155,7 +157,7 @@ func GenerateRandomPassword() (string, error) {
 	return string(password), nil
 }
 
-func SendEmployeeWelcomeEmail(toEmail, name, tempPassword string) error {
+func SendEmployeeInvitationEmail(toEmail, name, contractorName, token string, existingAccount bool) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -163,7 +165,7 @@ func SendEmployeeWelcomeEmail(toEmail, name, tempPassword string) error {
 	fromEmail := os.Getenv("SMTP_USER")
 	appURL := os.Getenv("APP_URL")
 
-	log.Printf("📧 EMPLOYEE WELCOME EMAIL CONFIG CHECK:")
+	log.Printf("📧 EMPLOYEE INVITATION EMAIL CONFIG CHECK:")
 	log.Printf("  SMTP_HOST: %s", smtpHost)
 	log.Printf("  SMTP_PORT: %s", smtpPort)
 	log.Printf("  SMTP_USER: %s", smtpUser)
@@ -176,39 +178,47 @@ func SendEmployeeWelcomeEmail(toEmail, name, tempPassword string) error {
 		return fmt.Errorf("SMTP configuration missing")
 	}
 
-	subject := "Welcome to Managrr - Your Account Details"
+	inviteLink := fmt.Sprintf("%s/accept-employee-invitation?token=%s", appURL, token)
+
+	var subject, instructions string
+	if existingAccount {
+		subject = "You've Been Added to a Crew - Managrr"
+		instructions = "You already have a Managrr account. Click the link below to join their crew:"
+	} else {
+		subject = "Welcome to Managrr - Set Up Your Account"
+		instructions = "Click the link below to choose your password and activate your account:"
+	}
+
 	body := fmt.Sprintf(`
 Hello %s,
 
-You have been added as an employee on Managrr.
+%s has added you as an employee on Managrr.
 
-Your login credentials are:
-Email: %s
-Temporary Password: %s
+%s
 
-Please log in at: %s
+%s
 
-For security, we recommend changing your password after your first login.
+This link will expire in 72 hours. If it expires, ask %s to resend the invitation.
 
 Thanks,
 Managrr Team
-`, name, toEmail, tempPassword, appURL)
+`, name, contractorName, instructions, inviteLink, contractorName)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
-	log.Printf("📤 Attempting to send employee welcome email to %s via %s", toEmail, addr)
+	log.Printf("📤 Attempting to send employee invitation email to %s via %s", toEmail, addr)
 
 	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
 	if err != nil {
-		log.Printf("❌ SMTP ERROR: Failed to send employee welcome email to %s: %v", toEmail, err)
+		log.Printf("❌ SMTP ERROR: Failed to send employee invitation email to %s: %v", toEmail, err)
 		log.Printf("   Host: %s, Port: %s, User: %s", smtpHost, smtpPort, smtpUser)
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	log.Printf("✅ Employee welcome email sent successfully to %s", toEmail)
+	log.Printf("✅ Employee invitation email sent successfully to %s", toEmail)
 	return nil
 }
 
@@ -247,7 +257,7 @@ Thanks,
 Managrr Team
 `, toName, uploaderName, uploaderType, projectTitle, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -263,7 +273,7 @@ Managrr Team
 	return nil
 }
 
-func SendExpenseAddedNotification(toEmail, toName, adderName, adderType, projectTitle string, amount float64, category, description string) error {
+func SendExpenseAddedNotification(toEmail, toName, adderName, adderType, projectTitle string, amount money.Amount, currency money.Currency, category, description string) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -288,7 +298,7 @@ Hello %s,
 %s (%s) has added a new expense to the project "%s".
 
 Expense Details:
-- Amount: $%.2f
+- Amount: %s
 - Category: %s
 - Description: %s
 
@@ -296,9 +306,9 @@ You can view all expenses in your project dashboard at: %s
 
 Thanks,
 Managrr Team
-`, toName, adderName, adderType, projectTitle, amount, category, descText, appURL)
+`, toName, adderName, adderType, projectTitle, currency.Format(amount), category, descText, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -314,7 +324,53 @@ Managrr Team
 	return nil
 }
 
-func SendExpenseUpdatedNotification(toEmail, toName, updaterName, updaterType, projectTitle string, amount float64, category, description string) error {
+// SendExpensesAddedNotification summarizes expenses recorded together, so a
+// batch of receipts sends one email rather than one per receipt. total is in
+// the project currency.
+func SendExpensesAddedNotification(toEmail, toName, adderName, adderType, projectTitle string, count int, total money.Amount, currency money.Currency) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for expenses notification")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	subject := fmt.Sprintf("%d New Expenses Added - %s", count, projectTitle)
+	body := fmt.Sprintf(`
+Hello %s,
+
+%s (%s) has added %d expenses to the project "%s".
+
+Total: %s
+
+You can view all expenses in your project dashboard at: %s
+
+Thanks,
+Managrr Team
+`, toName, adderName, adderType, count, projectTitle, currency.Format(total), appURL)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending expenses added notification to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send expenses added notification to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Expenses added notification sent to %s", toEmail)
+	return nil
+}
+
+func SendExpenseUpdatedNotification(toEmail, toName, updaterName, updaterType, projectTitle string, amount money.Amount, currency money.Currency, category, description string) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -339,7 +395,7 @@ Hello %s,
 %s (%s) has updated an expense in the project "%s".
 
 Updated Expense Details:
-- Amount: $%.2f
+- Amount: %s
 - Category: %s
 - Description: %s
 
@@ -347,9 +403,9 @@ You can view all expenses in your project dashboard at: %s
 
 Thanks,
 Managrr Team
-`, toName, updaterName, updaterType, projectTitle, amount, category, descText, appURL)
+`, toName, updaterName, updaterType, projectTitle, currency.Format(amount), category, descText, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -365,7 +421,7 @@ Managrr Team
 	return nil
 }
 
-func SendPaymentAddedNotification(toEmail, toName, ownerName, projectTitle string, amount float64, paymentMethod, paymentDate string) error {
+func SendPaymentAddedNotification(toEmail, toName, ownerName, projectTitle string, amount money.Amount, currency money.Currency, paymentMethod, paymentDate string) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -385,7 +441,7 @@ Hello %s,
 %s has recorded a payment for the project "%s" that requires your confirmation.
 
 Payment Details:
-- Amount: $%.2f
+- Amount: %s
 - Payment Method: %s
 - Payment Date: %s
 
@@ -393,9 +449,9 @@ Please log in to your dashboard to confirm or dispute this payment: %s
 
 Thanks,
 Managrr Team
-`, toName, ownerName, projectTitle, amount, paymentMethod, paymentDate, appURL)
+`, toName, ownerName, projectTitle, currency.Format(amount), paymentMethod, paymentDate, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -411,7 +467,7 @@ Managrr Team
 	return nil
 }
 
-func SendPaymentConfirmedNotification(toEmail, toName, contractorName, projectTitle string, amount float64, paymentDate string) error {
+func SendPaymentConfirmedNotification(toEmail, toName, contractorName, projectTitle string, amount money.Amount, currency money.Currency, paymentDate string) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -431,7 +487,7 @@ Hello %s,
 Good news! %s has confirmed the payment for the project "%s".
 
 Payment Details:
-- Amount: $%.2f
+- Amount: %s
 - Payment Date: %s
 - Status: Confirmed
 
@@ -439,9 +495,9 @@ You can view the payment details in your project dashboard at: %s
 
 Thanks,
 Managrr Team
-`, toName, contractorName, projectTitle, amount, paymentDate, appURL)
+`, toName, contractorName, projectTitle, currency.Format(amount), paymentDate, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -457,7 +513,7 @@ Managrr Team
 	return nil
 }
 
-func SendPaymentDisputedNotification(toEmail, toName, contractorName, projectTitle string, amount float64, paymentDate, disputeReason string) error {
+func SendPaymentDisputedNotification(toEmail, toName, contractorName, projectTitle string, amount money.Amount, currency money.Currency, paymentDate, disputeReason string) error {
 	smtpHost := os.Getenv("SMTP_HOST")
 	smtpPort := os.Getenv("SMTP_PORT")
 	smtpUser := os.Getenv("SMTP_USER")
@@ -477,7 +533,7 @@ Hello %s,
 %s has disputed a payment for the project "%s".
 
 Payment Details:
-- Amount: $%.2f
+- Amount: %s
 - Payment Date: %s
 - Status: Disputed
 - Reason: %s
@@ -486,9 +542,9 @@ Please log in to your dashboard to review and resolve this dispute: %s
 
 Thanks,
 Managrr Team
-`, toName, contractorName, projectTitle, amount, paymentDate, disputeReason, appURL)
+`, toName, contractorName, projectTitle, currency.Format(amount), paymentDate, disputeReason, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -539,7 +595,7 @@ Thanks,
 Managrr Team
 `, toName, contractorName, updateTypeText, projectTitle, content, appURL)
 
-	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
 	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
 	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
 
@@ -554,3 +610,392 @@ Managrr Team
 	log.Printf("✅ Project update notification sent to %s", toEmail)
 	return nil
 }
+
+func GenerateInvitationToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func SendContractorInvitationEmail(toEmail, ownerName, projectTitle, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for contractor invitation")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	inviteLink := fmt.Sprintf("%s/accept-invitation?token=%s", appURL, token)
+
+	subject := fmt.Sprintf("You're Invited to %s - Managrr", projectTitle)
+	body := fmt.Sprintf(`
+Hello,
+
+%s has invited you to join the project "%s" as a contractor on Managrr.
+
+Click the link below to create your account or sign in and accept the invitation:
+
+%s
+
+This link will expire in 7 days.
+
+If you weren't expecting this invitation, you can ignore this email.
+
+Thanks,
+Managrr Team
+`, ownerName, projectTitle, inviteLink)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending contractor invitation to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send contractor invitation to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Contractor invitation sent to %s", toEmail)
+	return nil
+}
+
+func SendMagicLinkEmail(toEmail, name, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for magic link email")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	loginLink := fmt.Sprintf("%s/magic-login?token=%s", appURL, token)
+
+	subject := "Your Login Link - Managrr"
+	body := fmt.Sprintf(`
+Hello %s,
+
+Click the link below to log in to Managrr:
+
+%s
+
+This link will expire in 15 minutes and can only be used once.
+
+If you didn't request this link, you can safely ignore this email.
+
+Thanks,
+Managrr Team
+`, name, loginLink)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending magic link email to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send magic link email to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Magic link email sent to %s", toEmail)
+	return nil
+}
+
+func SendSSOLinkEmail(toEmail, name, providerName, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for SSO link email")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	linkURL := fmt.Sprintf("%s/sso/link?token=%s", appURL, token)
+
+	subject := "Confirm Single Sign-On - Managrr"
+	body := fmt.Sprintf(`
+Hello %s,
+
+Someone signed in to Managrr through %s with this email address. To let
+%s sign you in to your existing account from now on, click the link below:
+
+%s
+
+This link will expire in 30 minutes and can only be used once.
+
+If this wasn't you, ignore this email and your account stays as it is.
+
+Thanks,
+Managrr Team
+`, name, providerName, providerName, linkURL)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending SSO link email to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send SSO link email to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ SSO link email sent to %s", toEmail)
+	return nil
+}
+
+func SendOrganizationInvitationEmail(toEmail, name, organizationName, inviterName, resetToken string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for organization invitation")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	subject := fmt.Sprintf("You've been added to %s on Managrr", organizationName)
+	body := fmt.Sprintf(`
+Hello %s,
+
+%s has added you to %s on Managrr.
+
+Set your password to activate your account:
+
+%s/reset-password?token=%s
+
+This link will expire in 72 hours.
+
+Thanks,
+Managrr Team
+`, name, inviterName, organizationName, appURL, resetToken)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending organization invitation to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send organization invitation to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Organization invitation sent to %s", toEmail)
+	return nil
+}
+
+func SendOrganizationJoinInvitationEmail(toEmail, name, organizationName, inviterName, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for organization invitation")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	inviteLink := fmt.Sprintf("%s/accept-organization-invitation?token=%s", appURL, token)
+
+	subject := fmt.Sprintf("You're Invited to Join %s - Managrr", organizationName)
+	body := fmt.Sprintf(`
+Hello %s,
+
+%s has invited you to join %s on Managrr.
+
+Sign in with your existing account and accept the invitation here:
+
+%s
+
+Accepting moves your account into %s. This link will expire in 72 hours.
+
+If you weren't expecting this invitation, you can ignore this email.
+
+Thanks,
+Managrr Team
+`, name, inviterName, organizationName, inviteLink, organizationName)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending organization invitation to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send organization invitation to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Organization invitation sent to %s", toEmail)
+	return nil
+}
+
+func SendProjectMemberInvitationEmail(toEmail, inviterName, projectTitle, roleLabel, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for project member invitation")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	inviteLink := fmt.Sprintf("%s/accept-project-invitation?token=%s", appURL, token)
+
+	subject := fmt.Sprintf("You're Invited to %s - Managrr", projectTitle)
+	body := fmt.Sprintf(`
+Hello,
+
+%s has invited you to the project "%s" as a %s on Managrr.
+
+Click the link below to create your house owner account or sign in and accept the invitation:
+
+%s
+
+This link will expire in 7 days.
+
+If you weren't expecting this invitation, you can ignore this email.
+
+Thanks,
+Managrr Team
+`, inviterName, projectTitle, roleLabel, inviteLink)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending project member invitation to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send project member invitation to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Project member invitation sent to %s", toEmail)
+	return nil
+}
+
+func SendOwnershipTransferRequestEmail(toEmail, ownerName, projectTitle, token string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for ownership transfer request")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	transferLink := fmt.Sprintf("%s/accept-ownership?token=%s", appURL, token)
+
+	subject := fmt.Sprintf("Take Over %s - Managrr", projectTitle)
+	body := fmt.Sprintf(`
+Hello,
+
+%s would like to transfer ownership of the project "%s" to you on Managrr.
+
+As the new owner you will manage the project, its contractors, estimates and payments.
+
+Click the link below to create your house owner account or sign in and accept the transfer:
+
+%s
+
+This link will expire in 7 days.
+
+If you weren't expecting this, you can ignore this email.
+
+Thanks,
+Managrr Team
+`, ownerName, projectTitle, transferLink)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending ownership transfer request to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send ownership transfer request to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Ownership transfer request sent to %s", toEmail)
+	return nil
+}
+
+func SendOwnershipTransferredNotification(toEmail, toName, projectTitle, previousOwnerName, newOwnerName string) error {
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := os.Getenv("SMTP_PORT")
+	smtpUser := os.Getenv("SMTP_USER")
+	smtpPass := os.Getenv("SMTP_PASS")
+	fromEmail := os.Getenv("SMTP_USER")
+	appURL := os.Getenv("APP_URL")
+
+	if smtpHost == "" || smtpPort == "" || smtpUser == "" || smtpPass == "" {
+		log.Printf("❌ ERROR: SMTP configuration missing for ownership transferred notification")
+		return fmt.Errorf("SMTP configuration missing")
+	}
+
+	subject := fmt.Sprintf("New Owner for %s - Managrr", projectTitle)
+	body := fmt.Sprintf(`
+Hello %s,
+
+Ownership of the project "%s" has been transferred from %s to %s.
+
+Estimates, payments and updates for this project are now handled by %s.
+
+You can view the project in your dashboard at: %s
+
+Thanks,
+Managrr Team
+`, toName, projectTitle, previousOwnerName, newOwnerName, newOwnerName, appURL)
+
+	message := []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s", fromEmail, toEmail, subject, body))
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	addr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+
+	log.Printf("📤 Sending ownership transferred notification to %s", toEmail)
+
+	err := smtp.SendMail(addr, auth, fromEmail, []string{toEmail}, message)
+	if err != nil {
+		log.Printf("❌ Failed to send ownership transferred notification to %s: %v", toEmail, err)
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	log.Printf("✅ Ownership transferred notification sent to %s", toEmail)
+	return nil
+}
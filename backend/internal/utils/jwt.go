@@ -12,6 +12,9 @@ type Claims struct {
 	UserID   string `json:"user_id"`
 	Email    string `json:"email"`
 	UserType string `json:"user_type"`
+
+	// ImpersonatorID is set on tokens an admin minted to act as this user.
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -35,6 +38,33 @@ func GenerateToken(userID, email, userType string) (string, error) {
 	return token.SignedString([]byte(secret))
 }
 
+// ImpersonationTokenTTL is deliberately short: the token exists for a single
+// troubleshooting session and cannot be refreshed.
+const ImpersonationTokenTTL = 30 * time.Minute
+
+func GenerateImpersonationToken(userID, email, userType, adminID string) (string, time.Time, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return "", time.Time{}, fmt.Errorf("JWT_SECRET not set")
+	}
+
+	expiresAt := time.Now().Add(ImpersonationTokenTTL)
+	claims := Claims{
+		UserID:         userID,
+		Email:          email,
+		UserType:       userType,
+		ImpersonatorID: adminID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(secret))
+	return signed, expiresAt, err
+}
+
 func ValidateToken(tokenString string) (*Claims, error) {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
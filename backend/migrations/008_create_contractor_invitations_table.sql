@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS contractor_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_contractor_invitations_project_id ON contractor_invitations(project_id);
+CREATE INDEX idx_contractor_invitations_email ON contractor_invitations(LOWER(email));
+CREATE INDEX idx_contractor_invitations_status ON contractor_invitations(status);
+CREATE UNIQUE INDEX idx_contractor_invitations_pending
+    ON contractor_invitations(project_id, LOWER(email))
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_contractor_invitations_updated_at BEFORE UPDATE ON contractor_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
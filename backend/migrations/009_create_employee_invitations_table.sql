@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS employee_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    contractor_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    employee_id UUID REFERENCES employees(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(50),
+    hourly_rate DECIMAL(10, 2) NOT NULL,
+    invitation_type VARCHAR(20) NOT NULL CHECK (invitation_type IN ('set_password', 'join_crew')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_employee_invitations_contractor_id ON employee_invitations(contractor_id);
+CREATE INDEX idx_employee_invitations_user_id ON employee_invitations(user_id);
+CREATE INDEX idx_employee_invitations_status ON employee_invitations(status);
+CREATE UNIQUE INDEX idx_employee_invitations_pending
+    ON employee_invitations(contractor_id, user_id)
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_employee_invitations_updated_at BEFORE UPDATE ON employee_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
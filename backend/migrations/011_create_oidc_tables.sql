@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS oidc_providers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    contractor_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    display_name VARCHAR(255) NOT NULL,
+    issuer_url VARCHAR(500) NOT NULL,
+    client_id VARCHAR(255) NOT NULL,
+    client_secret VARCHAR(500),
+    scopes VARCHAR(255) NOT NULL DEFAULT 'openid email profile',
+    default_user_type VARCHAR(20) NOT NULL DEFAULT 'employee' CHECK (default_user_type IN ('employee', 'contractor')),
+    allowed_domains TEXT[] NOT NULL DEFAULT '{}',
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TRIGGER update_oidc_providers_updated_at BEFORE UPDATE ON oidc_providers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    state VARCHAR(64) PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES oidc_providers(id) ON DELETE CASCADE,
+    code_verifier VARCHAR(128) NOT NULL,
+    nonce VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider_id UUID NOT NULL REFERENCES oidc_providers(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    last_login_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(provider_id, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
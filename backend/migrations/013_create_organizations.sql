@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS organizations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TRIGGER update_organizations_updated_at BEFORE UPDATE ON organizations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS organization_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'office_manager', 'foreman')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_organization_members_organization_id ON organization_members(organization_id);
+
+CREATE TRIGGER update_organization_members_updated_at BEFORE UPDATE ON organization_members
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Every existing contractor becomes the owner of a single-member organization.
+WITH contractors AS MATERIALIZED (
+    SELECT id AS user_id, name, gen_random_uuid() AS organization_id
+    FROM users
+    WHERE user_type = 'contractor'
+), created AS (
+    INSERT INTO organizations (id, name)
+    SELECT organization_id, name FROM contractors
+)
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT organization_id, user_id, 'owner' FROM contractors;
+
+ALTER TABLE contracts ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE contracts c SET organization_id = m.organization_id
+FROM organization_members m WHERE m.user_id = c.contractor_id AND c.organization_id IS NULL;
+ALTER TABLE contracts ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE contracts DROP CONSTRAINT IF EXISTS contracts_project_id_contractor_id_key;
+ALTER TABLE contracts ADD CONSTRAINT contracts_project_id_organization_id_key UNIQUE (project_id, organization_id);
+CREATE INDEX IF NOT EXISTS idx_contracts_organization_id ON contracts(organization_id);
+
+ALTER TABLE estimates ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE estimates e SET organization_id = c.organization_id
+FROM contracts c WHERE c.id = e.contract_id AND e.organization_id IS NULL;
+ALTER TABLE estimates ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_estimates_organization_id ON estimates(organization_id);
+
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE employees e SET organization_id = m.organization_id
+FROM organization_members m WHERE m.user_id = e.contractor_id AND e.organization_id IS NULL;
+ALTER TABLE employees ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_employees_organization_id ON employees(organization_id);
+
+ALTER TABLE employee_invitations ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE employee_invitations i SET organization_id = m.organization_id
+FROM organization_members m WHERE m.user_id = i.contractor_id AND i.organization_id IS NULL;
+ALTER TABLE employee_invitations ALTER COLUMN organization_id SET NOT NULL;
+DROP INDEX IF EXISTS idx_employee_invitations_pending;
+CREATE UNIQUE INDEX idx_employee_invitations_pending
+    ON employee_invitations(organization_id, user_id)
+    WHERE status = 'pending';
+
+ALTER TABLE oidc_providers ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE oidc_providers p SET organization_id = m.organization_id
+FROM organization_members m WHERE m.user_id = p.contractor_id AND p.organization_id IS NULL;
+ALTER TABLE oidc_providers ALTER COLUMN organization_id SET NOT NULL;
+ALTER TABLE oidc_providers DROP CONSTRAINT IF EXISTS oidc_providers_contractor_id_key;
+ALTER TABLE oidc_providers ADD CONSTRAINT oidc_providers_organization_id_key UNIQUE (organization_id);
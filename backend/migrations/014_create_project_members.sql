@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS project_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('co_owner', 'viewer')),
+    can_approve_estimates BOOLEAN NOT NULL DEFAULT false,
+    can_confirm_payments BOOLEAN NOT NULL DEFAULT false,
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+
+CREATE TRIGGER update_project_members_updated_at BEFORE UPDATE ON project_members
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE IF NOT EXISTS project_member_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('co_owner', 'viewer')),
+    can_approve_estimates BOOLEAN NOT NULL DEFAULT false,
+    can_confirm_payments BOOLEAN NOT NULL DEFAULT false,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_member_invitations_project_id ON project_member_invitations(project_id);
+CREATE UNIQUE INDEX idx_project_member_invitations_pending
+    ON project_member_invitations(project_id, LOWER(email))
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_project_member_invitations_updated_at BEFORE UPDATE ON project_member_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
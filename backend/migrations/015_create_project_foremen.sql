@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS project_foremen (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    employee_id UUID NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    can_post_updates BOOLEAN NOT NULL DEFAULT false,
+    can_log_expenses BOOLEAN NOT NULL DEFAULT false,
+    can_approve_timesheets BOOLEAN NOT NULL DEFAULT false,
+    can_view_crew_hours BOOLEAN NOT NULL DEFAULT false,
+    granted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(project_id, employee_id)
+);
+
+CREATE INDEX idx_project_foremen_employee_id ON project_foremen(employee_id);
+
+CREATE TRIGGER update_project_foremen_updated_at BEFORE UPDATE ON project_foremen
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE work_logs ADD COLUMN IF NOT EXISTS approved_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE work_logs ADD COLUMN IF NOT EXISTS approved_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS project_ownership_transfers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    from_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    to_email VARCHAR(255) NOT NULL,
+    to_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'declined', 'cancelled')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_project_ownership_transfers_project_id ON project_ownership_transfers(project_id);
+CREATE UNIQUE INDEX idx_project_ownership_transfers_pending
+    ON project_ownership_transfers(project_id)
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_project_ownership_transfers_updated_at BEFORE UPDATE ON project_ownership_transfers
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
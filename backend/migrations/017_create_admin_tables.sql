@@ -0,0 +1,23 @@
+-- Platform admins are promoted by hand:
+--   UPDATE users SET user_type = 'admin' WHERE email = '...';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_user_type_check;
+ALTER TABLE users ADD CONSTRAINT users_user_type_check
+    CHECK (user_type IN ('house_owner', 'contractor', 'employee', 'admin'));
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_reason TEXT;
+
+CREATE TABLE IF NOT EXISTS admin_audit_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID NOT NULL REFERENCES users(id),
+    action VARCHAR(50) NOT NULL,
+    target_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    target_project_id UUID REFERENCES projects(id) ON DELETE SET NULL,
+    details JSONB,
+    ip_address VARCHAR(64),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_admin_audit_logs_admin_id ON admin_audit_logs(admin_id);
+CREATE INDEX idx_admin_audit_logs_target_user_id ON admin_audit_logs(target_user_id);
+CREATE INDEX idx_admin_audit_logs_created_at ON admin_audit_logs(created_at DESC);
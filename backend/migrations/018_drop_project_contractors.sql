@@ -0,0 +1,19 @@
+-- contracts is the only record of which contractors work on a project.
+-- Carry over any assignment that never made it into contracts, from either
+-- legacy source, before dropping them.
+INSERT INTO contracts (project_id, contractor_id, organization_id, owner_id, status, start_date, created_at, updated_at)
+SELECT pc.project_id, pc.contractor_id, m.organization_id, p.owner_id, 'active', pc.assigned_at, pc.assigned_at, pc.assigned_at
+FROM project_contractors pc
+JOIN projects p ON pc.project_id = p.id
+JOIN organization_members m ON m.user_id = pc.contractor_id
+ON CONFLICT (project_id, organization_id) DO NOTHING;
+
+INSERT INTO contracts (project_id, contractor_id, organization_id, owner_id, status, start_date, created_at, updated_at)
+SELECT p.id, p.contractor_id, m.organization_id, p.owner_id, 'active', p.created_at, p.created_at, p.created_at
+FROM projects p
+JOIN organization_members m ON m.user_id = p.contractor_id
+WHERE p.contractor_id IS NOT NULL
+ON CONFLICT (project_id, organization_id) DO NOTHING;
+
+DROP TABLE IF EXISTS project_contractors;
+ALTER TABLE projects DROP COLUMN IF EXISTS contractor_id;
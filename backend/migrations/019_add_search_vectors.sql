@@ -0,0 +1,28 @@
+-- Full-text search columns. They are generated, so every write path keeps
+-- them current without application changes.
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', COALESCE(vendor, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_expenses_search_vector ON expenses USING GIN (search_vector);
+
+ALTER TABLE project_updates ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(content, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_project_updates_search_vector ON project_updates USING GIN (search_vector);
+
+ALTER TABLE project_photos ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(caption, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_project_photos_search_vector ON project_photos USING GIN (search_vector);
+
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(notes, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_payment_summaries_search_vector ON payment_summaries USING GIN (search_vector);
+
+ALTER TABLE estimates ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(description, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_estimates_search_vector ON estimates USING GIN (search_vector);
+
+ALTER TABLE contracts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector('english', COALESCE(terms, ''))) STORED;
+CREATE INDEX IF NOT EXISTS idx_contracts_search_vector ON contracts USING GIN (search_vector);
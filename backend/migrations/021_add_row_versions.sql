@@ -0,0 +1,24 @@
+-- Row versions back the ETags used for optimistic concurrency. The trigger
+-- bumps the version on every update, whichever code path makes it, so a
+-- client's If-Match fails whenever the row changed since it was read.
+CREATE OR REPLACE FUNCTION increment_version_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version = OLD.version + 1;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+CREATE TRIGGER increment_projects_version BEFORE UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION increment_version_column();
+CREATE TRIGGER increment_expenses_version BEFORE UPDATE ON expenses
+    FOR EACH ROW EXECUTE FUNCTION increment_version_column();
+CREATE TRIGGER increment_payment_summaries_version BEFORE UPDATE ON payment_summaries
+    FOR EACH ROW EXECUTE FUNCTION increment_version_column();
+CREATE TRIGGER increment_employees_version BEFORE UPDATE ON employees
+    FOR EACH ROW EXECUTE FUNCTION increment_version_column();
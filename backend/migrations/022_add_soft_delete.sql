@@ -0,0 +1,20 @@
+-- Deleting a project, expense, payment summary or employee moves it to the
+-- trash. Rows are restorable until the purge job removes them, with their
+-- stored files, once the retention period has passed.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS deleted_by UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_projects_deleted_at ON projects(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_expenses_deleted_at ON expenses(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_payment_summaries_deleted_at ON payment_summaries(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_employees_deleted_at ON employees(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_projects_deleted_by ON projects(deleted_by) WHERE deleted_by IS NOT NULL;
+CREATE INDEX idx_expenses_deleted_by ON expenses(deleted_by) WHERE deleted_by IS NOT NULL;
+CREATE INDEX idx_payment_summaries_deleted_by ON payment_summaries(deleted_by) WHERE deleted_by IS NOT NULL;
+CREATE INDEX idx_employees_deleted_by ON employees(deleted_by) WHERE deleted_by IS NOT NULL;
@@ -0,0 +1,38 @@
+-- Every project has a currency its totals are reported in. Expenses and
+-- payments may be recorded in another currency; they keep the rate used and
+-- the converted amount so totals never change when rates are edited later.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18, 8) NOT NULL DEFAULT 1;
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS converted_amount DECIMAL(12, 2);
+UPDATE expenses e SET currency = p.currency, converted_amount = e.amount
+FROM projects p WHERE e.project_id = p.id AND e.converted_amount IS NULL;
+ALTER TABLE expenses ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE expenses ALTER COLUMN converted_amount SET NOT NULL;
+
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS currency CHAR(3);
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18, 8) NOT NULL DEFAULT 1;
+ALTER TABLE payment_summaries ADD COLUMN IF NOT EXISTS converted_amount DECIMAL(12, 2);
+UPDATE payment_summaries ps SET currency = p.currency, converted_amount = ps.amount
+FROM projects p WHERE ps.project_id = p.id AND ps.converted_amount IS NULL;
+ALTER TABLE payment_summaries ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE payment_summaries ALTER COLUMN converted_amount SET NOT NULL;
+
+-- rate is how many units of the project currency one unit of currency buys,
+-- from effective_date until the next rate for the same currency.
+CREATE TABLE IF NOT EXISTS project_exchange_rates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    rate DECIMAL(18, 8) NOT NULL CHECK (rate > 0),
+    effective_date DATE NOT NULL,
+    source VARCHAR(10) NOT NULL DEFAULT 'manual' CHECK (source IN ('manual', 'import')),
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(project_id, currency, effective_date)
+);
+
+CREATE TRIGGER update_project_exchange_rates_updated_at BEFORE UPDATE ON project_exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,42 @@
+-- Users and projects have an IANA time zone. Days and weeks are bucketed in
+-- the zone of the project (work logs, reports) or user (summaries) they are
+-- for, instead of the server's.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Existing projects follow their owner's zone, which is UTC until they set
+-- one.
+UPDATE projects p SET time_zone = u.time_zone FROM users u WHERE p.owner_id = u.id;
+
+-- Expense and payment dates were free-form text. Values that are not a date
+-- fall back to the day the row was created.
+CREATE OR REPLACE FUNCTION pg_temp.to_date_or_null(value TEXT)
+RETURNS DATE AS $$
+BEGIN
+    RETURN value::date;
+EXCEPTION WHEN OTHERS THEN
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE expenses ALTER COLUMN date TYPE DATE
+    USING COALESCE(pg_temp.to_date_or_null(date::text), created_at::date);
+ALTER TABLE payment_summaries ALTER COLUMN payment_date TYPE DATE
+    USING COALESCE(pg_temp.to_date_or_null(payment_date::text), created_at::date);
+
+-- Check-in and check-out times written by NOW() into columns without a zone
+-- are in the session's zone, UTC on every deployment so far. Storing them as
+-- instants keeps hours correct across daylight saving changes.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_name = 'work_logs' AND column_name = 'check_in_time'
+          AND data_type = 'timestamp without time zone'
+    ) THEN
+        ALTER TABLE work_logs
+            ALTER COLUMN check_in_time TYPE TIMESTAMP WITH TIME ZONE USING check_in_time AT TIME ZONE 'UTC',
+            ALTER COLUMN check_out_time TYPE TIMESTAMP WITH TIME ZONE USING check_out_time AT TIME ZONE 'UTC';
+    END IF;
+END
+$$;
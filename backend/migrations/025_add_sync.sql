@@ -0,0 +1,102 @@
+-- Delta sync for offline clients. Every write stamps the row with the id of
+-- the transaction that made it. A sync reads the rows stamped at or after the
+-- client's watermark and hands back the oldest transaction still running as
+-- the next one, so rows from transactions that commit late are picked up by
+-- the following sync instead of being skipped.
+CREATE OR REPLACE FUNCTION set_sync_xid_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.sync_xid = pg_current_xact_id();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE expenses ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE work_logs ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+
+-- Rows that grant access to a project. When one changes, the project may
+-- have become visible to someone, who then needs all of it.
+ALTER TABLE contracts ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE employee_projects ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE project_members ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+ALTER TABLE project_ownership_transfers ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT '0';
+
+CREATE TRIGGER set_projects_sync_xid BEFORE INSERT OR UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_expenses_sync_xid BEFORE INSERT OR UPDATE ON expenses
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_work_logs_sync_xid BEFORE INSERT OR UPDATE ON work_logs
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_contracts_sync_xid BEFORE INSERT OR UPDATE ON contracts
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_employee_projects_sync_xid BEFORE INSERT OR UPDATE ON employee_projects
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_project_members_sync_xid BEFORE INSERT OR UPDATE ON project_members
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+CREATE TRIGGER set_project_ownership_transfers_sync_xid BEFORE INSERT OR UPDATE ON project_ownership_transfers
+    FOR EACH ROW EXECUTE FUNCTION set_sync_xid_column();
+
+-- A restored project comes back with rows that did not change while it was in
+-- the trash, so clients that dropped it need all of it again.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS restored_xid xid8 NOT NULL DEFAULT '0';
+
+CREATE OR REPLACE FUNCTION set_restored_xid_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL THEN
+        NEW.restored_xid = pg_current_xact_id();
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER set_projects_restored_xid BEFORE UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION set_restored_xid_column();
+
+CREATE INDEX IF NOT EXISTS idx_projects_sync_xid ON projects(sync_xid);
+CREATE INDEX IF NOT EXISTS idx_expenses_sync_xid ON expenses(sync_xid);
+CREATE INDEX IF NOT EXISTS idx_work_logs_sync_xid ON work_logs(sync_xid);
+
+-- Expenses and work logs that are deleted for good, which happens when the
+-- trash is purged, leave a tombstone so clients can drop their copy. Soft
+-- deletes need none: the row itself changes. Projects need none either, as
+-- every sync lists the projects the user can still see. Tombstones are purged
+-- once no watermark can be older than them.
+CREATE TABLE IF NOT EXISTS sync_tombstones (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    project_id UUID NOT NULL,
+    sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_tombstones_sync_xid ON sync_tombstones(sync_xid);
+CREATE INDEX IF NOT EXISTS idx_sync_tombstones_deleted_at ON sync_tombstones(deleted_at);
+
+CREATE OR REPLACE FUNCTION record_sync_tombstone()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO sync_tombstones (entity_type, entity_id, project_id) VALUES (TG_ARGV[0], OLD.id, OLD.project_id);
+    RETURN OLD;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER record_expenses_tombstone AFTER DELETE ON expenses
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone('expense');
+CREATE TRIGGER record_work_logs_tombstone AFTER DELETE ON work_logs
+    FOR EACH ROW EXECUTE FUNCTION record_sync_tombstone('work_log');
+
+-- Items uploaded from an offline queue, keyed by the id the client gave them
+-- so a retried upload does not record them twice.
+CREATE TABLE IF NOT EXISTS sync_uploads (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id VARCHAR(255) NOT NULL,
+    item_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, client_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_uploads_created_at ON sync_uploads(created_at);
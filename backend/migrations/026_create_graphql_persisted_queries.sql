@@ -0,0 +1,7 @@
+-- GraphQL queries registered by the SHA-256 of their text, so clients can
+-- send the hash instead of the whole query.
+CREATE TABLE IF NOT EXISTS graphql_persisted_queries (
+    hash CHAR(64) PRIMARY KEY,
+    query TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,15 @@
+-- SSO sign-ins that matched an existing account outside the provider's
+-- organization. The identity is linked only once the account's owner follows
+-- the link emailed to them.
+CREATE TABLE IF NOT EXISTS sso_link_requests (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider_id UUID NOT NULL REFERENCES oidc_providers(id) ON DELETE CASCADE,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sso_link_requests_user_provider ON sso_link_requests(user_id, provider_id);
@@ -0,0 +1,24 @@
+-- Invitations for existing accounts to join an organization. The account is
+-- moved into the organization only once its owner accepts.
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    invited_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'office_manager', 'foreman')),
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) DEFAULT 'pending' CHECK (status IN ('pending', 'accepted', 'revoked')),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_organization_invitations_organization_id ON organization_invitations(organization_id);
+CREATE UNIQUE INDEX idx_organization_invitations_pending
+    ON organization_invitations(organization_id, LOWER(email))
+    WHERE status = 'pending';
+
+CREATE TRIGGER update_organization_invitations_updated_at BEFORE UPDATE ON organization_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// RegisterResponse is returned by Register.
+type RegisterResponse struct {
+	Message string `json:"message"`
+	User    User   `json:"user"`
+}
+
+// Register creates an account. Most accounts must verify their email before
+// they can log in.
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error) {
+	r, err := newJSONRequest("POST", "/auth/register", req)
+	if err != nil {
+		return nil, err
+	}
+	r.public = true
+
+	var resp RegisterResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Login exchanges email and password for a session and uses its token for
+// later calls.
+func (c *Client) Login(ctx context.Context, email, password string) (*AuthResponse, error) {
+	auth, err := c.login(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	c.SetToken(auth.Token)
+	return auth, nil
+}
+
+func (c *Client) login(ctx context.Context, email, password string) (*AuthResponse, error) {
+	r, err := newJSONRequest("POST", "/auth/login", models.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		return nil, err
+	}
+	r.public = true
+
+	var auth AuthResponse
+	if err := c.do(ctx, r, &auth); err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+// VerifyEmail confirms an email address with the token from the
+// verification email.
+func (c *Client) VerifyEmail(ctx context.Context, token string) error {
+	return c.do(ctx, &request{method: "GET", path: "/auth/verify-email", query: url.Values{"token": {token}}, public: true}, nil)
+}
+
+// ForgotPassword emails a password reset link.
+func (c *Client) ForgotPassword(ctx context.Context, email string) error {
+	r, err := newJSONRequest("POST", "/auth/forgot-password", models.ForgotPasswordRequest{Email: email})
+	if err != nil {
+		return err
+	}
+	r.public = true
+	return c.do(ctx, r, nil)
+}
+
+// ResetPassword sets a new password with the token from a reset email.
+func (c *Client) ResetPassword(ctx context.Context, token, newPassword string) error {
+	r, err := newJSONRequest("POST", "/auth/reset-password", models.ResetPasswordRequest{Token: token, NewPassword: newPassword})
+	if err != nil {
+		return err
+	}
+	r.public = true
+	return c.do(ctx, r, nil)
+}
+
+// Me returns the authenticated user.
+func (c *Client) Me(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, &request{method: "GET", path: "/auth/me"}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateMe changes the authenticated user's name or time zone.
+func (c *Client) UpdateMe(ctx context.Context, req UpdateCurrentUserRequest) (*User, error) {
+	r, err := newJSONRequest("PUT", "/auth/me", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	if err := c.do(ctx, r, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,18 @@
+package client
+
+import "context"
+
+// bulk posts a bulk request whose items are applied one at a time. A nil
+// error means the request ran; check each result for the items that failed.
+func (c *Client) bulk(ctx context.Context, path string, body interface{}) (*BulkResponse, error) {
+	r, err := newJSONRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp BulkResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,301 @@
+// Package client is a typed Go client for the Managrr API.
+//
+// It is written against the route table in internal/routes and the OpenAPI
+// document in internal/openapi, and reuses the server's request and response
+// types so the client and server cannot drift apart silently. Those types are
+// aliased in this package, so callers never import the server's internal
+// packages.
+//
+//	c := client.New("https://managrr.example.com", client.WithCredentials(email, password))
+//	projects, err := c.ListAllProjects(ctx, client.ListOptions{})
+//
+// Failed calls return an *APIError carrying the status code, the server's
+// message and its error Code.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// TokenRefresher returns a fresh bearer token. The client calls it before
+// its first authenticated request when it has no token, and again when the
+// API rejects the current token with a 401.
+type TokenRefresher func(ctx context.Context) (string, error)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	refresh    TokenRefresher
+
+	mu    sync.Mutex
+	token string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the default http.Client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates with an existing session token or API token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithTokenRefresher sets how the client obtains a new token once the current
+// one expires.
+func WithTokenRefresher(refresh TokenRefresher) Option {
+	return func(c *Client) {
+		c.refresh = refresh
+	}
+}
+
+// WithCredentials logs in with email and password on first use and again
+// whenever the session expires.
+func WithCredentials(email, password string) Option {
+	return func(c *Client) {
+		c.refresh = func(ctx context.Context) (string, error) {
+			auth, err := c.login(ctx, email, password)
+			if err != nil {
+				return "", err
+			}
+			return auth.Token, nil
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New returns a client for the API served at baseURL, e.g.
+// "https://managrr.example.com". The /api prefix is added by the client.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		userAgent:  "managrr-go-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Token returns the bearer token the client is currently using.
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// SetToken replaces the bearer token.
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+// request is one API call. The body is held in memory so the call can be
+// replayed after a token refresh.
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	body        []byte
+	contentType string
+	public      bool
+}
+
+func newJSONRequest(method, path string, body interface{}) (*request, error) {
+	req := &request{method: method, path: path}
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request body: %w", err)
+		}
+		req.body = data
+		req.contentType = "application/json"
+	}
+	return req, nil
+}
+
+// do sends req and decodes a successful JSON response into out, which may be
+// nil to discard it.
+func (c *Client) do(ctx context.Context, req *request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode %s %s response: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// send performs req, refreshing the token and retrying once on a 401. The
+// caller must close the body of the returned response, which always has a
+// 2xx status.
+func (c *Client) send(ctx context.Context, req *request) (*http.Response, error) {
+	token := ""
+	if !req.public {
+		var err error
+		if token, err = c.currentToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.attempt(ctx, req, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && !req.public && c.refresh != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if token, err = c.refreshToken(ctx, token); err != nil {
+			return nil, err
+		}
+		if resp, err = c.attempt(ctx, req, token); err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		return nil, newAPIError(req.method, req.path, resp)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) attempt(ctx context.Context, req *request, token string) (*http.Response, error) {
+	target := c.baseURL + "/api" + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	if key, ok := ctx.Value(idempotencyKeyCtx{}).(string); ok && req.method == http.MethodPost {
+		httpReq.Header.Set("Idempotency-Key", key)
+	}
+	if version, ok := ctx.Value(ifMatchCtx{}).(int); ok && req.method == http.MethodPut {
+		httpReq.Header.Set("If-Match", `"`+strconv.Itoa(version)+`"`)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.method, req.path, err)
+	}
+	return resp, nil
+}
+
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a context that sends key as the Idempotency-Key
+// of POST requests made with it. Retrying a call with the same key, for
+// example after a timeout, returns the first response instead of creating a
+// second record. Keys must be unique per operation and expire after a day.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+type ifMatchCtx struct{}
+
+// WithVersion returns a context that makes updates made with it conditional
+// on the record still being at version, as read from its Version field. If
+// someone else changed it in the meantime the update fails and
+// IsPreconditionFailed reports true.
+func WithVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, ifMatchCtx{}, version)
+}
+
+// currentToken returns the token to send, fetching one first if the client
+// has none but knows how to get one.
+func (c *Client) currentToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token := c.token
+	c.mu.Unlock()
+
+	if token != "" || c.refresh == nil {
+		return token, nil
+	}
+	return c.refreshToken(ctx, "")
+}
+
+// refreshToken replaces stale with a new token. When another goroutine has
+// already replaced it, its token is used instead of refreshing again.
+func (c *Client) refreshToken(ctx context.Context, stale string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != stale {
+		return c.token, nil
+	}
+
+	token, err := c.refresh(ctx)
+	if err != nil {
+		return "", fmt.Errorf("refresh token: %w", err)
+	}
+	c.token = token
+	return token, nil
+}
+
+// Message is the body of endpoints that only confirm an action.
+type Message struct {
+	Message string `json:"message"`
+}
+
+func pathf(format string, args ...interface{}) string {
+	escaped := make([]interface{}, len(args))
+	for i, arg := range args {
+		escaped[i] = url.PathEscape(fmt.Sprint(arg))
+	}
+	return fmt.Sprintf(format, escaped...)
+}
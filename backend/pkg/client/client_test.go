@@ -0,0 +1,181 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/juazsh/managrr/pkg/client"
+)
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestRefreshesTokenOn401(t *testing.T) {
+	logins, calls := 0, 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("login sent Authorization %q", r.Header.Get("Authorization"))
+		}
+		logins++
+		writeJSON(w, http.StatusOK, client.AuthResponse{Token: "fresh"})
+	})
+	mux.HandleFunc("GET /api/auth/me", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Token expired", "code": string(client.CodeUnauthorized)})
+			return
+		}
+		writeJSON(w, http.StatusOK, client.User{ID: "u1", Email: "pat@example.com"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithToken("stale"), client.WithCredentials("pat@example.com", "secret"))
+
+	user, err := c.Me(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != "u1" {
+		t.Errorf("user = %+v", user)
+	}
+	if logins != 1 || calls != 2 {
+		t.Errorf("logins = %d, calls = %d; want 1 login and the call retried once", logins, calls)
+	}
+	if c.Token() != "fresh" {
+		t.Errorf("token = %q, want the refreshed one", c.Token())
+	}
+
+	// The refreshed token is kept for later calls.
+	if _, err := c.Me(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if logins != 1 {
+		t.Errorf("logged in %d times, want 1", logins)
+	}
+}
+
+func TestRefreshRetriesOnlyOnce(t *testing.T) {
+	refreshes, calls := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "Account disabled", "code": string(client.CodeAccountDisabled)})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithToken("stale"), client.WithTokenRefresher(func(context.Context) (string, error) {
+		refreshes++
+		return "fresh", nil
+	}))
+
+	_, err := c.Me(context.Background())
+	if !client.IsUnauthorized(err) {
+		t.Fatalf("err = %v, want a 401", err)
+	}
+	if refreshes != 1 || calls != 2 {
+		t.Errorf("refreshes = %d, calls = %d; want 1 and 2", refreshes, calls)
+	}
+}
+
+func TestListAllFollowsCursors(t *testing.T) {
+	next := "c2"
+	pages := map[string]client.Page[client.ProjectListItem]{
+		"": {
+			Data:       []client.ProjectListItem{{Project: client.Project{ID: "p1"}}, {Project: client.Project{ID: "p2"}}},
+			NextCursor: &next,
+			Total:      3,
+		},
+		"c2": {
+			Data:  []client.ProjectListItem{{Project: client.Project{ID: "p3"}}},
+			Total: 3,
+		},
+	}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		q := r.URL.Query()
+		requests = append(requests, q.Get("cursor"))
+		if q.Get("status") != "active" || q.Get("sort") != "title" || q.Get("limit") != "200" {
+			t.Errorf("query = %s, want the options on every page", r.URL.RawQuery)
+		}
+		writeJSON(w, http.StatusOK, pages[q.Get("cursor")])
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithToken("t"))
+	projects, err := c.ListAllProjects(context.Background(), client.ListOptions{
+		Sort:    "title",
+		Filters: map[string]string{"status": string(client.ProjectStatusActive)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	if len(ids) != 3 || ids[0] != "p1" || ids[1] != "p2" || ids[2] != "p3" {
+		t.Errorf("projects = %v, want p1 p2 p3", ids)
+	}
+	if len(requests) != 2 || requests[0] != "" || requests[1] != "c2" {
+		t.Errorf("cursors requested = %q, want the first page then c2", requests)
+	}
+}
+
+func TestAPIErrorDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/projects/p1":
+			writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+				"error":   "Validation failed",
+				"code":    client.CodeValidationFailed,
+				"details": []client.FieldError{{Field: "title", Message: "Title is required"}},
+			})
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("upstream unavailable\n"))
+		}
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL, client.WithToken("t"))
+
+	_, err := c.UpdateProject(context.Background(), "p1", client.UpdateProjectRequest{})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Method != "PUT" || apiErr.Path != "/projects/p1" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if apiErr.Message != "Validation failed" || apiErr.Code != client.CodeValidationFailed {
+		t.Errorf("message = %q, code = %q", apiErr.Message, apiErr.Code)
+	}
+	if len(apiErr.Details) != 1 || apiErr.Details[0].Field != "title" {
+		t.Errorf("details = %+v", apiErr.Details)
+	}
+	if !client.IsBadRequest(err) || client.ErrorCode(err) != client.CodeValidationFailed {
+		t.Errorf("IsBadRequest = %v, ErrorCode = %q", client.IsBadRequest(err), client.ErrorCode(err))
+	}
+
+	// Bodies that are not the API's JSON error are kept as the message.
+	_, err = c.Me(context.Background())
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "upstream unavailable" || apiErr.Code != "" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// ContractWithContractor is a contract with the contractor's contact details.
+type ContractWithContractor struct {
+	Contract
+	ContractorName  string `json:"contractor_name"`
+	ContractorEmail string `json:"contractor_email"`
+}
+
+// ListContracts returns a project's contracts.
+func (c *Client) ListContracts(ctx context.Context, projectID string) ([]ContractWithContractor, error) {
+	var contracts []ContractWithContractor
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/contracts/project/%s", projectID)}, &contracts); err != nil {
+		return nil, err
+	}
+	return contracts, nil
+}
+
+// GetContract returns a contract.
+func (c *Client) GetContract(ctx context.Context, contractID string) (*Contract, error) {
+	var contract Contract
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/contracts/%s", contractID)}, &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+// UpdateContractStatus moves a contract to status. endDate may be nil.
+func (c *Client) UpdateContractStatus(ctx context.Context, contractID string, status ContractStatus, endDate *time.Time) (*Contract, error) {
+	r, err := newJSONRequest("PUT", pathf("/contracts/%s/status", contractID), models.UpdateContractStatusRequest{
+		Status:  status,
+		EndDate: endDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var contract Contract
+	if err := c.do(ctx, r, &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
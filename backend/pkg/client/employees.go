@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// AddEmployee adds someone to the caller's crew and emails them an
+// invitation.
+func (c *Client) AddEmployee(ctx context.Context, req AddEmployeeRequest) (*Employee, error) {
+	r, err := newJSONRequest("POST", "/employees", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var employee Employee
+	if err := c.do(ctx, r, &employee); err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// ListEmployees returns the caller's crew.
+func (c *Client) ListEmployees(ctx context.Context) ([]Employee, error) {
+	var employees []Employee
+	if err := c.do(ctx, &request{method: "GET", path: "/employees"}, &employees); err != nil {
+		return nil, err
+	}
+	return employees, nil
+}
+
+// GetEmployee returns an employee and the projects they are assigned to.
+func (c *Client) GetEmployee(ctx context.Context, employeeID string) (*EmployeeWithProjects, error) {
+	var employee EmployeeWithProjects
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/employees/%s", employeeID)}, &employee); err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// UpdateEmployee changes an employee's details.
+func (c *Client) UpdateEmployee(ctx context.Context, employeeID string, req UpdateEmployeeRequest) (*Employee, error) {
+	r, err := newJSONRequest("PUT", pathf("/employees/%s", employeeID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var employee Employee
+	if err := c.do(ctx, r, &employee); err != nil {
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// DeleteEmployee removes an employee from the crew. They stay in the trash
+// until purged and can be brought back with RestoreEmployee.
+func (c *Client) DeleteEmployee(ctx context.Context, employeeID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/employees/%s", employeeID)}, nil)
+}
+
+// RestoreEmployee brings an employee back from the trash.
+func (c *Client) RestoreEmployee(ctx context.Context, employeeID string) error {
+	return c.do(ctx, &request{method: "POST", path: pathf("/employees/%s/restore", employeeID)}, nil)
+}
+
+// AssignEmployeeToProject assigns an employee to a project.
+func (c *Client) AssignEmployeeToProject(ctx context.Context, employeeID, projectID string) error {
+	r, err := newJSONRequest("POST", pathf("/employees/%s/assign-project", employeeID), models.AssignProjectRequest{ProjectID: projectID})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, r, nil)
+}
+
+// UnassignEmployeeFromProject takes an employee off a project.
+func (c *Client) UnassignEmployeeFromProject(ctx context.Context, employeeID, projectID string) error {
+	r, err := newJSONRequest("POST", pathf("/employees/%s/unassign-project", employeeID), models.AssignProjectRequest{ProjectID: projectID})
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, r, nil)
+}
+
+// AssignEmployeesToProject assigns several employees to a project, reporting
+// the outcome for each.
+func (c *Client) AssignEmployeesToProject(ctx context.Context, projectID string, employeeIDs ...string) (*BulkResponse, error) {
+	return c.bulk(ctx, "/employees/bulk-assign-project", models.BulkAssignProjectRequest{ProjectID: projectID, EmployeeIDs: employeeIDs})
+}
+
+// UnassignEmployeesFromProject takes several employees off a project,
+// reporting the outcome for each.
+func (c *Client) UnassignEmployeesFromProject(ctx context.Context, projectID string, employeeIDs ...string) (*BulkResponse, error) {
+	return c.bulk(ctx, "/employees/bulk-unassign-project", models.BulkAssignProjectRequest{ProjectID: projectID, EmployeeIDs: employeeIDs})
+}
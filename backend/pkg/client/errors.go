@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the API answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Path       string
+	// Message is the server's error message, or the raw body when it was not
+	// the usual JSON error.
+	Message string
+	// Code is the server's machine-readable error code; compare it against
+	// the Code constants rather than matching Message.
+	Code Code
+	// Details lists the rejected fields of a failed validation.
+	Details []FieldError
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+func newAPIError(method, path string, resp *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Method: method, Path: path}
+
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	var body ErrorBody
+	if err := json.Unmarshal(data, &body); err == nil && body.Message != "" {
+		apiErr.Message = body.Message
+		apiErr.Code = body.Code
+		apiErr.Details = body.Details
+	} else {
+		apiErr.Message = strings.TrimSpace(string(data))
+	}
+
+	return apiErr
+}
+
+// StatusCode returns the HTTP status of an *APIError in err's chain, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// ErrorCode returns the code of an *APIError in err's chain, or "".
+func ErrorCode(err error) Code {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+// IsBadRequest reports whether the API rejected the request as invalid.
+func IsBadRequest(err error) bool {
+	return StatusCode(err) == http.StatusBadRequest
+}
+
+// IsUnauthorized reports whether the API rejected the credentials.
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether the caller may not perform the action.
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsNotFound reports whether the resource does not exist.
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports whether the request conflicts with existing data.
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsPreconditionFailed reports whether a conditional update lost to someone
+// else's change.
+func IsPreconditionFailed(err error) bool {
+	return StatusCode(err) == http.StatusPreconditionFailed
+}
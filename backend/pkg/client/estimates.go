@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// CreateEstimate submits an estimate on a contract.
+func (c *Client) CreateEstimate(ctx context.Context, req CreateEstimateRequest) (*Estimate, error) {
+	r, err := newJSONRequest("POST", "/estimates", req)
+	if err != nil {
+		return nil, err
+	}
+
+	var estimate Estimate
+	if err := c.do(ctx, r, &estimate); err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// ListEstimates returns a contract's estimates.
+func (c *Client) ListEstimates(ctx context.Context, contractID string) ([]Estimate, error) {
+	var estimates []Estimate
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/estimates/contract/%s", contractID)}, &estimates); err != nil {
+		return nil, err
+	}
+	return estimates, nil
+}
+
+// ApproveEstimate approves an estimate, optionally making it the contract's
+// active estimate.
+func (c *Client) ApproveEstimate(ctx context.Context, estimateID string, setAsActive bool) (*Estimate, error) {
+	r, err := newJSONRequest("POST", pathf("/estimates/%s/approve", estimateID), models.ApproveEstimateRequest{SetAsActive: setAsActive})
+	if err != nil {
+		return nil, err
+	}
+
+	var estimate Estimate
+	if err := c.do(ctx, r, &estimate); err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
+
+// RejectEstimate rejects an estimate.
+func (c *Client) RejectEstimate(ctx context.Context, estimateID, reason string) (*Estimate, error) {
+	r, err := newJSONRequest("POST", pathf("/estimates/%s/reject", estimateID), models.RejectEstimateRequest{Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+
+	var estimate Estimate
+	if err := c.do(ctx, r, &estimate); err != nil {
+		return nil, err
+	}
+	return &estimate, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import "context"
+
+// ImportExchangeRatesResponse reports what ImportExchangeRates saved.
+type ImportExchangeRatesResponse struct {
+	Message  string `json:"message"`
+	Imported int    `json:"imported"`
+	Created  int    `json:"created"`
+	Replaced int    `json:"replaced"`
+}
+
+// ListExchangeRates returns one page of a project's exchange rates. Filters:
+// currency, source.
+func (c *Client) ListExchangeRates(ctx context.Context, projectID string, opts ListOptions) (*Page[ProjectExchangeRate], error) {
+	var page Page[ProjectExchangeRate]
+	if err := c.list(ctx, pathf("/projects/%s/exchange-rates", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// SetExchangeRate sets the rate for a currency from req.EffectiveDate on,
+// replacing any rate already set for that day.
+func (c *Client) SetExchangeRate(ctx context.Context, projectID string, req SetExchangeRateRequest) (*ProjectExchangeRate, error) {
+	r, err := newJSONRequest("POST", pathf("/projects/%s/exchange-rates", projectID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var rate ProjectExchangeRate
+	if err := c.do(ctx, r, &rate); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// ImportExchangeRates uploads a CSV file of currency, rate and
+// effective_date rows. Nothing is saved unless every row is valid.
+func (c *Client) ImportExchangeRates(ctx context.Context, projectID string, csv *File) (*ImportExchangeRatesResponse, error) {
+	f := newForm()
+	f.file("file", csv)
+
+	r, err := f.request("POST", pathf("/projects/%s/exchange-rates/import", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ImportExchangeRatesResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteExchangeRate removes a rate. Expenses and payments already converted
+// with it are unchanged.
+func (c *Client) DeleteExchangeRate(ctx context.Context, projectID, rateID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/projects/%s/exchange-rates/%s", projectID, rateID)}, nil)
+}
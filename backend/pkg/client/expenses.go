@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// ExpenseWithUser is an expense with the name of whoever recorded it.
+type ExpenseWithUser struct {
+	Expense
+	AddedByName string `json:"added_by_name"`
+}
+
+// ExpenseSummary totals every expense matching a list request, not just the
+// page returned, in the project currency.
+type ExpenseSummary struct {
+	TotalExpenses       Amount            `json:"total_expenses"`
+	TotalByOwner        Amount            `json:"total_by_owner"`
+	TotalByContractor   Amount            `json:"total_by_contractor"`
+	BreakdownByCategory map[string]Amount `json:"breakdown_by_category"`
+}
+
+// ExpensePage is a page of a project's expenses.
+type ExpensePage struct {
+	Page[ExpenseWithUser]
+	Summary ExpenseSummary `json:"summary"`
+}
+
+// UpdateExpenseRequest holds the editable fields of an expense.
+type UpdateExpenseRequest struct {
+	Amount Amount
+	// Currency of Amount. Empty keeps the expense's current currency.
+	Currency    Currency
+	Date        string
+	Category    ExpenseCategory
+	Vendor      *string
+	Description *string
+}
+
+// CreateExpense records an expense with an optional receipt photo. Owners
+// must say which contract it belongs to; contractors leave contractID empty.
+// An empty Currency records it in the project currency.
+// PaidBy is set by the server from the caller's role.
+func (c *Client) CreateExpense(ctx context.Context, req CreateExpenseRequest, contractID string, receipt *File) (*Expense, error) {
+	f := newForm()
+	f.field("project_id", req.ProjectID)
+	f.field("contract_id", contractID)
+	writeExpenseFields(f, req.Amount, req.Currency, req.Date, req.Category, req.Vendor, req.Description)
+	f.file("receipt_photo", receipt)
+
+	r, err := f.request("POST", "/expenses")
+	if err != nil {
+		return nil, err
+	}
+
+	var expense Expense
+	if err := c.do(ctx, r, &expense); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// CreateExpenses records several expenses on a project at once. receipts
+// maps the ReceiptPhoto names the items use to their files. Owners must say
+// which contract the expenses belong to; contractors leave contractID empty.
+// Nothing is created unless every expense is valid.
+func (c *Client) CreateExpenses(ctx context.Context, projectID, contractID string, items []BulkExpenseItem, receipts map[string]*File) ([]Expense, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("encode expenses: %w", err)
+	}
+
+	f := newForm()
+	f.field("contract_id", contractID)
+	f.field("expenses", string(data))
+
+	names := make([]string, 0, len(receipts))
+	for name := range receipts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f.file(name, receipts[name])
+	}
+
+	r, err := f.request("POST", pathf("/projects/%s/expenses/bulk", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp models.BulkCreateExpensesResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Expenses, nil
+}
+
+// GetExpense returns an expense.
+func (c *Client) GetExpense(ctx context.Context, expenseID string) (*ExpenseWithUser, error) {
+	var expense ExpenseWithUser
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/expenses/%s", expenseID)}, &expense); err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// UpdateExpense replaces an expense's fields. A nil receipt keeps the
+// current one.
+func (c *Client) UpdateExpense(ctx context.Context, expenseID string, req UpdateExpenseRequest, receipt *File) error {
+	f := newForm()
+	writeExpenseFields(f, req.Amount, req.Currency, req.Date, req.Category, req.Vendor, req.Description)
+	f.file("receipt_photo", receipt)
+
+	r, err := f.request("PUT", pathf("/expenses/%s", expenseID))
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, r, nil)
+}
+
+// DeleteExpense moves an expense to the trash.
+func (c *Client) DeleteExpense(ctx context.Context, expenseID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/expenses/%s", expenseID)}, nil)
+}
+
+// RestoreExpense brings an expense back from the trash.
+func (c *Client) RestoreExpense(ctx context.Context, expenseID string) error {
+	return c.do(ctx, &request{method: "POST", path: pathf("/expenses/%s/restore", expenseID)}, nil)
+}
+
+// ListProjectExpenses returns one page of a project's expenses and a summary
+// of all of them. Filters: contract_id, paid_by, category.
+func (c *Client) ListProjectExpenses(ctx context.Context, projectID string, opts ListOptions) (*ExpensePage, error) {
+	var page ExpensePage
+	if err := c.list(ctx, pathf("/projects/%s/expenses", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+func writeExpenseFields(f *form, amount Amount, currency Currency, date string, category ExpenseCategory, vendor, description *string) {
+	f.field("amount", amount.String())
+	f.field("currency", string(currency))
+	f.field("date", date)
+	f.field("category", string(category))
+	if vendor != nil {
+		f.field("vendor", *vendor)
+	}
+	if description != nil {
+		f.field("description", *description)
+	}
+}
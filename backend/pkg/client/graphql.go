@@ -0,0 +1,23 @@
+package client
+
+import (
+	"context"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// GraphQL runs a GraphQL query. A query that fails, in whole or in part, is
+// not an error: its errors are in the response's Errors, and Data holds what
+// did resolve. Unmarshal Data into a struct shaped like the query.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}) (*GraphQLResponse, error) {
+	r, err := newJSONRequest("POST", "/graphql", models.GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+
+	var resp GraphQLResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
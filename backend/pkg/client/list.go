@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/juazsh/managrr/internal/pagination"
+)
+
+// ListOptions pages, sorts and filters list endpoints. The zero value asks
+// for the first page in the endpoint's default order.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	// Sort is a sort key, prefixed with "-" for descending, e.g. "-date".
+	Sort string
+	From time.Time
+	To   time.Time
+	// Filters holds endpoint-specific equality filters such as status or
+	// contract_id.
+	Filters map[string]string
+}
+
+func (o ListOptions) values() url.Values {
+	values := url.Values{}
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		values.Set("cursor", o.Cursor)
+	}
+	if o.Sort != "" {
+		values.Set("sort", o.Sort)
+	}
+	if !o.From.IsZero() {
+		values.Set("from", o.From.Format(time.RFC3339))
+	}
+	if !o.To.IsZero() {
+		values.Set("to", o.To.Format(time.RFC3339))
+	}
+	for key, value := range o.Filters {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+	return values
+}
+
+func (c *Client) list(ctx context.Context, path string, opts ListOptions, out interface{}) error {
+	return c.do(ctx, &request{method: "GET", path: path, query: opts.values()}, out)
+}
+
+// listAll follows next_cursor from opts until the last page.
+func listAll[T any](ctx context.Context, opts ListOptions, page func(context.Context, ListOptions) (*Page[T], error)) ([]T, error) {
+	if opts.Limit == 0 {
+		opts.Limit = pagination.MaxLimit
+	}
+
+	var items []T
+	for {
+		p, err := page(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, p.Data...)
+		if p.NextCursor == nil {
+			return items, nil
+		}
+		opts.Cursor = *p.NextCursor
+	}
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+// File is an upload. ContentType defaults from the file name's extension.
+type File struct {
+	Name        string
+	ContentType string
+	Content     io.Reader
+}
+
+// form builds a multipart/form-data body.
+type form struct {
+	buf    bytes.Buffer
+	writer *multipart.Writer
+	err    error
+}
+
+func newForm() *form {
+	f := &form{}
+	f.writer = multipart.NewWriter(&f.buf)
+	return f
+}
+
+// field adds a text field, skipping empty values so optional fields can be
+// passed unconditionally.
+func (f *form) field(name, value string) {
+	if f.err != nil || value == "" {
+		return
+	}
+	f.err = f.writer.WriteField(name, value)
+}
+
+// file adds an upload, skipping nil files.
+func (f *form) file(name string, file *File) {
+	if f.err != nil || file == nil {
+		return
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = imageContentType(file.Name)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		escapeQuotes(name), escapeQuotes(filepath.Base(file.Name))))
+	header.Set("Content-Type", contentType)
+
+	part, err := f.writer.CreatePart(header)
+	if err != nil {
+		f.err = err
+		return
+	}
+	_, f.err = io.Copy(part, file.Content)
+}
+
+func (f *form) request(method, path string) (*request, error) {
+	if f.err == nil {
+		f.err = f.writer.Close()
+	}
+	if f.err != nil {
+		return nil, fmt.Errorf("build multipart body: %w", f.err)
+	}
+
+	return &request{
+		method:      method,
+		path:        path,
+		body:        f.buf.Bytes(),
+		contentType: f.writer.FormDataContentType(),
+	}, nil
+}
+
+// imageContentType matches the extensions the upload handlers accept.
+func imageContentType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".png":
+		return "image/png"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// PaymentWithUser is a payment with the name of whoever recorded it.
+type PaymentWithUser struct {
+	PaymentSummary
+	AddedByName string `json:"added_by_name"`
+}
+
+// PaymentRequest holds the fields of a payment.
+type PaymentRequest struct {
+	// ContractID is required when recording a payment and ignored on update.
+	ContractID string
+	Amount     Amount
+	// Currency of Amount. Empty means the project currency when recording
+	// and the payment's current currency on update.
+	Currency      Currency
+	PaymentMethod PaymentMethod
+	// PaymentDate is a calendar date, YYYY-MM-DD.
+	PaymentDate string
+	Notes       string
+}
+
+// CreatePayment records a payment to a contractor with an optional
+// screenshot of the transfer.
+func (c *Client) CreatePayment(ctx context.Context, projectID string, req PaymentRequest, screenshot *File) (*PaymentSummary, error) {
+	f := newForm()
+	f.field("project_id", projectID)
+	f.field("contract_id", req.ContractID)
+	writePaymentFields(f, req)
+	f.file("screenshot", screenshot)
+
+	r, err := f.request("POST", pathf("/projects/%s/payments", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var payment PaymentSummary
+	if err := c.do(ctx, r, &payment); err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListPayments returns one page of a project's payments. Filters:
+// contract_id, status, payment_method.
+func (c *Client) ListPayments(ctx context.Context, projectID string, opts ListOptions) (*Page[PaymentWithUser], error) {
+	var page Page[PaymentWithUser]
+	if err := c.list(ctx, pathf("/projects/%s/payments", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListAllPayments returns every payment on a project matching opts.
+func (c *Client) ListAllPayments(ctx context.Context, projectID string, opts ListOptions) ([]PaymentWithUser, error) {
+	return listAll(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[PaymentWithUser], error) {
+		return c.ListPayments(ctx, projectID, opts)
+	})
+}
+
+// UpdatePayment replaces a payment's fields. A nil screenshot keeps the
+// current one.
+func (c *Client) UpdatePayment(ctx context.Context, paymentID string, req PaymentRequest, screenshot *File) error {
+	f := newForm()
+	writePaymentFields(f, req)
+	f.file("screenshot", screenshot)
+
+	r, err := f.request("PUT", pathf("/payments/%s", paymentID))
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, r, nil)
+}
+
+// DeletePayment moves a payment to the trash.
+func (c *Client) DeletePayment(ctx context.Context, paymentID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/payments/%s", paymentID)}, nil)
+}
+
+// RestorePayment brings a payment back from the trash.
+func (c *Client) RestorePayment(ctx context.Context, paymentID string) error {
+	return c.do(ctx, &request{method: "POST", path: pathf("/payments/%s/restore", paymentID)}, nil)
+}
+
+// ConfirmPayment confirms the contractor received a payment.
+func (c *Client) ConfirmPayment(ctx context.Context, paymentID string) error {
+	return c.do(ctx, &request{method: "POST", path: pathf("/payments/%s/confirm", paymentID)}, nil)
+}
+
+// ConfirmPayments confirms several payments, reporting the outcome for each.
+func (c *Client) ConfirmPayments(ctx context.Context, paymentIDs ...string) (*BulkResponse, error) {
+	return c.bulk(ctx, "/payments/bulk-confirm", models.BulkConfirmPaymentsRequest{PaymentIDs: paymentIDs})
+}
+
+// DisputePayment disputes a payment.
+func (c *Client) DisputePayment(ctx context.Context, paymentID, reason string) error {
+	body := struct {
+		Reason string `json:"reason"`
+	}{Reason: reason}
+
+	r, err := newJSONRequest("POST", pathf("/payments/%s/dispute", paymentID), body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, r, nil)
+}
+
+func writePaymentFields(f *form, req PaymentRequest) {
+	f.field("amount", req.Amount.String())
+	f.field("currency", string(req.Currency))
+	f.field("payment_method", string(req.PaymentMethod))
+	f.field("payment_date", req.PaymentDate)
+	f.field("notes", req.Notes)
+}
@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/juazsh/managrr/internal/models"
+)
+
+// ProjectListItem is a project as listed, with its lead contractor.
+type ProjectListItem struct {
+	Project
+	ContractorName  *string `json:"contractor_name,omitempty"`
+	ContractorCount int     `json:"contractor_count"`
+}
+
+// ProjectDetail is a project with its owner and active contractors.
+type ProjectDetail struct {
+	Project
+	OwnerName   string                  `json:"owner_name"`
+	OwnerEmail  string                  `json:"owner_email"`
+	Contractors []ProjectContractorInfo `json:"contractors"`
+}
+
+// ProjectContractorInfo identifies a contractor on a project.
+type ProjectContractorInfo struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// ProjectContractor is a contractor's contract on a project.
+type ProjectContractor struct {
+	ContractID   string    `json:"contract_id"`
+	ContractorID string    `json:"contractor_id"`
+	Name         string    `json:"name"`
+	Email        string    `json:"email"`
+	AssignedAt   time.Time `json:"assigned_at"`
+}
+
+// AssignContractorsResponse reports what AssignContractors did.
+type AssignContractorsResponse struct {
+	Message           string   `json:"message"`
+	AssignedCount     int      `json:"assigned_count"`
+	DuplicateCount    int      `json:"duplicate_count"`
+	FailedContractors []string `json:"failed_contractors,omitempty"`
+}
+
+// CreateProject creates a draft project with optional photos. An empty
+// TimeZone uses the owner's.
+func (c *Client) CreateProject(ctx context.Context, req CreateProjectRequest, photos ...*File) (*Project, error) {
+	f := newForm()
+	f.field("title", req.Title)
+	f.field("description", req.Description)
+	if req.EstimatedCost != 0 {
+		f.field("estimated_cost", req.EstimatedCost.String())
+	}
+	f.field("currency", string(req.Currency))
+	f.field("time_zone", string(req.TimeZone))
+	if req.Address != nil {
+		f.field("address", *req.Address)
+	}
+	for _, photo := range photos {
+		f.file("photos", photo)
+	}
+
+	r, err := f.request("POST", "/projects")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Project Project `json:"project"`
+	}
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Project, nil
+}
+
+// ListProjects returns one page of the caller's projects. Filters: status.
+func (c *Client) ListProjects(ctx context.Context, opts ListOptions) (*Page[ProjectListItem], error) {
+	var page Page[ProjectListItem]
+	if err := c.list(ctx, "/projects", opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListAllProjects returns every project matching opts.
+func (c *Client) ListAllProjects(ctx context.Context, opts ListOptions) ([]ProjectListItem, error) {
+	return listAll(ctx, opts, c.ListProjects)
+}
+
+// GetProject returns a project with its owner and contractors.
+func (c *Client) GetProject(ctx context.Context, projectID string) (*ProjectDetail, error) {
+	var project ProjectDetail
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/projects/%s", projectID)}, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// UpdateProject changes the fields set in req.
+func (c *Client) UpdateProject(ctx context.Context, projectID string, req UpdateProjectRequest) (*Project, error) {
+	r, err := newJSONRequest("PUT", pathf("/projects/%s", projectID), req)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.do(ctx, r, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// DeleteProject moves a project to the trash.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/projects/%s", projectID)}, nil)
+}
+
+// RestoreProject brings a project back from the trash.
+func (c *Client) RestoreProject(ctx context.Context, projectID string) error {
+	return c.do(ctx, &request{method: "POST", path: pathf("/projects/%s/restore", projectID)}, nil)
+}
+
+// AssignContractors gives each contractor a contract on the project.
+func (c *Client) AssignContractors(ctx context.Context, projectID string, contractorIDs ...string) (*AssignContractorsResponse, error) {
+	body := struct {
+		ContractorIDs []string `json:"contractor_ids"`
+	}{ContractorIDs: contractorIDs}
+
+	r, err := newJSONRequest("POST", pathf("/projects/%s/contractors", projectID), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp AssignContractorsResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveContractor terminates a contractor's contract on the project.
+func (c *Client) RemoveContractor(ctx context.Context, projectID, contractorID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/projects/%s/contractors/%s", projectID, contractorID)}, nil)
+}
+
+// ListProjectContractors returns the contractors working on a project.
+func (c *Client) ListProjectContractors(ctx context.Context, projectID string) ([]ProjectContractor, error) {
+	var contractors []ProjectContractor
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/projects/%s/contractors", projectID)}, &contractors); err != nil {
+		return nil, err
+	}
+	return contractors, nil
+}
+
+// UploadProjectPhoto adds a photo to the project. Owners must say which
+// contract it belongs to; contractors leave contractID empty.
+func (c *Client) UploadProjectPhoto(ctx context.Context, projectID string, photo *File, caption, contractID string) (*ProjectPhoto, error) {
+	f := newForm()
+	f.file("photo", photo)
+	f.field("caption", caption)
+	f.field("contract_id", contractID)
+
+	r, err := f.request("POST", pathf("/projects/%s/photos", projectID))
+	if err != nil {
+		return nil, err
+	}
+
+	var uploaded ProjectPhoto
+	if err := c.do(ctx, r, &uploaded); err != nil {
+		return nil, err
+	}
+	return &uploaded, nil
+}
+
+// ListProjectPhotos returns one page of a project's photos. Filters:
+// contract_id.
+func (c *Client) ListProjectPhotos(ctx context.Context, projectID string, opts ListOptions) (*Page[ProjectPhoto], error) {
+	var page Page[ProjectPhoto]
+	if err := c.list(ctx, pathf("/projects/%s/photos", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// DeleteProjectPhoto deletes a photo. Owners can delete any photo; others
+// only the ones they uploaded.
+func (c *Client) DeleteProjectPhoto(ctx context.Context, projectID, photoID string) error {
+	return c.do(ctx, &request{method: "DELETE", path: pathf("/projects/%s/photos/%s", projectID, photoID)}, nil)
+}
+
+// DeleteProjectPhotos deletes several photos, reporting the outcome for each.
+func (c *Client) DeleteProjectPhotos(ctx context.Context, projectID string, photoIDs ...string) (*BulkResponse, error) {
+	return c.bulk(ctx, pathf("/projects/%s/photos/bulk-delete", projectID), models.BulkDeletePhotosRequest{PhotoIDs: photoIDs})
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// Sync returns what changed since the watermark of an earlier sync, or
+// everything when since is empty. Pass the returned Watermark next time.
+func (c *Client) Sync(ctx context.Context, since string) (*SyncResponse, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+
+	var resp SyncResponse
+	if err := c.do(ctx, &request{method: "GET", path: "/sync", query: query}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UploadSyncItems uploads check-ins, check-outs and expenses queued offline.
+// files holds the photos and receipts the items name, keyed by field name.
+// Items are applied one at a time; check each result for its outcome.
+func (c *Client) UploadSyncItems(ctx context.Context, items []SyncUploadItem, files map[string]*File) (*SyncUploadResponse, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("encode sync items: %w", err)
+	}
+
+	f := newForm()
+	f.field("items", string(data))
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f.file(name, files[name])
+	}
+
+	r, err := f.request("POST", "/sync/upload")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SyncUploadResponse
+	if err := c.do(ctx, r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
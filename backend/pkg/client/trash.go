@@ -0,0 +1,13 @@
+package client
+
+import "context"
+
+// ListTrash returns one page of what the caller deleted and can still
+// restore. Filters: type (project, expense, payment or employee).
+func (c *Client) ListTrash(ctx context.Context, opts ListOptions) (*Page[TrashItem], error) {
+	var page Page[TrashItem]
+	if err := c.list(ctx, "/trash", opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -0,0 +1,231 @@
+package client
+
+import (
+	"github.com/juazsh/managrr/internal/apierror"
+	"github.com/juazsh/managrr/internal/calendar"
+	"github.com/juazsh/managrr/internal/models"
+	"github.com/juazsh/managrr/internal/money"
+	"github.com/juazsh/managrr/internal/pagination"
+)
+
+// The request and response types are the server's own. They are aliased here
+// so programs outside this module, which cannot import internal packages, can
+// name them.
+
+type (
+	// Amount is a money amount held exactly in cents. It is sent and received
+	// as a JSON number.
+	Amount = money.Amount
+	// Currency is an ISO 4217 currency code.
+	Currency = money.Currency
+	// Rate is an exchange rate: units of a project's currency one unit of
+	// another currency buys.
+	Rate = money.Rate
+	// Zone is an IANA time zone name such as "America/Chicago".
+	Zone = calendar.Zone
+
+	// Code is a machine-readable error code.
+	Code = apierror.Code
+	// FieldError describes one rejected field of a failed validation.
+	FieldError = apierror.FieldError
+	// ErrorBody is the error an item of a bulk request or sync upload failed
+	// with.
+	ErrorBody = apierror.Error
+)
+
+// Page is one page of a list. NextCursor is nil on the last page.
+type Page[T any] = pagination.Page[T]
+
+type (
+	AddEmployeeRequest       = models.AddEmployeeRequest
+	AssignedProject          = models.AssignedProject
+	AuthResponse             = models.AuthResponse
+	BulkExpenseItem          = models.BulkExpenseItem
+	BulkResponse             = models.BulkResponse
+	BulkResult               = models.BulkResult
+	Contract                 = models.Contract
+	ContractStatus           = models.ContractStatus
+	CreateEstimateRequest    = models.CreateEstimateRequest
+	CreateExpenseRequest     = models.CreateExpenseRequest
+	CreateProjectRequest     = models.CreateProjectRequest
+	Employee                 = models.Employee
+	EmployeeWithProjects     = models.EmployeeWithProjects
+	Estimate                 = models.Estimate
+	EstimateStatus           = models.EstimateStatus
+	ExchangeRateSource       = models.ExchangeRateSource
+	Expense                  = models.Expense
+	ExpenseCategory          = models.ExpenseCategory
+	ExpensePaidBy            = models.ExpensePaidBy
+	GraphQLError             = models.GraphQLError
+	GraphQLResponse          = models.GraphQLResponse
+	PaymentMethod            = models.PaymentMethod
+	PaymentStatus            = models.PaymentStatus
+	PaymentSummary           = models.PaymentSummary
+	Project                  = models.Project
+	ProjectExchangeRate      = models.ProjectExchangeRate
+	ProjectPhoto             = models.ProjectPhoto
+	ProjectStatus            = models.ProjectStatus
+	ProjectUpdatePhoto       = models.ProjectUpdatePhoto
+	RegisterRequest          = models.RegisterRequest
+	SetExchangeRateRequest   = models.SetExchangeRateRequest
+	SyncEntityType           = models.SyncEntityType
+	SyncItemType             = models.SyncItemType
+	SyncResponse             = models.SyncResponse
+	SyncTombstone            = models.SyncTombstone
+	SyncUploadItem           = models.SyncUploadItem
+	SyncUploadResponse       = models.SyncUploadResponse
+	SyncUploadResult         = models.SyncUploadResult
+	TrashItem                = models.TrashItem
+	UpdateCurrentUserRequest = models.UpdateCurrentUserRequest
+	UpdateEmployeeRequest    = models.UpdateEmployeeRequest
+	UpdateProjectRequest     = models.UpdateProjectRequest
+	UpdateType               = models.UpdateType
+	User                     = models.User
+	UserType                 = models.UserType
+	WorkLog                  = models.WorkLog
+)
+
+// DefaultCurrency is the currency of projects created without one.
+const DefaultCurrency = money.DefaultCurrency
+
+const (
+	ContractStatusPending    = models.ContractStatusPending
+	ContractStatusActive     = models.ContractStatusActive
+	ContractStatusCompleted  = models.ContractStatusCompleted
+	ContractStatusTerminated = models.ContractStatusTerminated
+
+	EstimateStatusPending  = models.EstimateStatusPending
+	EstimateStatusApproved = models.EstimateStatusApproved
+	EstimateStatusRejected = models.EstimateStatusRejected
+
+	ExchangeRateSourceManual = models.ExchangeRateSourceManual
+	ExchangeRateSourceImport = models.ExchangeRateSourceImport
+
+	ExpenseCategoryMaterials = models.ExpenseCategoryMaterials
+	ExpenseCategoryLabor     = models.ExpenseCategoryLabor
+	ExpenseCategoryEquipment = models.ExpenseCategoryEquipment
+	ExpenseCategoryOther     = models.ExpenseCategoryOther
+
+	ExpensePaidByContractor = models.ExpensePaidByContractor
+	ExpensePaidByOwner      = models.ExpensePaidByOwner
+
+	PaymentMethodCash         = models.PaymentMethodCash
+	PaymentMethodBankTransfer = models.PaymentMethodBankTransfer
+	PaymentMethodZelle        = models.PaymentMethodZelle
+	PaymentMethodPaypal       = models.PaymentMethodPaypal
+	PaymentMethodCashApp      = models.PaymentMethodCashApp
+	PaymentMethodVenmo        = models.PaymentMethodVenmo
+	PaymentMethodOther        = models.PaymentMethodOther
+
+	PaymentStatusPending   = models.PaymentStatusPending
+	PaymentStatusConfirmed = models.PaymentStatusConfirmed
+	PaymentStatusDisputed  = models.PaymentStatusDisputed
+
+	ProjectStatusDraft     = models.ProjectStatusDraft
+	ProjectStatusActive    = models.ProjectStatusActive
+	ProjectStatusCompleted = models.ProjectStatusCompleted
+
+	SyncEntityWorkLog = models.SyncEntityWorkLog
+	SyncEntityExpense = models.SyncEntityExpense
+
+	SyncItemCheckIn  = models.SyncItemCheckIn
+	SyncItemCheckOut = models.SyncItemCheckOut
+	SyncItemExpense  = models.SyncItemExpense
+
+	UpdateTypeDailySummary = models.UpdateTypeDailySummary
+	UpdateTypeWeeklyPlan   = models.UpdateTypeWeeklyPlan
+
+	UserTypeHouseOwner = models.UserTypeHouseOwner
+	UserTypeContractor = models.UserTypeContractor
+	UserTypeEmployee   = models.UserTypeEmployee
+	UserTypeAdmin      = models.UserTypeAdmin
+)
+
+// Error codes the API answers with. Compare APIError.Code against these
+// rather than matching messages.
+const (
+	CodeBadRequest             = apierror.CodeBadRequest
+	CodeUnauthorized           = apierror.CodeUnauthorized
+	CodeForbidden              = apierror.CodeForbidden
+	CodeNotFound               = apierror.CodeNotFound
+	CodeConflict               = apierror.CodeConflict
+	CodeGone                   = apierror.CodeGone
+	CodePayloadTooLarge        = apierror.CodePayloadTooLarge
+	CodeTooManyRequests        = apierror.CodeTooManyRequests
+	CodeInternal               = apierror.CodeInternal
+	CodeBadGateway             = apierror.CodeBadGateway
+	CodeServiceUnavailable     = apierror.CodeServiceUnavailable
+	CodeValidationFailed       = apierror.CodeValidationFailed
+	CodeInvalidRequestBody     = apierror.CodeInvalidRequestBody
+	CodeInvalidQuery           = apierror.CodeInvalidQuery
+	CodeInvalidID              = apierror.CodeInvalidID
+	CodeInvalidReference       = apierror.CodeInvalidReference
+	CodeAlreadyExists          = apierror.CodeAlreadyExists
+	CodeInUse                  = apierror.CodeInUse
+	CodeAccessDenied           = apierror.CodeAccessDenied
+	CodeAdminRequired          = apierror.CodeAdminRequired
+	CodeInvalidToken           = apierror.CodeInvalidToken
+	CodeInvalidCredentials     = apierror.CodeInvalidCredentials
+	CodeAccountDisabled        = apierror.CodeAccountDisabled
+	CodeEmailNotVerified       = apierror.CodeEmailNotVerified
+	CodeEmailTaken             = apierror.CodeEmailTaken
+	CodeFileTooLarge           = apierror.CodeFileTooLarge
+	CodeUnsupportedFileType    = apierror.CodeUnsupportedFileType
+	CodeProjectNotFound        = apierror.CodeProjectNotFound
+	CodeContractNotFound       = apierror.CodeContractNotFound
+	CodeEmployeeNotFound       = apierror.CodeEmployeeNotFound
+	CodeEstimateNotFound       = apierror.CodeEstimateNotFound
+	CodeExpenseNotFound        = apierror.CodeExpenseNotFound
+	CodePaymentNotFound        = apierror.CodePaymentNotFound
+	CodeWorkLogNotFound        = apierror.CodeWorkLogNotFound
+	CodeInvitationNotFound     = apierror.CodeInvitationNotFound
+	CodeMemberNotFound         = apierror.CodeMemberNotFound
+	CodeUserNotFound           = apierror.CodeUserNotFound
+	CodeExchangeRateNotFound   = apierror.CodeExchangeRateNotFound
+	CodePhotoNotFound          = apierror.CodePhotoNotFound
+	CodeContractRequired       = apierror.CodeContractRequired
+	CodeInvalidContract        = apierror.CodeInvalidContract
+	CodeAlreadyCheckedIn       = apierror.CodeAlreadyCheckedIn
+	CodeAlreadyCheckedOut      = apierror.CodeAlreadyCheckedOut
+	CodeWorkLogOverlap         = apierror.CodeWorkLogOverlap
+	CodeCheckOutBeforeIn       = apierror.CodeCheckOutBeforeIn
+	CodeStillCheckedIn         = apierror.CodeStillCheckedIn
+	CodeAlreadyApproved        = apierror.CodeAlreadyApproved
+	CodePaymentNotPending      = apierror.CodePaymentNotPending
+	CodeInvitationPending      = apierror.CodeInvitationPending
+	CodeInvitationExpired      = apierror.CodeInvitationExpired
+	CodeTransferPending        = apierror.CodeTransferPending
+	CodeTransferExpired        = apierror.CodeTransferExpired
+	CodeAlreadyMember          = apierror.CodeAlreadyMember
+	CodeSlugTaken              = apierror.CodeSlugTaken
+	CodeNotAssignedToProject   = apierror.CodeNotAssignedToProject
+	CodeExchangeRateMissing    = apierror.CodeExchangeRateMissing
+	CodeCurrencyLocked         = apierror.CodeCurrencyLocked
+	CodeInvalidIdempotencyKey  = apierror.CodeInvalidIdempotencyKey
+	CodeIdempotencyKeyReused   = apierror.CodeIdempotencyKeyReused
+	CodeRequestInProgress      = apierror.CodeRequestInProgress
+	CodeVersionConflict        = apierror.CodeVersionConflict
+	CodePersistedQueryNotFound = apierror.CodePersistedQueryNotFound
+	CodeQueryTooComplex        = apierror.CodeQueryTooComplex
+)
+
+// ParseAmount reads a decimal amount such as "1250.50". It must not be
+// negative or have more than two decimal places.
+func ParseAmount(s string) (Amount, error) {
+	return money.Parse(s)
+}
+
+// ParseCurrency reads an ISO 4217 currency code the API supports.
+func ParseCurrency(s string) (Currency, error) {
+	return money.ParseCurrency(s)
+}
+
+// ParseRate reads a positive exchange rate with at most eight decimal places.
+func ParseRate(s string) (Rate, error) {
+	return money.ParseRate(s)
+}
+
+// ParseZone reads an IANA time zone name.
+func ParseZone(s string) (Zone, error) {
+	return calendar.ParseZone(s)
+}
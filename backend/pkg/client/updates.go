@@ -0,0 +1,61 @@
+package client
+
+import "context"
+
+// ProjectUpdateWithPhotos is a project update as listed.
+type ProjectUpdateWithPhotos struct {
+	ID         string               `json:"id"`
+	ProjectID  string               `json:"project_id"`
+	UpdateType UpdateType           `json:"update_type"`
+	Content    string               `json:"content"`
+	CreatedBy  UpdateAuthor         `json:"created_by"`
+	Photos     []ProjectUpdatePhoto `json:"photos"`
+	CreatedAt  string               `json:"created_at"`
+}
+
+// UpdateAuthor is who posted a project update.
+type UpdateAuthor struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// CreateProjectUpdate posts a daily summary or weekly plan with optional
+// photos and returns the new update's ID.
+func (c *Client) CreateProjectUpdate(ctx context.Context, projectID string, updateType UpdateType, content string, photos ...*File) (string, error) {
+	f := newForm()
+	f.field("update_type", string(updateType))
+	f.field("content", content)
+	for _, photo := range photos {
+		f.file("photos[]", photo)
+	}
+
+	r, err := f.request("POST", pathf("/projects/%s/updates", projectID))
+	if err != nil {
+		return "", err
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(ctx, r, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// ListProjectUpdates returns one page of a project's updates. Filters:
+// contract_id, type.
+func (c *Client) ListProjectUpdates(ctx context.Context, projectID string, opts ListOptions) (*Page[ProjectUpdateWithPhotos], error) {
+	var page Page[ProjectUpdateWithPhotos]
+	if err := c.list(ctx, pathf("/projects/%s/updates", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListAllProjectUpdates returns every update on a project matching opts.
+func (c *Client) ListAllProjectUpdates(ctx context.Context, projectID string, opts ListOptions) ([]ProjectUpdateWithPhotos, error) {
+	return listAll(ctx, opts, func(ctx context.Context, opts ListOptions) (*Page[ProjectUpdateWithPhotos], error) {
+		return c.ListProjectUpdates(ctx, projectID, opts)
+	})
+}
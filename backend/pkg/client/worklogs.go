@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// WorkLogWithNames is a work log as listed.
+type WorkLogWithNames struct {
+	WorkLog
+	EmployeeName string `json:"employee_name"`
+	ProjectName  string `json:"project_name"`
+}
+
+// WorkLogDetail is a single work log.
+type WorkLogDetail struct {
+	WorkLog
+	EmployeeName string `json:"employee_name"`
+	ProjectTitle string `json:"project_title"`
+}
+
+// WeeklySummary is the caller's hours since the start of the week, Sunday
+// in their time zone.
+type WeeklySummary struct {
+	TotalHours float64   `json:"total_hours"`
+	WeekStart  time.Time `json:"week_start"`
+	TimeZone   Zone      `json:"time_zone"`
+}
+
+// EmployeeHours totals an employee's hours.
+type EmployeeHours struct {
+	EmployeeID   string  `json:"employee_id"`
+	EmployeeName string  `json:"employee_name"`
+	TotalHours   float64 `json:"total_hours"`
+}
+
+// ProjectHours totals the hours worked on a project.
+type ProjectHours struct {
+	ProjectID    string  `json:"project_id"`
+	ProjectTitle string  `json:"project_title"`
+	TotalHours   float64 `json:"total_hours"`
+}
+
+// Location is where a check-in or check-out happened.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// CheckIn starts a work log on a project. location may be nil.
+func (c *Client) CheckIn(ctx context.Context, projectID string, photo *File, location *Location) (*WorkLog, error) {
+	f := newForm()
+	f.field("project_id", projectID)
+	f.file("photo", photo)
+	writeLocation(f, location)
+
+	r, err := f.request("POST", "/work-logs/check-in")
+	if err != nil {
+		return nil, err
+	}
+
+	var workLog WorkLog
+	if err := c.do(ctx, r, &workLog); err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+// CheckOut ends a work log. location may be nil.
+func (c *Client) CheckOut(ctx context.Context, workLogID string, photo *File, location *Location) (*WorkLog, error) {
+	f := newForm()
+	f.field("work_log_id", workLogID)
+	f.file("photo", photo)
+	writeLocation(f, location)
+
+	r, err := f.request("POST", "/work-logs/check-out")
+	if err != nil {
+		return nil, err
+	}
+
+	var workLog WorkLog
+	if err := c.do(ctx, r, &workLog); err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+// ListWorkLogs returns one page of the work logs visible to the caller.
+// Filters: project_id, employee_id.
+func (c *Client) ListWorkLogs(ctx context.Context, opts ListOptions) (*Page[WorkLogWithNames], error) {
+	var page Page[WorkLogWithNames]
+	if err := c.list(ctx, "/work-logs", opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// ListAllWorkLogs returns every work log matching opts.
+func (c *Client) ListAllWorkLogs(ctx context.Context, opts ListOptions) ([]WorkLogWithNames, error) {
+	return listAll(ctx, opts, c.ListWorkLogs)
+}
+
+// ListProjectWorkLogs returns one page of a project's work logs. Filters:
+// contract_id, employee_id.
+func (c *Client) ListProjectWorkLogs(ctx context.Context, projectID string, opts ListOptions) (*Page[WorkLogWithNames], error) {
+	var page Page[WorkLogWithNames]
+	if err := c.list(ctx, pathf("/projects/%s/work-logs", projectID), opts, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetWorkLog returns a work log.
+func (c *Client) GetWorkLog(ctx context.Context, workLogID string) (*WorkLogDetail, error) {
+	var workLog WorkLogDetail
+	if err := c.do(ctx, &request{method: "GET", path: pathf("/work-logs/%s", workLogID)}, &workLog); err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+// ApproveWorkLog approves a completed work log.
+func (c *Client) ApproveWorkLog(ctx context.Context, workLogID string) (*WorkLog, error) {
+	var workLog WorkLog
+	if err := c.do(ctx, &request{method: "POST", path: pathf("/work-logs/%s/approve", workLogID)}, &workLog); err != nil {
+		return nil, err
+	}
+	return &workLog, nil
+}
+
+// WeeklySummary returns the hours worked this week.
+func (c *Client) WeeklySummary(ctx context.Context) (*WeeklySummary, error) {
+	var summary WeeklySummary
+	if err := c.do(ctx, &request{method: "GET", path: "/work-logs/summary/weekly"}, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// HoursByEmployee returns hours worked per employee.
+func (c *Client) HoursByEmployee(ctx context.Context) ([]EmployeeHours, error) {
+	var hours []EmployeeHours
+	if err := c.do(ctx, &request{method: "GET", path: "/work-logs/summary/by-employee"}, &hours); err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// HoursByProject returns hours worked per project.
+func (c *Client) HoursByProject(ctx context.Context) ([]ProjectHours, error) {
+	var hours []ProjectHours
+	if err := c.do(ctx, &request{method: "GET", path: "/work-logs/summary/by-project"}, &hours); err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+func writeLocation(f *form, location *Location) {
+	if location == nil {
+		return
+	}
+	f.field("latitude", strconv.FormatFloat(location.Latitude, 'f', -1, 64))
+	f.field("longitude", strconv.FormatFloat(location.Longitude, 'f', -1, 64))
+}
@@ -11,7 +11,7 @@ import {
 import { useRouter } from 'expo-router';
 import { Ionicons } from '@expo/vector-icons';
 import { useAuth } from '../../src/context/AuthContext';
-import api from '../../src/services/api';
+import { getAllPages } from '../../src/services/api';
 
 const COLORS = {
   primary: '#2563EB',
@@ -47,8 +47,8 @@ export default function ProjectsScreen() {
   const fetchProjects = async () => {
     try {
       setError('');
-      const response = await api.get('/projects');
-      setProjects(response.data || []);
+      const page = await getAllPages('/projects');
+      setProjects(page.data);
     } catch (err: any) {
       setError(err.response?.data?.error || 'Failed to load projects');
     } finally {
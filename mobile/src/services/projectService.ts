@@ -1,9 +1,9 @@
-import api from './api';
+import api, { getAllPages } from './api';
 
 const projectService = {
   getAllProjects: async () => {
-    const response = await api.get('/projects');
-    return response.data;
+    const page = await getAllPages('/projects');
+    return page.data;
   },
 
   getProjectById: async (id: string) => {
@@ -63,8 +63,8 @@ const projectService = {
   },
 
   getProjectPhotos: async (id: string) => {
-    const response = await api.get(`/projects/${id}/photos`);
-    return response.data;
+    const page = await getAllPages(`/projects/${id}/photos`);
+    return page.data;
   },
 
   uploadProjectPhoto: async (id: string, photoUri: string, caption?: string) => {
@@ -88,8 +88,8 @@ const projectService = {
   },
 
   getProjectUpdates: async (id: string) => {
-    const response = await api.get(`/projects/${id}/updates`);
-    return response.data;
+    const page = await getAllPages(`/projects/${id}/updates`);
+    return page.data;
   },
 
   createProjectUpdate: async (id: string, updateData: any) => {
@@ -126,13 +126,13 @@ const projectService = {
     if (filters.start_date) params.append('start_date', filters.start_date);
     if (filters.end_date) params.append('end_date', filters.end_date);
 
-    const response = await api.get(`/projects/${id}/expenses?${params.toString()}`);
-    return response.data;
+    const page = await getAllPages(`/projects/${id}/expenses?${params.toString()}`);
+    return { expenses: page.data, summary: page.summary };
   },
 
   getProjectWorkLogs: async (id: string) => {
-    const response = await api.get(`/projects/${id}/work-logs`);
-    return response.data;
+    const page = await getAllPages(`/projects/${id}/work-logs`);
+    return page.data;
   },
 };
 
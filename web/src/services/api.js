@@ -31,4 +31,22 @@ api.interceptors.response.use(
   }
 );
 
-export default api;
\ No newline at end of file
+// List endpoints are cursor-paginated. getAllPages follows next_cursor to the
+// last page and returns the first page's envelope with every row in data.
+export const getAllPages = async (url) => {
+  const separator = url.includes('?') ? '&' : '?';
+  const first = await api.get(`${url}${separator}limit=200`);
+  const page = { ...first.data, data: [...first.data.data] };
+
+  let cursor = first.data.next_cursor;
+  while (cursor) {
+    const next = await api.get(`${url}${separator}limit=200&cursor=${encodeURIComponent(cursor)}`);
+    page.data.push(...next.data.data);
+    cursor = next.data.next_cursor;
+  }
+
+  page.next_cursor = null;
+  return page;
+};
+
+export default api;
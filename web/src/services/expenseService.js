@@ -1,4 +1,4 @@
-import api from './api';
+import api, { getAllPages } from './api';
 
 const expenseService = {
   getProjectExpenses: async (projectId, filters = {}) => {
@@ -12,8 +12,8 @@ const expenseService = {
     const queryString = params.toString();
     const url = `/projects/${projectId}/expenses${queryString ? `?${queryString}` : ''}`;
 
-    const response = await api.get(url);
-    return response.data;
+    const page = await getAllPages(url);
+    return { expenses: page.data, summary: page.summary };
   },
 
   downloadExpensesExcel: async (projectId, filters = {}) => {
@@ -1,4 +1,4 @@
-import api from './api';
+import api, { getAllPages } from './api';
 
 const paymentService = {
   getProjectPayments: async (projectId, contractId = null) => {
@@ -8,8 +8,8 @@ const paymentService = {
     const queryString = params.toString();
     const url = `/projects/${projectId}/payments${queryString ? `?${queryString}` : ''}`;
 
-    const response = await api.get(url);
-    return response.data;
+    const page = await getAllPages(url);
+    return page.data;
   },
 
   downloadPaymentSummaryExcel: async (projectId, contractId = null) => {
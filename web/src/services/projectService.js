@@ -1,9 +1,9 @@
-import api from './api';
+import api, { getAllPages } from './api';
 
 const projectService = {
   getAllProjects: async () => {
-    const response = await api.get('/projects');
-    return { projects: response.data };
+    const page = await getAllPages('/projects');
+    return { projects: page.data };
   },
 
   getProjectById: async (id) => {
@@ -86,8 +86,8 @@ const projectService = {
     }
     const queryString = queryParams.toString();
     const url = `/projects/${id}/photos${queryString ? `?${queryString}` : ''}`;
-    const response = await api.get(url);
-    return { photos: response.data };
+    const page = await getAllPages(url);
+    return { photos: page.data };
   },
 
   uploadProjectPhoto: async (id, photoFile, caption, contractId) => {
@@ -115,8 +115,8 @@ const projectService = {
     }
     const queryString = queryParams.toString();
     const url = `/projects/${id}/updates${queryString ? `?${queryString}` : ''}`;
-    const response = await api.get(url);
-    return response.data;
+    const page = await getAllPages(url);
+    return page.data;
   },
 
   createProjectUpdate: async (id, updateData) => {
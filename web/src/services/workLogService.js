@@ -1,4 +1,4 @@
-import api from './api';
+import api, { getAllPages } from './api';
 
 const workLogService = {
   getWorkLogs: async (filters = {}) => {
@@ -18,8 +18,8 @@ const workLogService = {
         params.append('end_date', filters.endDate);
       }
 
-      const response = await api.get(`/work-logs?${params.toString()}`);
-      return response.data;
+      const page = await getAllPages(`/work-logs?${params.toString()}`);
+      return page.data;
     } catch (error) {
       throw error;
     }
@@ -33,8 +33,8 @@ const workLogService = {
       const queryString = params.toString();
       const url = `/projects/${projectId}/work-logs${queryString ? `?${queryString}` : ''}`;
 
-      const response = await api.get(url);
-      return response.data;
+      const page = await getAllPages(url);
+      return page.data;
     } catch (error) {
       throw error;
     }